@@ -0,0 +1,202 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+	"inet.af/wf"
+)
+
+// The machine-readable output formats of the list commands all share
+// the JSON encoding of the inet.af/wf types:
+//
+//   - json is an indented JSON array of objects.
+//   - ndjson is one JSON object per line.
+//   - yaml is a YAML sequence of the same objects.
+//   - csv has a header row of the objects' top-level keys, then one
+//     row per object. Strings, numbers and booleans are written as is;
+//     nested values, such as rule conditions, are written as JSON.
+//
+// Objects have every exported field of wf.Provider, wf.Layer,
+// wf.Sublayer, wf.Rule, wf.DropEvent and wf.NetEvent, under the Go
+// field name. GUIDs are written as their well-known name (for example
+// "ALE_AUTH_CONNECT_V4") when they have one, and as
+// "{XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX}" otherwise, or always as
+// GUIDs with --guids. Byte slices such as ProviderData are base64.
+// Layer fields are objects {"id": <GUID>, "type": <type>}, and rule
+// conditions are objects
+// {"field": <GUID>, "op": <op>, "type": <type>, "value": <value>},
+// where type says how to read value; see the inet.af/wf JSON
+// encoding for the list of types.
+
+// formats are the valid values of the --format flag.
+var formats = map[string]bool{
+	"text":   true,
+	"json":   true,
+	"ndjson": true,
+	"yaml":   true,
+	"csv":    true,
+}
+
+// textFormat reports whether the output format is the human-readable
+// text layout, after checking that the format is known.
+func textFormat() (bool, error) {
+	if !formats[*format] {
+		return false, fmt.Errorf("unknown output format %q", *format)
+	}
+	return *format == "text", nil
+}
+
+// writeObjects writes objs, which must be a slice, to stdout in the
+// machine-readable format selected by --format.
+func writeObjects(objs interface{}) error {
+	var elems []json.RawMessage
+	ids := wf.IDNames
+	if *guids {
+		ids = wf.IDGUIDs
+	}
+	v := reflect.ValueOf(objs)
+	for i := 0; i < v.Len(); i++ {
+		bs, err := wf.MarshalJSON(v.Index(i).Interface(), ids)
+		if err != nil {
+			return fmt.Errorf("encoding object %d: %w", i, err)
+		}
+		elems = append(elems, bs)
+	}
+
+	switch *format {
+	case "json":
+		if elems == nil {
+			elems = []json.RawMessage{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(elems)
+	case "ndjson":
+		for _, elem := range elems {
+			if _, err := fmt.Printf("%s\n", elem); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "yaml":
+		return writeYAML(os.Stdout, elems)
+	case "csv":
+		return writeCSV(os.Stdout, elems)
+	}
+	return fmt.Errorf("unknown output format %q", *format)
+}
+
+// writeYAML writes elems as a YAML sequence.
+func writeYAML(w io.Writer, elems []json.RawMessage) error {
+	if elems == nil {
+		elems = []json.RawMessage{}
+	}
+	bs, err := json.Marshal(elems)
+	if err != nil {
+		return err
+	}
+	// JSON is YAML, so decode it into a node tree to keep the key
+	// order, and drop the JSON styling so the encoder writes block
+	// style and only quotes strings that need it.
+	var doc yaml.Node
+	if err := yaml.Unmarshal(bs, &doc); err != nil {
+		return err
+	}
+	clearStyle(&doc)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func clearStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearStyle(c)
+	}
+}
+
+// writeCSV writes elems, which must all be JSON objects, as CSV.
+func writeCSV(w io.Writer, elems []json.RawMessage) error {
+	var (
+		header []string
+		rows   [][]string
+	)
+	for _, elem := range elems {
+		keys, vals, err := objectFields(elem)
+		if err != nil {
+			return err
+		}
+		if header == nil {
+			header = keys
+		}
+		rows = append(rows, vals)
+	}
+
+	cw := csv.NewWriter(w)
+	if header != nil {
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// objectFields returns the keys of the JSON object obj in order, and
+// their values as CSV cells.
+func objectFields(obj json.RawMessage) (keys, vals []string, err error) {
+	// Use a token decoder rather than a map, since maps lose the
+	// order of the struct fields.
+	dec := json.NewDecoder(bytes.NewReader(obj))
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("object %s is not a JSON object", obj)
+		}
+		var val json.RawMessage
+		if err := dec.Decode(&val); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+		vals = append(vals, csvCell(val))
+	}
+	return keys, vals, nil
+}
+
+// csvCell returns the CSV form of the JSON value v.
+func csvCell(v json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(v, &s); err == nil {
+		return s
+	}
+	if string(v) == "null" {
+		return ""
+	}
+	return string(v)
+}
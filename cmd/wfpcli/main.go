@@ -2,6 +2,9 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build windows
+// +build windows
+
 // wfpcli is a CLI tool for interacting with the Windows Filtering
 // Platform (WFP), aka the Windows firewall.
 package main
@@ -11,8 +14,10 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
 	"golang.org/x/sys/windows"
@@ -32,6 +37,7 @@ var (
 	providerDescription = addProviderFS.String("description", "", "Provider description")
 	providerPersistent  = addProviderFS.Bool("persistent", false, "Whether the provider is persistent")
 	providerServiceName = addProviderFS.String("service", "", "Service name")
+	providerSDDL        = addProviderFS.String("sddl", "", "Security descriptor of the provider, in SDDL form")
 	addProviderC        = &ffcli.Command{
 		Name:       "add-provider",
 		ShortUsage: "wfpcli add-provider",
@@ -42,11 +48,21 @@ var (
 
 	delProviderC = &ffcli.Command{
 		Name:       "del-provider",
-		ShortUsage: "wfpcli del-provider <guid>",
+		ShortUsage: "wfpcli del-provider <guid|name>",
 		ShortHelp:  "Delete WFP provider.",
 		Exec:       delProvider,
 	}
 
+	purgeProviderFS = flag.NewFlagSet("wfpcli purge-provider", flag.ExitOnError)
+	purgeDryRun     = purgeProviderFS.Bool("dry-run", false, "Only print what would be deleted")
+	purgeProviderC  = &ffcli.Command{
+		Name:       "purge-provider",
+		ShortUsage: "wfpcli purge-provider <guid|name>",
+		ShortHelp:  "Delete WFP provider and all the objects it owns.",
+		FlagSet:    purgeProviderFS,
+		Exec:       purgeProvider,
+	}
+
 	listLayersC = &ffcli.Command{
 		Name:       "list-layers",
 		ShortUsage: "wfpcli list-layers",
@@ -70,6 +86,7 @@ var (
 	sublayerPersistent  = addSublayerFS.Bool("persistent", false, "Whether the sublayer is persistent")
 	sublayerProvider    = addSublayerFS.String("provider", "", "Owner of the sublayer")
 	sublayerWeight      = addSublayerFS.Int("weight", 1, "Sublayer weight")
+	sublayerSDDL        = addSublayerFS.String("sddl", "", "Security descriptor of the sublayer, in SDDL form")
 	addSublayerC        = &ffcli.Command{
 		Name:       "add-sublayer",
 		ShortUsage: "wfpcli add-sublayer",
@@ -80,11 +97,18 @@ var (
 
 	delSublayerC = &ffcli.Command{
 		Name:       "del-sublayer",
-		ShortUsage: "wfpcli del-sublayer <guid>",
+		ShortUsage: "wfpcli del-sublayer <guid|name>",
 		ShortHelp:  "Delete WFP sublayer.",
 		Exec:       delSublayer,
 	}
 
+	listCalloutsC = &ffcli.Command{
+		Name:       "list-callouts",
+		ShortUsage: "wfpcli list-callouts",
+		ShortHelp:  "List WFP callouts, and the rules that invoke them.",
+		Exec:       listCallouts,
+	}
+
 	listRulesC = &ffcli.Command{
 		Name:       "list-rules",
 		ShortUsage: "wfpcli list-rules",
@@ -92,11 +116,99 @@ var (
 		Exec:       listRules,
 	}
 
-	listEventsC = &ffcli.Command{
+	addRuleFS       = flag.NewFlagSet("wfpcli add-rule", flag.ExitOnError)
+	ruleName        = addRuleFS.String("name", "", "Rule name")
+	ruleDescription = addRuleFS.String("description", "", "Rule description")
+	ruleLayer       = addRuleFS.String("layer", "", "Layer name or GUID")
+	ruleSublayer    = addRuleFS.String("sublayer", "", "Sublayer name or GUID")
+	ruleProvider    = addRuleFS.String("provider", "", "Owner of the rule")
+	ruleAction      = addRuleFS.String("action", "block", "Rule action: block or permit")
+	ruleWeight      = addRuleFS.Uint64("weight", 0, "Rule weight")
+	rulePersistent  = addRuleFS.Bool("persistent", false, "Whether the rule is persistent")
+	ruleSDDL        = addRuleFS.String("sddl", "", "Security descriptor of the rule, in SDDL form")
+	ruleConditions  conditions
+	addRuleC        = &ffcli.Command{
+		Name:       "add-rule",
+		ShortUsage: "wfpcli add-rule --layer <layer> --sublayer <sublayer> [--cond 'FIELD op value' ...]",
+		ShortHelp:  "Add WFP rule.",
+		LongHelp: strings.TrimSpace(`
+Each --cond is a condition of the form "FIELD op value", for example
+"IP_REMOTE_ADDRESS in 10.0.0.0/8" or "IP_PROTOCOL == tcp". FIELD is a
+field name from list-layers, op is one of ==, !=, <, <=, >, >=, in,
+F[all], F[any], F[none], pfx and !pfx, and value is parsed according
+to the field's type: a number, an IP address, CIDR prefix or range, a
+protocol name, condition flags such as IsLoopback|IsReauthorize, a MAC
+address, a SID or a string. "in" takes a range of the form "from-to",
+or a CIDR prefix for address fields. See wf.Schema.ParseMatch for the
+full syntax.`),
+		FlagSet: addRuleFS,
+		Exec:    addRule,
+	}
+
+	delRuleC = &ffcli.Command{
+		Name:       "del-rule",
+		ShortUsage: "wfpcli del-rule <guid>",
+		ShortHelp:  "Delete WFP rule.",
+		Exec:       delRule,
+	}
+
+	showSecurityC = &ffcli.Command{
+		Name:       "show-security",
+		ShortUsage: "wfpcli show-security <provider|sublayer|rule> <guid|name>",
+		ShortHelp:  "Print the security descriptor of a WFP object.",
+		Exec:       showSecurity,
+	}
+
+	setDACLC = &ffcli.Command{
+		Name:       "set-dacl",
+		ShortUsage: "wfpcli set-dacl <provider|sublayer|rule> <guid|name> <sddl>",
+		ShortHelp:  "Replace the DACL of a WFP object.",
+		LongHelp: strings.TrimSpace(`
+The DACL is taken from the SDDL security descriptor, for example
+"D:P(A;;GA;;;SY)" to only allow LocalSystem to access the object. The
+owner, group and SACL of the security descriptor are ignored.`),
+		Exec: setDACL,
+	}
+
+	listEventsFS    = flag.NewFlagSet("wfpcli list-events", flag.ExitOnError)
+	eventsAll       = listEventsFS.Bool("all", false, "List events of all types, not just drops")
+	eventsSince     = listEventsFS.String("since", "", "Only list events since this RFC 3339 time, or this long ago, such as 10m")
+	eventsUntil     = listEventsFS.String("until", "", "Only list events until this RFC 3339 time, or this long ago")
+	eventsFollow    = listEventsFS.Bool("follow", false, "After listing, print new events as they are recorded, until interrupted")
+	eventConditions conditions
+	listEventsC     = &ffcli.Command{
 		Name:       "list-events",
-		ShortUsage: "wfpcli list-events",
-		ShortHelp:  "List WFP drop events.",
-		Exec:       listEvents,
+		ShortUsage: "wfpcli list-events [--all] [--since <time>] [--until <time>] [--follow] [--cond 'FIELD op value' ...]",
+		ShortHelp:  "List WFP drop events, or events of all types with --all.",
+		LongHelp: strings.TrimSpace(`
+Each --cond is a condition of the form "FIELD op value" on the events,
+for example "IP_REMOTE_ADDRESS in 10.0.0.0/8" or "IP_PROTOCOL == udp".
+The fields are IP_PROTOCOL, IP_LOCAL_ADDRESS, IP_REMOTE_ADDRESS,
+IP_LOCAL_PORT, IP_REMOTE_PORT, ALE_APP_ID, ALE_USER_ID, ALE_PACKAGE_ID
+and NET_EVENT_TYPE. See add-rule for the syntax of values.
+
+With --follow, only the text and ndjson formats are supported, and
+--until is ignored for new events.`),
+		FlagSet: listEventsFS,
+		Exec:    listEvents,
+	}
+
+	watchRulesFS  = flag.NewFlagSet("wfpcli watch-rules", flag.ExitOnError)
+	watchProvider = watchRulesFS.String("provider", "", "Only report rules owned by this provider")
+	watchLayer    = watchRulesFS.String("layer", "", "Only report rules in this layer, by name or GUID")
+	watchRulesC   = &ffcli.Command{
+		Name:       "watch-rules",
+		ShortUsage: "wfpcli watch-rules [--provider <guid>] [--layer <layer>]",
+		ShortHelp:  "Print WFP rules as they are added and deleted, until interrupted.",
+		FlagSet:    watchRulesFS,
+		Exec:       watchRules,
+	}
+
+	explainDropsC = &ffcli.Command{
+		Name:       "explain-drops",
+		ShortUsage: "wfpcli explain-drops",
+		ShortHelp:  "List WFP drop events with the rules that caused them.",
+		Exec:       explainDrops,
 	}
 
 	testC = &ffcli.Command{
@@ -108,16 +220,33 @@ var (
 
 	rootFS  = flag.NewFlagSet("wfpcli", flag.ExitOnError)
 	dynamic = rootFS.Bool("dynamic", false, "Use a dynamic WFP session")
+	format  = rootFS.String("format", "text", "Output format of list commands: text, json (an indented array of objects), ndjson (one JSON object per line), yaml, or csv (one row per object, nested values as JSON). Objects have the exported fields of the inet.af/wf types, with IDs as well-known names where possible")
+	guids   = rootFS.Bool("guids", false, "Write IDs as GUIDs rather than well-known names in machine-readable formats")
 	root    = &ffcli.Command{
 		ShortUsage:  "wfpcli <subcommand>",
 		FlagSet:     rootFS,
-		Subcommands: []*ffcli.Command{listProvidersC, addProviderC, delProviderC, listLayersC, listSublayersC, addSublayerC, delSublayerC, listRulesC, listEventsC, testC},
+		Subcommands: []*ffcli.Command{listProvidersC, addProviderC, delProviderC, purgeProviderC, listLayersC, listSublayersC, addSublayerC, delSublayerC, listCalloutsC, listRulesC, addRuleC, delRuleC, showSecurityC, setDACLC, watchRulesC, listEventsC, explainDropsC, testC},
 		Exec: func(context.Context, []string) error {
 			return flag.ErrHelp
 		},
 	}
 )
 
+// conditions is a repeatable flag.Value of textual rule conditions.
+type conditions []string
+
+func (c *conditions) String() string { return strings.Join(*c, ", ") }
+
+func (c *conditions) Set(s string) error {
+	*c = append(*c, s)
+	return nil
+}
+
+func init() {
+	addRuleFS.Var(&ruleConditions, "cond", "Rule condition \"FIELD op value\", may be repeated")
+	listEventsFS.Var(&eventConditions, "cond", "Event condition \"FIELD op value\", may be repeated")
+}
+
 func main() {
 	if err := root.ParseAndRun(context.Background(), os.Args[1:]); err != nil {
 		fmt.Fprintf(os.Stderr, "%s", err)
@@ -160,6 +289,12 @@ func listProviders(_ context.Context, _ []string) error {
 		return fmt.Errorf("listing providers: %w", err)
 	}
 
+	if text, err := textFormat(); err != nil {
+		return err
+	} else if !text {
+		return writeObjects(providers)
+	}
+
 	for _, provider := range providers {
 		fmt.Printf("%s\n", displayName(provider.ID.String(), provider.Name))
 		fmt.Printf("  GUID: %s\n", provider.ID)
@@ -189,11 +324,12 @@ func addProvider(context.Context, []string) error {
 	defer sess.Close()
 
 	p := &wf.Provider{
-		ID:          wf.ProviderID(mustGUID()),
-		Name:        *providerName,
-		Description: *providerDescription,
-		Persistent:  *providerPersistent,
-		ServiceName: *providerServiceName,
+		ID:                 wf.ProviderID(mustGUID()),
+		Name:               *providerName,
+		Description:        *providerDescription,
+		Persistent:         *providerPersistent,
+		ServiceName:        *providerServiceName,
+		SecurityDescriptor: *providerSDDL,
 	}
 
 	if err := sess.AddProvider(p); err != nil {
@@ -211,7 +347,7 @@ func delProvider(_ context.Context, args []string) error {
 		return flag.ErrHelp
 	}
 
-	guid, err := windows.GUIDFromString(args[0])
+	id, err := wf.ParseProviderID(args[0])
 	if err != nil {
 		return fmt.Errorf("Parsing GUID: %w", err)
 	}
@@ -222,11 +358,45 @@ func delProvider(_ context.Context, args []string) error {
 	}
 	defer sess.Close()
 
-	if err := sess.DeleteProvider(wf.ProviderID(guid)); err != nil {
+	if err := sess.DeleteProvider(id); err != nil {
 		return fmt.Errorf("deleting provider: %w", err)
 	}
 
-	fmt.Printf("Deleted provider %s\n", guid)
+	fmt.Printf("Deleted provider %s\n", id)
+
+	return nil
+}
+
+func purgeProvider(_ context.Context, args []string) error {
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "GUID is required\n")
+		return flag.ErrHelp
+	}
+
+	id, err := wf.ParseProviderID(args[0])
+	if err != nil {
+		return fmt.Errorf("Parsing GUID: %w", err)
+	}
+
+	sess, err := session()
+	if err != nil {
+		return fmt.Errorf("creating WFP session: %w", err)
+	}
+	defer sess.Close()
+
+	plan, err := sess.PurgeProvider(id, *purgeDryRun)
+	if err != nil {
+		return fmt.Errorf("purging provider: %w", err)
+	}
+
+	for _, c := range plan.Changes {
+		fmt.Printf("%s\n", c)
+	}
+	if *purgeDryRun {
+		fmt.Printf("Would delete %d objects\n", len(plan.Changes))
+	} else {
+		fmt.Printf("Deleted %d objects\n", len(plan.Changes))
+	}
 
 	return nil
 }
@@ -243,6 +413,12 @@ func listLayers(_ context.Context, _ []string) error {
 		return fmt.Errorf("listing layers: %w", err)
 	}
 
+	if text, err := textFormat(); err != nil {
+		return err
+	} else if !text {
+		return writeObjects(layers)
+	}
+
 	for _, layer := range layers {
 		fmt.Printf("%s\n", displayName(layer.ID.String(), layer.Name))
 		fmt.Printf("  GUID: %s\n", layer.ID)
@@ -271,11 +447,14 @@ func listSublayers(_ context.Context, _ []string) error {
 
 	var providers []wf.ProviderID
 	for _, f := range strings.Split(*sublayerProviders, ",") {
-		guid, err := windows.GUIDFromString(f)
+		if f == "" {
+			continue
+		}
+		id, err := wf.ParseProviderID(f)
 		if err != nil {
 			return fmt.Errorf("parsing GUID %q: %v", f, err)
 		}
-		providers = append(providers, wf.ProviderID(guid))
+		providers = append(providers, id)
 	}
 
 	sublayers, err := sess.Sublayers(providers...)
@@ -283,6 +462,12 @@ func listSublayers(_ context.Context, _ []string) error {
 		return fmt.Errorf("listing WFP sublayers: %w", err)
 	}
 
+	if text, err := textFormat(); err != nil {
+		return err
+	} else if !text {
+		return writeObjects(sublayers)
+	}
+
 	for _, sublayer := range sublayers {
 		fmt.Printf("%s\n", displayName(sublayer.ID.String(), sublayer.Name))
 		fmt.Printf("  GUID: %s\n", sublayer.ID)
@@ -312,18 +497,19 @@ func addSublayer(_ context.Context, _ []string) error {
 	defer sess.Close()
 
 	sl := &wf.Sublayer{
-		ID:          wf.SublayerID(mustGUID()),
-		Name:        *sublayerName,
-		Description: *sublayerDescription,
-		Persistent:  *sublayerPersistent,
-		Weight:      uint16(*sublayerWeight),
+		ID:                 wf.SublayerID(mustGUID()),
+		Name:               *sublayerName,
+		Description:        *sublayerDescription,
+		Persistent:         *sublayerPersistent,
+		Weight:             uint16(*sublayerWeight),
+		SecurityDescriptor: *sublayerSDDL,
 	}
 	if *sublayerProvider != "" {
-		guid, err := windows.GUIDFromString(*sublayerProvider)
+		id, err := wf.ParseProviderID(*sublayerProvider)
 		if err != nil {
 			return fmt.Errorf("Parsing provider GUID: %w", err)
 		}
-		sl.Provider = wf.ProviderID(guid)
+		sl.Provider = id
 	}
 
 	if err := sess.AddSublayer(sl); err != nil {
@@ -340,7 +526,7 @@ func delSublayer(_ context.Context, args []string) error {
 		return flag.ErrHelp
 	}
 
-	guid, err := windows.GUIDFromString(args[0])
+	id, err := wf.ParseSublayerID(args[0])
 	if err != nil {
 		return fmt.Errorf("Parsing GUID: %w", err)
 	}
@@ -351,11 +537,77 @@ func delSublayer(_ context.Context, args []string) error {
 	}
 	defer sess.Close()
 
-	if err := sess.DeleteSublayer(wf.SublayerID(guid)); err != nil {
+	if err := sess.DeleteSublayer(id); err != nil {
 		return fmt.Errorf("deleting sublayer: %w", err)
 	}
 
-	fmt.Printf("Deleted sublayer %s\n", guid)
+	fmt.Printf("Deleted sublayer %s\n", id)
+
+	return nil
+}
+
+func listCallouts(context.Context, []string) error {
+	sess, err := session()
+	if err != nil {
+		return fmt.Errorf("creating WFP session: %w", err)
+	}
+	defer sess.Close()
+
+	callouts, err := sess.Callouts()
+	if err != nil {
+		return fmt.Errorf("listing WFP callouts: %w", err)
+	}
+
+	if text, err := textFormat(); err != nil {
+		return err
+	} else if !text {
+		return writeObjects(callouts)
+	}
+
+	rules, err := sess.Rules()
+	if err != nil {
+		return fmt.Errorf("getting rules: %w", err)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID.String() < rules[j].ID.String()
+	})
+	invokers := map[wf.CalloutID][]*wf.Rule{}
+	for _, rule := range rules {
+		if rule.Action&wf.Action(wf.ActionFlagCallout) != 0 {
+			invokers[rule.Callout] = append(invokers[rule.Callout], rule)
+		}
+	}
+
+	for _, callout := range callouts {
+		fmt.Printf("%s\n", displayName(callout.ID.String(), callout.Name))
+		fmt.Printf("  GUID: %s\n", callout.ID)
+		fmt.Printf("  Name: %q\n", callout.Name)
+		if callout.Description != "" {
+			fmt.Printf("  Description: %q\n", callout.Description)
+		}
+		fmt.Printf("  Layer: %s\n", callout.Layer)
+		fmt.Printf("  Persistent: %v\n", callout.Persistent)
+		if !callout.Provider.IsZero() {
+			fmt.Printf("  Provider: %s\n", callout.Provider)
+		}
+		if len(callout.ProviderData) > 0 {
+			fmt.Printf("  Provider data: %v\n", callout.ProviderData)
+		}
+		if callout.UsesProviderContext {
+			fmt.Printf("  Uses provider context: %v\n", callout.UsesProviderContext)
+		}
+		// An unregistered callout has no driver to call, so rules
+		// invoking it fall back to PermitIfMissing.
+		fmt.Printf("  Registered: %v\n", callout.Registered)
+		for _, rule := range invokers[callout.ID] {
+			fmt.Printf("  Rule: %s", displayName(rule.ID.String(), rule.Name))
+			if rule.Action == wf.ActionCalloutTerminating || rule.Action == wf.ActionCalloutUnknown {
+				fmt.Printf(" (permit if missing: %v)", rule.PermitIfMissing)
+			}
+			fmt.Printf("\n")
+		}
+		fmt.Printf("\n")
+	}
 
 	return nil
 }
@@ -376,6 +628,12 @@ func listRules(context.Context, []string) error {
 		return rules[i].ID.String() < rules[j].ID.String()
 	})
 
+	if text, err := textFormat(); err != nil {
+		return err
+	} else if !text {
+		return writeObjects(rules)
+	}
+
 	for _, rule := range rules {
 		fmt.Printf("%s\n", displayName(rule.ID.String(), rule.Name))
 		fmt.Printf("  GUID: %s\n", rule.ID)
@@ -393,6 +651,12 @@ func listRules(context.Context, []string) error {
 		if rule.Action == wf.ActionCalloutTerminating || rule.Action == wf.ActionCalloutUnknown {
 			fmt.Printf("  Permit if missing: %v\n", rule.PermitIfMissing)
 		}
+		if !rule.ProviderContext.IsZero() {
+			fmt.Printf("  Provider context: %s\n", rule.ProviderContext)
+		}
+		if rule.RawContext != 0 {
+			fmt.Printf("  Raw context: 0x%x\n", rule.RawContext)
+		}
 		fmt.Printf("  Persistent: %v\n", rule.Persistent)
 		fmt.Printf("  Boot-time: %v\n", rule.BootTime)
 		if !rule.Provider.IsZero() {
@@ -410,19 +674,493 @@ func listRules(context.Context, []string) error {
 	return nil
 }
 
-func listEvents(context.Context, []string) error {
+func addRule(context.Context, []string) error {
+	if *ruleLayer == "" || *ruleSublayer == "" {
+		fmt.Fprintf(os.Stderr, "--layer and --sublayer are required\n")
+		return flag.ErrHelp
+	}
+
+	sess, err := session()
+	if err != nil {
+		return fmt.Errorf("creating WFP session: %w", err)
+	}
+	defer sess.Close()
+
+	layerID, err := wf.ParseLayerID(*ruleLayer)
+	if err != nil {
+		return fmt.Errorf("parsing layer: %w", err)
+	}
+	layers, err := sess.Layers()
+	if err != nil {
+		return fmt.Errorf("listing layers: %w", err)
+	}
+	schema := wf.NewSchema(layers)
+	if schema.Layer(layerID) == nil {
+		return fmt.Errorf("unknown layer %s", layerID)
+	}
+
+	sublayerID, err := findSublayer(sess, *ruleSublayer)
+	if err != nil {
+		return err
+	}
+
+	r := &wf.Rule{
+		ID:                 wf.RuleID(mustGUID()),
+		Name:               *ruleName,
+		Description:        *ruleDescription,
+		Layer:              layerID,
+		Sublayer:           sublayerID,
+		Weight:             *ruleWeight,
+		Persistent:         *rulePersistent,
+		SecurityDescriptor: *ruleSDDL,
+	}
+	switch strings.ToLower(*ruleAction) {
+	case "block":
+		r.Action = wf.ActionBlock
+	case "permit":
+		r.Action = wf.ActionPermit
+	default:
+		return fmt.Errorf("unknown action %q", *ruleAction)
+	}
+	if *ruleProvider != "" {
+		id, err := wf.ParseProviderID(*ruleProvider)
+		if err != nil {
+			return fmt.Errorf("Parsing provider GUID: %w", err)
+		}
+		r.Provider = id
+	}
+	for _, c := range ruleConditions {
+		m, err := schema.ParseMatch(layerID, c)
+		if err != nil {
+			return err
+		}
+		r.Conditions = append(r.Conditions, m)
+	}
+
+	if err := wf.Validate(r, schema); err != nil {
+		return fmt.Errorf("invalid rule: %w", err)
+	}
+	if err := sess.AddRule(r); err != nil {
+		return fmt.Errorf("creating rule: %w", err)
+	}
+
+	fmt.Printf("Created rule %s\n", r.ID)
+	return nil
+}
+
+// findSublayer returns the ID of the sublayer named by s, which is
+// either a sublayer GUID, a well-known sublayer name such as
+// SUBLAYER_UNIVERSAL, or the Name of an existing sublayer.
+func findSublayer(sess *wf.Session, s string) (wf.SublayerID, error) {
+	id, err := wf.ParseSublayerID(s)
+	if err == nil {
+		return id, nil
+	}
+	sublayers, err := sess.Sublayers()
+	if err != nil {
+		return id, fmt.Errorf("listing sublayers: %w", err)
+	}
+	for _, sl := range sublayers {
+		if sl.Name == s {
+			return sl.ID, nil
+		}
+	}
+	return id, fmt.Errorf("unknown sublayer %q", s)
+}
+
+func delRule(_ context.Context, args []string) error {
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "GUID is required\n")
+		return flag.ErrHelp
+	}
+
+	guid, err := windows.GUIDFromString(args[0])
+	if err != nil {
+		return fmt.Errorf("Parsing GUID: %w", err)
+	}
+
+	sess, err := session()
+	if err != nil {
+		return fmt.Errorf("creating WFP session: %w", err)
+	}
+	defer sess.Close()
+
+	if err := sess.DeleteRule(wf.RuleID(guid)); err != nil {
+		return fmt.Errorf("deleting rule: %w", err)
+	}
+
+	fmt.Printf("Deleted rule %s\n", guid)
+
+	return nil
+}
+
+func showSecurity(_ context.Context, args []string) error {
+	if len(args) != 2 {
+		fmt.Fprintf(os.Stderr, "object type and GUID are required\n")
+		return flag.ErrHelp
+	}
+
+	sess, err := session()
+	if err != nil {
+		return fmt.Errorf("creating WFP session: %w", err)
+	}
+	defer sess.Close()
+
+	get, _, err := securityTarget(sess, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	sddl, err := get()
+	if err != nil {
+		return fmt.Errorf("getting security descriptor: %w", err)
+	}
+
+	fmt.Println(sddl)
+	return nil
+}
+
+func setDACL(_ context.Context, args []string) error {
+	if len(args) != 3 {
+		fmt.Fprintf(os.Stderr, "object type, GUID and security descriptor are required\n")
+		return flag.ErrHelp
+	}
+
+	sess, err := session()
+	if err != nil {
+		return fmt.Errorf("creating WFP session: %w", err)
+	}
+	defer sess.Close()
+
+	_, set, err := securityTarget(sess, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	if err := set(args[2]); err != nil {
+		return fmt.Errorf("setting DACL: %w", err)
+	}
+
+	fmt.Printf("Set DACL of %s %s\n", args[0], args[1])
+	return nil
+}
+
+// securityTarget returns functions that get the security descriptor
+// and set the DACL of the object of the given kind named by s.
+func securityTarget(sess *wf.Session, kind, s string) (get func() (string, error), set func(string) error, err error) {
+	switch kind {
+	case "provider":
+		id, err := wf.ParseProviderID(s)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Parsing GUID: %w", err)
+		}
+		get = func() (string, error) { return sess.ProviderSecurity(id) }
+		set = func(sddl string) error { return sess.SetProviderDACL(id, sddl) }
+	case "sublayer":
+		id, err := findSublayer(sess, s)
+		if err != nil {
+			return nil, nil, err
+		}
+		get = func() (string, error) { return sess.SublayerSecurity(id) }
+		set = func(sddl string) error { return sess.SetSublayerDACL(id, sddl) }
+	case "rule":
+		guid, err := windows.GUIDFromString(s)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Parsing GUID: %w", err)
+		}
+		id := wf.RuleID(guid)
+		get = func() (string, error) { return sess.RuleSecurity(id) }
+		set = func(sddl string) error { return sess.SetRuleDACL(id, sddl) }
+	default:
+		return nil, nil, fmt.Errorf("unknown object type %q, want provider, sublayer or rule", kind)
+	}
+	return get, set, nil
+}
+
+func watchRules(ctx context.Context, _ []string) error {
+	if *format != "text" && *format != "ndjson" {
+		return fmt.Errorf("watch-rules doesn't support format %q", *format)
+	}
+
+	filter := &wf.RuleWatchFilter{}
+	if *watchProvider != "" {
+		id, err := wf.ParseProviderID(*watchProvider)
+		if err != nil {
+			return fmt.Errorf("parsing provider: %w", err)
+		}
+		filter.Provider = &id
+	}
+	if *watchLayer != "" {
+		id, err := wf.ParseLayerID(*watchLayer)
+		if err != nil {
+			return fmt.Errorf("parsing layer: %w", err)
+		}
+		filter.Layer = id
+	}
+
+	sess, err := session()
+	if err != nil {
+		return fmt.Errorf("creating WFP session: %w", err)
+	}
+	defer sess.Close()
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	w, err := sess.WatchRules(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("watching rules: %w", err)
+	}
+	defer w.Close()
+
+	var lost uint64
+	for c := range w.Changes() {
+		if n := w.Lost(); n != lost {
+			fmt.Fprintf(os.Stderr, "Lost %d changes\n", n-lost)
+			lost = n
+		}
+		if *format == "ndjson" {
+			if err := writeObjects([]*wf.RuleChange{c}); err != nil {
+				return err
+			}
+			continue
+		}
+		name := c.ID.String()
+		if c.Rule != nil {
+			name = displayName(name, c.Rule.Name)
+		}
+		fmt.Printf("%s %s\n", c.Type, name)
+		fmt.Printf("  GUID: %s\n", c.ID)
+		fmt.Printf("  Kernel ID: %d\n", c.KernelID)
+		if c.Rule != nil {
+			fmt.Printf("  Layer: %s\n", c.Rule.Layer)
+			fmt.Printf("  Action: %s\n", c.Rule.Action)
+			fmt.Printf("  Provider: %s\n", c.Rule.Provider)
+		}
+		fmt.Printf("\n")
+	}
+	return nil
+}
+
+func listEvents(ctx context.Context, _ []string) error {
+	if *eventsFollow && *format != "text" && *format != "ndjson" {
+		return fmt.Errorf("--follow doesn't support format %q", *format)
+	}
+
 	sess, err := session()
 	if err != nil {
 		return fmt.Errorf("creating WFP session: %w", err)
 	}
 	defer sess.Close()
 
-	events, err := sess.DropEvents()
+	enum, err := eventEnumerator(sess)
+	if err != nil {
+		return err
+	}
+	if *eventsAll {
+		err = listNetEvents(enum)
+	} else {
+		err = listDropEvents(enum)
+	}
+	if err != nil || !*eventsFollow {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	return followEvents(ctx, sess)
+}
+
+func listDropEvents(enum wf.NetEventEnumerator) error {
+	events, err := enum.DropEvents()
+	if err != nil {
+		return fmt.Errorf("getting events: %w", err)
+	}
+
+	if text, err := textFormat(); err != nil {
+		return err
+	} else if !text {
+		return writeObjects(events)
+	}
+
+	for _, event := range events {
+		printDropEvent(event)
+	}
+	fmt.Printf("Dumped %d events\n", len(events))
+	return nil
+}
+
+func printDropEvent(event *wf.DropEvent) {
+	fmt.Printf("%s\n", event.Timestamp)
+	fmt.Printf("  Protocol: %d\n", event.IPProtocol)
+	fmt.Printf("  Local addr: %s\n", event.LocalAddr)
+	fmt.Printf("  Remote addr: %s\n", event.RemoteAddr)
+	if event.AppID != "" {
+		fmt.Printf("  App ID: %s\n", event.AppID)
+	}
+	fmt.Printf("  Layer ID: %d\n", event.LayerID)
+	fmt.Printf("  Filter ID: %d\n", event.FilterID)
+	fmt.Printf("\n")
+}
+
+// followEvents prints the events that the list-events flags select as
+// they are recorded, until ctx is done.
+func followEvents(ctx context.Context, sess *wf.Session) error {
+	filter := &wf.NetEventFilter{}
+	for _, c := range eventConditions {
+		m, err := wf.ParseNetEventMatch(c)
+		if err != nil {
+			return err
+		}
+		filter.Conditions = append(filter.Conditions, m)
+	}
+	if !*eventsAll {
+		filter.Conditions = append(filter.Conditions, &wf.Match{
+			Field: wf.FieldNetEventType,
+			Op:    wf.MatchTypeEqual,
+			Value: uint32(wf.NetEventTypeClassifyDrop),
+		})
+	}
+
+	sub, err := sess.SubscribeNetEvents(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("subscribing to events: %w", err)
+	}
+	defer sub.Close()
+
+	var lost uint64
+	for event := range sub.Events() {
+		if n := sub.Lost(); n != lost {
+			fmt.Fprintf(os.Stderr, "Lost %d events\n", n-lost)
+			lost = n
+		}
+		var obj interface{} = event
+		if !*eventsAll {
+			obj = event.DropEvent()
+		}
+		if *format == "ndjson" {
+			if err := writeObjects([]interface{}{obj}); err != nil {
+				return err
+			}
+			continue
+		}
+		if *eventsAll {
+			printNetEvent(event)
+		} else {
+			printDropEvent(event.DropEvent())
+		}
+	}
+	return nil
+}
+
+// eventEnumerator returns an enumerator of the events that the
+// list-events flags select.
+func eventEnumerator(sess *wf.Session) (wf.NetEventEnumerator, error) {
+	enum := sess.EnumerateNetEvents()
+
+	now := time.Now()
+	since, err := parseEventTime(*eventsSince, now)
+	if err != nil {
+		return enum, fmt.Errorf("parsing --since: %w", err)
+	}
+	until, err := parseEventTime(*eventsUntil, now)
+	if err != nil {
+		return enum, fmt.Errorf("parsing --until: %w", err)
+	}
+	enum = enum.WithTimeRange(since, until)
+
+	var ms []*wf.Match
+	for _, c := range eventConditions {
+		m, err := wf.ParseNetEventMatch(c)
+		if err != nil {
+			return enum, err
+		}
+		ms = append(ms, m)
+	}
+	return enum.WithConditions(ms), nil
+}
+
+// parseEventTime parses s as an RFC 3339 time, or as a duration
+// before now. An empty s is the zero time.
+func parseEventTime(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func listNetEvents(enum wf.NetEventEnumerator) error {
+	events, err := enum.Execute()
 	if err != nil {
 		return fmt.Errorf("getting events: %w", err)
 	}
 
+	if text, err := textFormat(); err != nil {
+		return err
+	} else if !text {
+		return writeObjects(events)
+	}
+
 	for _, event := range events {
+		printNetEvent(event)
+	}
+	fmt.Printf("Dumped %d events\n", len(events))
+	return nil
+}
+
+func printNetEvent(event *wf.NetEvent) {
+	fmt.Printf("%s %s\n", event.Timestamp, event.Type)
+	fmt.Printf("  Protocol: %d\n", event.IPProtocol)
+	fmt.Printf("  Local addr: %s\n", event.LocalAddr)
+	fmt.Printf("  Remote addr: %s\n", event.RemoteAddr)
+	if event.AppID != "" {
+		fmt.Printf("  App ID: %s\n", event.AppID)
+	}
+	if event.UserID != "" {
+		fmt.Printf("  User ID: %s\n", event.UserID)
+	}
+	if event.PackageID != "" {
+		fmt.Printf("  Package ID: %s\n", event.PackageID)
+	}
+	switch {
+	case event.Classify != nil:
+		fmt.Printf("  Layer ID: %d\n", event.Classify.LayerID)
+		fmt.Printf("  Filter ID: %d\n", event.Classify.FilterID)
+		fmt.Printf("  Direction: %s\n", event.Classify.Direction)
+		fmt.Printf("  Loopback: %v\n", event.Classify.Loopback)
+	case event.Capability != nil:
+		fmt.Printf("  Capability: %d\n", event.Capability.Capability)
+		fmt.Printf("  Filter ID: %d\n", event.Capability.FilterID)
+	case event.IKEFailure != nil:
+		fmt.Printf("  Error code: %d\n", event.IKEFailure.ErrorCode)
+		fmt.Printf("  Keying module: %d\n", event.IKEFailure.KeyingModule)
+		fmt.Printf("  Filter ID: %d\n", event.IKEFailure.FilterID)
+	case event.IPsecDrop != nil:
+		fmt.Printf("  Status: %#x\n", event.IPsecDrop.FailureStatus)
+		fmt.Printf("  SPI: %#x\n", event.IPsecDrop.SPI)
+		fmt.Printf("  Filter ID: %d\n", event.IPsecDrop.FilterID)
+	case event.DoSPDrop != nil:
+		fmt.Printf("  Status: %#x\n", event.DoSPDrop.FailureStatus)
+		fmt.Printf("  Public host: %s\n", event.DoSPDrop.PublicHostAddr)
+		fmt.Printf("  Internal host: %s\n", event.DoSPDrop.InternalHostAddr)
+	}
+	fmt.Printf("\n")
+}
+
+func explainDrops(context.Context, []string) error {
+	sess, err := session()
+	if err != nil {
+		return fmt.Errorf("creating WFP session: %w", err)
+	}
+	defer sess.Close()
+
+	exps, err := sess.ExplainDrops()
+	if err != nil {
+		return fmt.Errorf("explaining events: %w", err)
+	}
+
+	for _, exp := range exps {
+		event := exp.Event
 		fmt.Printf("%s\n", event.Timestamp)
 		fmt.Printf("  Protocol: %d\n", event.IPProtocol)
 		fmt.Printf("  Local addr: %s\n", event.LocalAddr)
@@ -430,11 +1168,36 @@ func listEvents(context.Context, []string) error {
 		if event.AppID != "" {
 			fmt.Printf("  App ID: %s\n", event.AppID)
 		}
-		fmt.Printf("  Layer ID: %d\n", event.LayerID)
-		fmt.Printf("  Filter ID: %d\n", event.FilterID)
+		if exp.Layer != nil {
+			fmt.Printf("  Layer: %s\n", exp.Layer.ID)
+		} else {
+			fmt.Printf("  Layer ID: %d (unknown)\n", event.LayerID)
+		}
+		switch rule := exp.Rule; {
+		case rule != nil:
+			fmt.Printf("  Rule: %s\n", displayName(rule.ID.String(), rule.Name))
+			fmt.Printf("    GUID: %s\n", rule.ID)
+			fmt.Printf("    Filter ID: %d\n", event.FilterID)
+			fmt.Printf("    Action: %s\n", rule.Action)
+			if exp.Sublayer != nil {
+				fmt.Printf("    Sublayer: %s\n", displayName(exp.Sublayer.ID.String(), exp.Sublayer.Name))
+			} else {
+				fmt.Printf("    Sublayer: %s\n", rule.Sublayer)
+			}
+			if exp.Provider != nil {
+				fmt.Printf("    Provider: %s\n", displayName(exp.Provider.ID.String(), exp.Provider.Name))
+			} else if !rule.Provider.IsZero() {
+				fmt.Printf("    Provider: %s\n", rule.Provider)
+			}
+			for _, cond := range rule.Conditions {
+				fmt.Printf("    Condition: %s\n", cond)
+			}
+		case exp.RuleMissing():
+			fmt.Printf("  Filter ID: %d (rule no longer exists)\n", event.FilterID)
+		}
 		fmt.Printf("\n")
 	}
-	fmt.Printf("Dumped %d events\n", len(events))
+	fmt.Printf("Explained %d events\n", len(exps))
 	return nil
 }
 
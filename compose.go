@@ -2,6 +2,9 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build windows
+// +build windows
+
 package wf
 
 import (
@@ -42,6 +45,85 @@ func toSublayerEnumTemplate0(a *arena, provider ProviderID) *fwpmSublayerEnumTem
 	return ret
 }
 
+// toFilterEnumTemplate0 returns an arena-allocated
+// fwpmFilterEnumTemplate0 equivalent to tpl, or nil if tpl is nil.
+func toFilterEnumTemplate0(a *arena, tpl *RuleEnumTemplate, lt layerTypes) (*fwpmFilterEnumTemplate0, error) {
+	if tpl == nil {
+		return nil, nil
+	}
+
+	ret := (*fwpmFilterEnumTemplate0)(a.Alloc(unsafe.Sizeof(fwpmFilterEnumTemplate0{})))
+	*ret = fwpmFilterEnumTemplate0{
+		LayerKey:   tpl.Layer,
+		EnumType:   tpl.EnumType,
+		Flags:      tpl.Flags,
+		ActionMask: tpl.ActionMask,
+	}
+	if tpl.Provider != nil {
+		ret.ProviderKey = (*ProviderID)(a.Alloc(unsafe.Sizeof(ProviderID{})))
+		*ret.ProviderKey = *tpl.Provider
+	}
+	if tpl.ProviderContext != nil {
+		ctx := (*fwpmProviderContextEnumTemplate0)(a.Alloc(unsafe.Sizeof(fwpmProviderContextEnumTemplate0{})))
+		ctx.ProviderContextType = tpl.ProviderContext.Type
+		if tpl.ProviderContext.Provider != nil {
+			ctx.ProviderKey = (*ProviderID)(a.Alloc(unsafe.Sizeof(ProviderID{})))
+			*ctx.ProviderKey = *tpl.ProviderContext.Provider
+		}
+		ret.ProviderContextTemplate = ctx
+	}
+	if tpl.Callout != nil {
+		ret.CalloutKey = (*CalloutID)(a.Alloc(unsafe.Sizeof(CalloutID{})))
+		*ret.CalloutKey = *tpl.Callout
+	}
+	if len(tpl.Conditions) > 0 {
+		ft, ok := lt[tpl.Layer]
+		if !ok {
+			return nil, fmt.Errorf("enumerating rules by condition requires a known layer, got %s", tpl.Layer)
+		}
+		conds, err := toCondition0(a, tpl.Conditions, ft)
+		if err != nil {
+			return nil, err
+		}
+		ret.NumConditions = uint32(len(tpl.Conditions))
+		ret.Conditions = conds
+	}
+
+	return ret, nil
+}
+
+// maxFiletime is the latest time that a FILETIME can hold, which
+// leaves the end of a net event enumeration unbounded.
+var maxFiletime = windows.Filetime{LowDateTime: 0xffffffff, HighDateTime: 0x7fffffff}
+
+// toNetEventEnumTemplate0 returns the C equivalent of tpl.
+func toNetEventEnumTemplate0(a *arena, tpl *NetEventEnumTemplate) (*fwpmNetEventEnumTemplate0, error) {
+	if tpl == nil {
+		return nil, nil
+	}
+
+	ret := (*fwpmNetEventEnumTemplate0)(a.Alloc(unsafe.Sizeof(fwpmNetEventEnumTemplate0{})))
+	*ret = fwpmNetEventEnumTemplate0{
+		EndTime: maxFiletime,
+	}
+	if !tpl.StartTime.IsZero() {
+		ret.StartTime = windows.NsecToFiletime(tpl.StartTime.UnixNano())
+	}
+	if !tpl.EndTime.IsZero() {
+		ret.EndTime = windows.NsecToFiletime(tpl.EndTime.UnixNano())
+	}
+	if len(tpl.Conditions) > 0 {
+		conds, err := toCondition0(a, tpl.Conditions, netEventFieldTypes)
+		if err != nil {
+			return nil, err
+		}
+		ret.NumConditions = uint32(len(tpl.Conditions))
+		ret.Conditions = conds
+	}
+
+	return ret, nil
+}
+
 // toSublayer0 converts sl into an arena-allocated fwpmSublayer0.
 func toSublayer0(a *arena, sl *Sublayer) *fwpmSublayer0 {
 	ret := (*fwpmSublayer0)(a.Alloc(unsafe.Sizeof(fwpmSublayer0{})))
@@ -65,6 +147,63 @@ func toSublayer0(a *arena, sl *Sublayer) *fwpmSublayer0 {
 	return ret
 }
 
+// toCallout0 converts c into an arena-allocated fwpmCallout0.
+func toCallout0(a *arena, c *Callout) *fwpmCallout0 {
+	ret := (*fwpmCallout0)(a.Alloc(unsafe.Sizeof(fwpmCallout0{})))
+	*ret = fwpmCallout0{
+		CalloutKey: c.ID,
+		DisplayData: fwpmDisplayData0{
+			Name:        toUint16(a, c.Name),
+			Description: toUint16(a, c.Description),
+		},
+		ProviderKey: toGUID(a, windows.GUID(c.Provider)),
+		ProviderData: fwpByteBlob{
+			Size: uint32(len(c.ProviderData)),
+			Data: toBytes(a, c.ProviderData),
+		},
+		ApplicableLayer: c.Layer,
+	}
+	if c.Persistent {
+		ret.Flags |= fwpmCalloutFlagsPersistent
+	}
+	if c.UsesProviderContext {
+		ret.Flags |= fwpmCalloutFlagsUsesProviderContext
+	}
+
+	return ret
+}
+
+// toProviderContext0 converts pc into an arena-allocated
+// fwpmProviderContext0.
+func toProviderContext0(a *arena, pc *ProviderContext) *fwpmProviderContext0 {
+	ret := (*fwpmProviderContext0)(a.Alloc(unsafe.Sizeof(fwpmProviderContext0{})))
+	*ret = fwpmProviderContext0{
+		ProviderContextKey: pc.ID,
+		DisplayData: fwpmDisplayData0{
+			Name:        toUint16(a, pc.Name),
+			Description: toUint16(a, pc.Description),
+		},
+		ProviderKey: toGUID(a, windows.GUID(pc.Provider)),
+		ProviderData: fwpByteBlob{
+			Size: uint32(len(pc.ProviderData)),
+			Data: toBytes(a, pc.ProviderData),
+		},
+		Type: pc.Type,
+	}
+	if pc.Persistent {
+		ret.Flags = fwpmProviderContextFlagsPersistent
+	}
+	if pc.Type == ProviderContextGeneral {
+		ret.DataBuffer = (*fwpByteBlob)(a.Alloc(unsafe.Sizeof(fwpByteBlob{})))
+		*ret.DataBuffer = fwpByteBlob{
+			Size: uint32(len(pc.Data)),
+			Data: toBytes(a, pc.Data),
+		}
+	}
+
+	return ret
+}
+
 // toProvider0 converts p into an arena-allocated fwpmProvider0.
 func toProvider0(a *arena, p *Provider) *fwpmProvider0 {
 	ret := (*fwpmProvider0)(a.Alloc(unsafe.Sizeof(fwpmProvider0{})))
@@ -138,6 +277,12 @@ func toFilter0(a *arena, r *Rule, lt layerTypes) (*fwpmFilter0, error) {
 	if r.BootTime {
 		ret.Flags |= fwpmFilterFlagsBootTime
 	}
+	if !r.ProviderContext.IsZero() {
+		ret.Flags |= fwpmFilterFlagsHasProviderContext
+		*ret.providerContextKey() = r.ProviderContext
+	} else {
+		*ret.rawContext() = r.RawContext
+	}
 
 	return ret, nil
 }
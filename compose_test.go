@@ -0,0 +1,96 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package wf
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFilter0Context(t *testing.T) {
+	pcID := ProviderContextID{0x01020304, 0x0506, 0x0708, [8]byte{9, 10, 11, 12, 13, 14, 15, 16}}
+	tests := []struct {
+		name      string
+		rule      *Rule
+		wantFlags fwpmFilterFlags
+	}{
+		{
+			name: "none",
+			rule: &Rule{},
+		},
+		{
+			name: "raw",
+			rule: &Rule{RawContext: 0x1122334455667788},
+		},
+		{
+			name:      "provider_context",
+			rule:      &Rule{ProviderContext: pcID},
+			wantFlags: fwpmFilterFlagsHasProviderContext,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var a arena
+			defer a.Dispose()
+
+			r := *test.rule
+			r.ID = RuleID{Data1: 1}
+			r.Layer = LayerALEAuthConnectV4
+			r.Action = ActionCalloutInspection
+			r.Callout = CalloutID{Data1: 2}
+
+			f, err := toFilter0(&a, &r, defaultSchema.types)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.Flags & fwpmFilterFlagsHasProviderContext; got != test.wantFlags {
+				t.Errorf("HasProviderContext flag = %v, want %v", got, test.wantFlags)
+			}
+			if test.wantFlags == 0 && *f.rawContext() != r.RawContext {
+				t.Errorf("rawContext = %#x, want %#x", *f.rawContext(), r.RawContext)
+			}
+			if test.wantFlags != 0 && *f.providerContextKey() != r.ProviderContext {
+				t.Errorf("providerContextKey = %s, want %s", *f.providerContextKey(), r.ProviderContext)
+			}
+
+			rules, err := fromFilter0(&f, 1, defaultSchema.types)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rules) != 1 {
+				t.Fatalf("got %d rules, want 1", len(rules))
+			}
+			if diff := cmp.Diff(rules[0], &r); diff != "" {
+				t.Errorf("round trip changed rule (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProviderContext0(t *testing.T) {
+	var a arena
+	defer a.Dispose()
+
+	pc := &ProviderContext{
+		ID:           ProviderContextID{Data1: 1},
+		Name:         "context",
+		Description:  "test context",
+		Persistent:   true,
+		Provider:     ProviderID{Data1: 2},
+		ProviderData: []byte("provider data"),
+		Type:         ProviderContextGeneral,
+		Data:         []byte("callout configuration"),
+	}
+	pc0 := toProviderContext0(&a, pc)
+	got := fromProviderContext0(&pc0, 1)
+	if diff := cmp.Diff(got, []*ProviderContext{pc}); diff != "" {
+		t.Errorf("round trip changed provider context (-got+want):\n%s", diff)
+	}
+}
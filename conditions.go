@@ -0,0 +1,212 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"net/netip"
+	"reflect"
+
+	"go4.org/netipx"
+)
+
+// FilterRules returns the rules whose conditions relate to conds in
+// the way typ asks for, like a RuleEnumerator with WithType and
+// WithConditions does in the filtering engine. It is the offline
+// equivalent, for rule lists that were saved earlier or built in
+// memory.
+//
+// Conditions describe a set of packets: conditions on the same field
+// are ORed, and conditions on different fields are ANDed. With
+// FilterEnumTypeOverlapping, a rule is returned if its set and the
+// set of conds may have a packet in common. With
+// FilterEnumTypeFullyContained, a rule is returned if its set is a
+// subset of the set of conds, which means that it must have
+// conditions on every field in conds, each within one of conds.
+//
+// Equality, range and ordering matches are compared as intervals of
+// field values. Other matches, such as flag tests, prefixes of
+// strings and !=, are assumed to overlap anything on the same
+// field, and only contain themselves.
+func FilterRules(rules []*Rule, typ FilterEnumType, conds []*Match) []*Rule {
+	var ret []*Rule
+	for _, r := range rules {
+		if conditionsSelected(r.Conditions, typ, conds) {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
+
+// conditionsSelected reports whether a rule with conditions ms is
+// selected by an enumeration of type typ with conditions tpl.
+func conditionsSelected(ms []*Match, typ FilterEnumType, tpl []*Match) bool {
+	if len(tpl) == 0 {
+		return true
+	}
+	byField := map[FieldID][]*Match{}
+	for _, m := range ms {
+		byField[m.Field] = append(byField[m.Field], m)
+	}
+	tplByField := map[FieldID][]*Match{}
+	for _, t := range tpl {
+		tplByField[t.Field] = append(tplByField[t.Field], t)
+	}
+
+	for field, ts := range tplByField {
+		rs := byField[field]
+		if typ == FilterEnumTypeFullyContained {
+			if len(rs) == 0 {
+				// The rule matches any value of field.
+				return false
+			}
+			for _, r := range rs {
+				if !anyContains(ts, r) {
+					return false
+				}
+			}
+			continue
+		}
+		if len(rs) > 0 && !anyOverlaps(ts, rs) {
+			return false
+		}
+	}
+	return true
+}
+
+// anyContains reports whether one of ts contains the values that m
+// matches.
+func anyContains(ts []*Match, m *Match) bool {
+	mi, mok := matchInterval(m)
+	for _, t := range ts {
+		ti, tok := matchInterval(t)
+		if mok && tok {
+			if ti.contains(mi) {
+				return true
+			}
+		} else if reflect.DeepEqual(t, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// anyOverlaps reports whether one of ts may match a value that one of
+// ms also matches.
+func anyOverlaps(ts, ms []*Match) bool {
+	for _, t := range ts {
+		ti, tok := matchInterval(t)
+		for _, m := range ms {
+			mi, mok := matchInterval(m)
+			if !tok || !mok || ti.overlaps(mi) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// A condInterval is the set of field values between lo and hi. A nil
+// bound is unbounded, and an open bound excludes its value.
+type condInterval struct {
+	lo, hi         interface{}
+	loOpen, hiOpen bool
+}
+
+// matchInterval returns the interval of field values that m
+// matches, and whether m can be expressed as one.
+func matchInterval(m *Match) (condInterval, bool) {
+	switch m.Op {
+	case MatchTypeEqual, MatchTypeRange:
+		switch v := m.Value.(type) {
+		case Range:
+			return condInterval{lo: v.From, hi: v.To}, true
+		case netipx.IPRange:
+			return condInterval{lo: v.From(), hi: v.To()}, true
+		case netip.Prefix:
+			r := netipx.RangeOfPrefix(v.Masked())
+			return condInterval{lo: r.From(), hi: r.To()}, true
+		}
+		if m.Op == MatchTypeEqual {
+			if _, ok := simCompare(m.Value, m.Value); ok {
+				return condInterval{lo: m.Value, hi: m.Value}, true
+			}
+		}
+	case MatchTypeGreater, MatchTypeGreaterOrEqual:
+		if _, ok := simCompare(m.Value, m.Value); ok {
+			return condInterval{lo: m.Value, loOpen: m.Op == MatchTypeGreater}, true
+		}
+	case MatchTypeLess, MatchTypeLessOrEqual:
+		if _, ok := simCompare(m.Value, m.Value); ok {
+			return condInterval{hi: m.Value, hiOpen: m.Op == MatchTypeLess}, true
+		}
+	}
+	return condInterval{}, false
+}
+
+// overlaps reports whether a and b have a value in common. Intervals
+// of values that can't be compared, such as IPv4 and IPv6 addresses,
+// don't overlap.
+func (a condInterval) overlaps(b condInterval) bool {
+	return a.below(b) && b.below(a)
+}
+
+// below reports whether a starts no later than b ends, so that a and
+// b overlap if b also starts no later than a ends.
+func (a condInterval) below(b condInterval) bool {
+	if a.lo == nil || b.hi == nil {
+		return a.comparable(b)
+	}
+	c, ok := simCompare(a.lo, b.hi)
+	if !ok {
+		return false
+	}
+	if a.loOpen || b.hiOpen {
+		return c < 0
+	}
+	return c <= 0
+}
+
+// contains reports whether every value in b is also in a.
+func (a condInterval) contains(b condInterval) bool {
+	if !a.comparable(b) {
+		return false
+	}
+	if a.lo != nil {
+		if b.lo == nil {
+			return false
+		}
+		c, _ := simCompare(a.lo, b.lo)
+		if c > 0 || (c == 0 && a.loOpen && !b.loOpen) {
+			return false
+		}
+	}
+	if a.hi != nil {
+		if b.hi == nil {
+			return false
+		}
+		c, _ := simCompare(a.hi, b.hi)
+		if c < 0 || (c == 0 && a.hiOpen && !b.hiOpen) {
+			return false
+		}
+	}
+	return true
+}
+
+// comparable reports whether the bounds of a and b are all of
+// comparable types.
+func (a condInterval) comparable(b condInterval) bool {
+	var bounds []interface{}
+	for _, v := range []interface{}{a.lo, a.hi, b.lo, b.hi} {
+		if v != nil {
+			bounds = append(bounds, v)
+		}
+	}
+	for _, v := range bounds {
+		if _, ok := simCompare(bounds[0], v); !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go4.org/netipx"
+)
+
+func TestFilterRules(t *testing.T) {
+	rule := func(n uint32, ms ...*Match) *Rule {
+		return &Rule{ID: RuleID{Data1: n}, Layer: LayerALEAuthConnectV4, Conditions: ms}
+	}
+	rules := []*Rule{
+		// Matches everything.
+		rule(1),
+		// Exactly the template's traffic.
+		rule(2,
+			&Match{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParseAddr("1.2.3.4")},
+			&Match{FieldIPRemotePort, MatchTypeEqual, uint16(443)},
+			&Match{FieldIPProtocol, MatchTypeEqual, IPProtoTCP}),
+		// A subnet containing the address, on any port.
+		rule(3, &Match{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParsePrefix("1.2.0.0/16")}),
+		// A different subnet.
+		rule(4, &Match{FieldIPRemoteAddress, MatchTypeRange, netipx.MustParseIPRange("10.0.0.0-10.255.255.255")}),
+		// Port ranges on either side of 443.
+		rule(5, &Match{FieldIPRemotePort, MatchTypeRange, Range{uint16(400), uint16(500)}}),
+		rule(6, &Match{FieldIPRemotePort, MatchTypeGreater, uint16(443)}),
+		// UDP or TCP: conditions on the same field are ORed.
+		rule(7,
+			&Match{FieldIPProtocol, MatchTypeEqual, IPProtoUDP},
+			&Match{FieldIPProtocol, MatchTypeEqual, IPProtoTCP}),
+		// Only UDP.
+		rule(8, &Match{FieldIPProtocol, MatchTypeEqual, IPProtoUDP}),
+		// A flag test can't be compared, so it may overlap.
+		rule(9, &Match{FieldFlags, MatchTypeFlagsAllSet, ConditionFlagIsLoopback}),
+		// An IPv6 address never overlaps an IPv4 one.
+		rule(10, &Match{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParseAddr("::1")}),
+	}
+
+	tests := []struct {
+		name  string
+		typ   FilterEnumType
+		conds []*Match
+		want  []uint32
+	}{
+		{
+			name: "no conditions",
+			typ:  FilterEnumTypeFullyContained,
+			want: []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		{
+			name: "overlapping address, port and protocol",
+			typ:  FilterEnumTypeOverlapping,
+			conds: []*Match{
+				{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParseAddr("1.2.3.4")},
+				{FieldIPRemotePort, MatchTypeEqual, uint16(443)},
+				{FieldIPProtocol, MatchTypeEqual, IPProtoTCP},
+			},
+			want: []uint32{1, 2, 3, 5, 7, 9},
+		},
+		{
+			name: "fully contained in a subnet",
+			typ:  FilterEnumTypeFullyContained,
+			conds: []*Match{
+				{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParsePrefix("1.0.0.0/8")},
+			},
+			want: []uint32{2, 3},
+		},
+		{
+			name: "fully contained in a port range",
+			typ:  FilterEnumTypeFullyContained,
+			conds: []*Match{
+				{FieldIPRemotePort, MatchTypeGreaterOrEqual, uint16(443)},
+			},
+			want: []uint32{2, 6},
+		},
+		{
+			name: "fully contained in either protocol",
+			typ:  FilterEnumTypeFullyContained,
+			conds: []*Match{
+				{FieldIPProtocol, MatchTypeEqual, IPProtoTCP},
+				{FieldIPProtocol, MatchTypeEqual, IPProtoUDP},
+			},
+			want: []uint32{2, 7, 8},
+		},
+		{
+			name: "fully contained uncomparable match",
+			typ:  FilterEnumTypeFullyContained,
+			conds: []*Match{
+				{FieldFlags, MatchTypeFlagsAllSet, ConditionFlagIsLoopback},
+			},
+			want: []uint32{9},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []uint32
+			for _, r := range FilterRules(rules, test.typ, test.conds) {
+				got = append(got, r.ID.Data1)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Fatalf("wrong rules (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnumerateRulesWithConditions(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(testLayers), nil)
+	defer s.Close()
+
+	rules := []*Rule{
+		{ID: RuleID{Data1: 1}, Layer: LayerALEAuthConnectV4, Action: ActionBlock, Conditions: []*Match{
+			{FieldIPRemotePort, MatchTypeEqual, uint16(443)},
+		}},
+		{ID: RuleID{Data1: 2}, Layer: LayerALEAuthConnectV4, Action: ActionBlock, Conditions: []*Match{
+			{FieldIPRemotePort, MatchTypeEqual, uint16(80)},
+		}},
+		{ID: RuleID{Data1: 3}, Layer: LayerALEAuthConnectV4, Action: ActionPermit},
+	}
+	for _, r := range rules {
+		if err := s.AddRule(r); err != nil {
+			t.Fatalf("adding rule: %v", err)
+		}
+	}
+
+	conds := []*Match{{FieldIPRemotePort, MatchTypeRange, Range{uint16(400), uint16(500)}}}
+	for _, test := range []struct {
+		typ  FilterEnumType
+		want []uint32
+	}{
+		{FilterEnumTypeOverlapping, []uint32{1, 3}},
+		{FilterEnumTypeFullyContained, []uint32{1}},
+	} {
+		got, err := s.EnumerateRules(test.typ, LayerALEAuthConnectV4).WithActionMask(ActionFlagIgnore).WithConditions(conds).Execute()
+		if err != nil {
+			t.Fatalf("enumerating rules: %v", err)
+		}
+		var ids []uint32
+		for _, r := range got {
+			ids = append(ids, r.ID.Data1)
+		}
+		if diff := cmp.Diff(ids, test.want); diff != "" {
+			t.Errorf("enum type %d returned wrong rules (-got+want):\n%s", test.typ, diff)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"unicode/utf16"
+)
+
+type dataType uint32
+
+const (
+	dataTypeEmpty                  dataType = 0
+	dataTypeUint8                  dataType = 1
+	dataTypeUint16                 dataType = 2
+	dataTypeUint32                 dataType = 3
+	dataTypeUint64                 dataType = 4
+	dataTypeByteArray16            dataType = 11
+	dataTypeByteBlob               dataType = 12
+	dataTypeSID                    dataType = 13
+	dataTypeSecurityDescriptor     dataType = 14
+	dataTypeTokenInformation       dataType = 15
+	dataTypeTokenAccessInformation dataType = 16
+	dataTypeArray6                 dataType = 18
+	dataTypeBitmapIndex            dataType = 19
+	dataTypeV4AddrMask             dataType = 256
+	dataTypeV6AddrMask             dataType = 257
+	dataTypeRange                  dataType = 258
+)
+
+// Types not implemented, because WFP doesn't seem to use them.
+// dataTypeInt8 dataType = 5
+// dataTypeInt16 dataType = 6
+// dataTypeInt32 dataType = 7
+// dataTypeInt64 dataType = 8
+// dataTypeFloat dataType = 9
+// dataTypeDouble dataType = 10
+// dataTypeUnicodeString dataType = 17
+// dataTypeBitmapArray64 dataType = 20
+
+type fwpIPVersion uint32
+
+const (
+	fwpIPVersion4 fwpIPVersion = 0
+	fwpIPVersion6 fwpIPVersion = 1
+)
+
+// A memReader reads the memory of C structs returned by the filtering
+// engine. On Windows it reads the process's memory directly. In
+// tests, it reads byte fixtures, so that the decoders built on it can
+// run on any platform.
+type memReader interface {
+	// read returns the n bytes at addr. The returned slice may
+	// alias the memory, and must not be retained.
+	read(addr uint64, n int) ([]byte, error)
+	// ptrSize returns the size of a pointer in bytes, 4 or 8.
+	ptrSize() int
+}
+
+// errNilPointer is returned when a memReader is asked to read at
+// address 0.
+var errNilPointer = errors.New("read through nil pointer")
+
+// A cStruct decodes the fields of the C struct at addr, one at a time
+// and in declaration order. Each field is aligned the way the Windows
+// C compiler aligns it, so that the same field list decodes both 32-
+// and 64-bit layouts.
+//
+// The first read error is sticky: later reads return zero values, and
+// the error is available in err.
+type cStruct struct {
+	mem  memReader
+	addr uint64
+	off  uint64
+	err  error
+}
+
+// align advances the read offset to the next multiple of n.
+func (c *cStruct) align(n int) {
+	c.off = (c.off + uint64(n) - 1) &^ (uint64(n) - 1)
+}
+
+// field returns the n bytes of the next field, which is aligned to
+// align bytes.
+func (c *cStruct) field(n, align int) []byte {
+	c.align(align)
+	if c.err != nil {
+		return make([]byte, n)
+	}
+	b, err := c.mem.read(c.addr+c.off, n)
+	if err != nil {
+		c.err = err
+		return make([]byte, n)
+	}
+	c.off += uint64(n)
+	return b
+}
+
+func (c *cStruct) u8() uint8 {
+	return c.field(1, 1)[0]
+}
+
+func (c *cStruct) u16() uint16 {
+	return binary.LittleEndian.Uint16(c.field(2, 2))
+}
+
+func (c *cStruct) u32() uint32 {
+	return binary.LittleEndian.Uint32(c.field(4, 4))
+}
+
+func (c *cStruct) u64() uint64 {
+	return binary.LittleEndian.Uint64(c.field(8, 8))
+}
+
+// ptr returns the value of the next pointer field.
+func (c *cStruct) ptr() uint64 {
+	n := c.mem.ptrSize()
+	b := c.field(n, n)
+	if n == 4 {
+		return uint64(binary.LittleEndian.Uint32(b))
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+// guid returns the value of the next GUID field.
+func (c *cStruct) guid() GUID {
+	var ret GUID
+	ret.Data1 = c.u32()
+	ret.Data2 = c.u16()
+	ret.Data3 = c.u16()
+	copy(ret.Data4[:], c.field(8, 1))
+	return ret
+}
+
+// bytes returns a copy of the next n bytes, which are aligned to
+// align bytes.
+func (c *cStruct) bytes(n, align int) []byte {
+	return append([]byte(nil), c.field(n, align)...)
+}
+
+// blob returns a copy of the contents of the next FWP_BYTE_BLOB
+// field.
+func (c *cStruct) blob() []byte {
+	c.align(c.mem.ptrSize())
+	n := c.u32()
+	p := c.ptr()
+	if c.err != nil || n == 0 {
+		return nil
+	}
+	b, err := c.mem.read(p, int(n))
+	if err != nil {
+		c.err = err
+		return nil
+	}
+	return append([]byte(nil), b...)
+}
+
+// blobString returns the contents of the next FWP_BYTE_BLOB field,
+// which holds a NUL-terminated UTF-16 string.
+func (c *cStruct) blobString() string {
+	b := c.blob()
+	if c.err == nil && len(b)%2 != 0 {
+		c.err = errors.New("byte blob should be string, but has odd number of bytes")
+	}
+	if c.err != nil {
+		return ""
+	}
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	for i, r := range u {
+		if r == 0 {
+			u = u[:i]
+			break
+		}
+	}
+	return string(utf16.Decode(u))
+}
+
+// str returns the NUL-terminated UTF-16 string that the next field
+// points to, or "" if the pointer is nil.
+func (c *cStruct) str() string {
+	p := c.ptr()
+	if c.err != nil || p == 0 {
+		return ""
+	}
+	s, err := readWideString(c.mem, p)
+	if err != nil {
+		c.err = err
+	}
+	return s
+}
+
+// strs returns the n strings in the array of string pointers that the
+// next field points to.
+func (c *cStruct) strs(n uint32) []string {
+	p := c.ptr()
+	if c.err != nil || p == 0 || n == 0 {
+		return nil
+	}
+	arr := &cStruct{mem: c.mem, addr: p}
+	var ret []string
+	for i := uint32(0); i < n; i++ {
+		ret = append(ret, arr.str())
+	}
+	if arr.err != nil {
+		c.err = arr.err
+		return nil
+	}
+	return ret
+}
+
+// sid returns the string form of the SID that the next field points
+// to, or "" if the pointer is nil.
+func (c *cStruct) sid() string {
+	p := c.ptr()
+	if c.err != nil || p == 0 {
+		return ""
+	}
+	s, err := readSID(c.mem, p)
+	if err != nil {
+		c.err = err
+	}
+	return s
+}
+
+// maxWideString is the length beyond which readWideString gives up
+// looking for a string's terminator.
+const maxWideString = 1 << 15
+
+// readWideString reads the NUL-terminated UTF-16 string at addr.
+func readWideString(mem memReader, addr uint64) (string, error) {
+	var u []uint16
+	for {
+		if len(u) == maxWideString {
+			return "", fmt.Errorf("string at %#x is not terminated", addr)
+		}
+		b, err := mem.read(addr+2*uint64(len(u)), 2)
+		if err != nil {
+			return "", err
+		}
+		r := binary.LittleEndian.Uint16(b)
+		if r == 0 {
+			return string(utf16.Decode(u)), nil
+		}
+		u = append(u, r)
+	}
+}
+
+// readSID reads the binary SID at addr, and returns it in string
+// form, such as "S-1-5-18".
+func readSID(mem memReader, addr uint64) (string, error) {
+	hdr, err := mem.read(addr, 8)
+	if err != nil {
+		return "", err
+	}
+	rev, n := hdr[0], int(hdr[1])
+	var auth uint64
+	for _, b := range hdr[2:8] {
+		auth = auth<<8 | uint64(b)
+	}
+	subs, err := mem.read(addr+8, 4*n)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "S-%d-", rev)
+	if auth >= 1<<32 {
+		fmt.Fprintf(&sb, "0x%012X", auth)
+	} else {
+		fmt.Fprintf(&sb, "%d", auth)
+	}
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "-%d", binary.LittleEndian.Uint32(subs[4*i:]))
+	}
+	return sb.String(), nil
+}
+
+func ipv4From32(v uint32) netip.Addr {
+	return netip.AddrFrom4([4]byte{uint8(v >> 24), uint8(v >> 16), uint8(v >> 8), uint8(v)})
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import "context"
+
+// enumPageSize is the number of objects that enumerations fetch from
+// the filtering engine at a time.
+const enumPageSize = 100
+
+// An Engine is a connection to a filtering engine. Session uses an
+// Engine for all of its operations, which lets code built on Session
+// run against something other than the local Windows Filtering
+// Platform, such as a MemoryEngine in tests.
+//
+// Engine methods report failures as the syscall.Errno values that WFP
+// itself would return, such as ProviderNotFound or AlreadyExists.
+// Argument validation that doesn't need the engine's state (e.g. zero
+// IDs) is done by Session before the Engine is called.
+//
+// The Walk methods enumerate objects a page at a time, calling fn
+// with each page. They stop and return the error if fn returns one,
+// or if ctx is done before the next page is fetched, and always
+// release the engine's enumeration handle before returning.
+type Engine interface {
+	// Close closes the connection to the engine. If the session
+	// is dynamic, objects created during it are removed.
+	Close() error
+
+	// Layers returns all layers known to the engine.
+	Layers() ([]*Layer, error)
+	// WalkLayers calls fn with successive pages of the layers
+	// known to the engine.
+	WalkLayers(ctx context.Context, fn func([]*Layer) error) error
+
+	// Providers returns all providers.
+	Providers() ([]*Provider, error)
+	// WalkProviders calls fn with successive pages of providers.
+	WalkProviders(ctx context.Context, fn func([]*Provider) error) error
+	// AddProvider creates a new provider.
+	AddProvider(p *Provider) error
+	// DeleteProvider deletes the provider whose ID is id.
+	DeleteProvider(id ProviderID) error
+	// ProviderSecurity returns the owner, group and DACL of the
+	// provider whose ID is id, as an SDDL security descriptor.
+	ProviderSecurity(id ProviderID) (string, error)
+	// SetProviderDACL replaces the DACL of the provider whose ID
+	// is id with the DACL of the SDDL security descriptor sddl.
+	SetProviderDACL(id ProviderID, sddl string) error
+
+	// Sublayers returns the sublayers owned by provider, or all
+	// sublayers if provider is zero.
+	Sublayers(provider ProviderID) ([]*Sublayer, error)
+	// WalkSublayers calls fn with successive pages of the
+	// sublayers owned by provider, or of all sublayers if provider
+	// is zero.
+	WalkSublayers(ctx context.Context, provider ProviderID, fn func([]*Sublayer) error) error
+	// AddSublayer creates a new sublayer.
+	AddSublayer(sl *Sublayer) error
+	// DeleteSublayer deletes the sublayer whose ID is id.
+	DeleteSublayer(id SublayerID) error
+	// SublayerSecurity returns the owner, group and DACL of the
+	// sublayer whose ID is id, as an SDDL security descriptor.
+	SublayerSecurity(id SublayerID) (string, error)
+	// SetSublayerDACL replaces the DACL of the sublayer whose ID
+	// is id with the DACL of the SDDL security descriptor sddl.
+	SetSublayerDACL(id SublayerID, sddl string) error
+
+	// Callouts returns all callouts.
+	Callouts() ([]*Callout, error)
+	// WalkCallouts calls fn with successive pages of callouts.
+	WalkCallouts(ctx context.Context, fn func([]*Callout) error) error
+	// AddCallout creates a new callout.
+	AddCallout(c *Callout) error
+	// DeleteCallout deletes the callout whose ID is id.
+	DeleteCallout(id CalloutID) error
+
+	// ProviderContexts returns all provider contexts.
+	ProviderContexts() ([]*ProviderContext, error)
+	// AddProviderContext creates a new provider context.
+	AddProviderContext(pc *ProviderContext) error
+	// DeleteProviderContext deletes the provider context whose ID
+	// is id.
+	DeleteProviderContext(id ProviderContextID) error
+
+	// Rules returns the rules that match tpl, or all rules if tpl
+	// is nil.
+	Rules(tpl *RuleEnumTemplate) ([]*Rule, error)
+	// WalkRules calls fn with successive pages of the rules that
+	// match tpl, or of all rules if tpl is nil.
+	WalkRules(ctx context.Context, tpl *RuleEnumTemplate, fn func([]*Rule) error) error
+	// AddRule creates a new rule.
+	AddRule(r *Rule) error
+	// DeleteRule deletes the rule whose ID is id.
+	DeleteRule(id RuleID) error
+	// RuleSecurity returns the owner, group and DACL of the rule
+	// whose ID is id, as an SDDL security descriptor.
+	RuleSecurity(id RuleID) (string, error)
+	// SetRuleDACL replaces the DACL of the rule whose ID is id
+	// with the DACL of the SDDL security descriptor sddl.
+	SetRuleDACL(id RuleID, sddl string) error
+	// SubscribeRuleChanges arranges for deliver to be called
+	// with each addition or deletion of a rule that matches tpl's
+	// Provider and Layer, or of any rule if tpl is nil. The Rule
+	// of added rules is set if the rule still exists when deliver
+	// is called. deliver is called with nil for a change that was
+	// lost. It must not block. The returned function ends the
+	// subscription; once it returns, deliver is no longer called.
+	SubscribeRuleChanges(tpl *RuleEnumTemplate, deliver func(*RuleChange)) (unsubscribe func() error, err error)
+
+	// NetEvents returns the events recorded by the engine that
+	// match tpl, or all events if tpl is nil.
+	NetEvents(tpl *NetEventEnumTemplate) ([]*NetEvent, error)
+	// WalkNetEvents calls fn with successive pages of the events
+	// recorded by the engine that match tpl, or of all events if
+	// tpl is nil.
+	WalkNetEvents(ctx context.Context, tpl *NetEventEnumTemplate, fn func([]*NetEvent) error) error
+	// SubscribeNetEvents arranges for deliver to be called with
+	// each event that matches tpl's conditions, or with every
+	// event if tpl is nil, as the engine records it. deliver is
+	// called with nil for an event that couldn't be decoded. It
+	// must not block. The returned function ends the
+	// subscription; once it returns, deliver is no longer called.
+	SubscribeNetEvents(tpl *NetEventEnumTemplate, deliver func(*NetEvent)) (unsubscribe func() error, err error)
+
+	// BeginTransaction starts an explicit transaction.
+	BeginTransaction(flags TransactionFlag) error
+	// CommitTransaction commits the current transaction.
+	CommitTransaction() error
+	// AbortTransaction aborts the current transaction, discarding
+	// all changes made since BeginTransaction.
+	AbortTransaction() error
+}
+
+// A Backend opens connections to a filtering engine.
+type Backend interface {
+	// Open opens a new connection to the engine. The Engine must
+	// honor opts.Dynamic and opts.TransactionStartTimeout.
+	Open(opts *Options) (Engine, error)
+}
+
+// RuleEnumTemplate restricts the rules returned by Engine.Rules.
+type RuleEnumTemplate struct {
+	// Provider, if non-nil, limits the results to rules owned by
+	// that provider.
+	Provider *ProviderID
+	// Layer, if non-zero, limits the results to rules in that layer.
+	Layer LayerID
+	// EnumType selects how rule conditions are compared to the
+	// template's conditions.
+	EnumType FilterEnumType
+	// Conditions, if non-empty, limits the results to rules whose
+	// conditions are fully contained in or overlap these, according
+	// to EnumType. See FilterRules for the semantics. Conditions
+	// require a Layer.
+	Conditions []*Match
+	// Flags further restrict or sort the results.
+	Flags FilterEnumFlags
+	// ProviderContext, if non-nil, limits the results to rules that
+	// reference a matching provider context.
+	ProviderContext *ProviderContextEnumTemplate
+	// ActionMask limits the results to rules whose action has at
+	// least one of the given flags set. ActionFlagIgnore matches
+	// all rules.
+	ActionMask ActionFlag
+	// Callout, if non-nil, limits the results to rules that invoke
+	// that callout.
+	Callout *CalloutID
+}
+
+// ProviderContextEnumTemplate restricts an enumeration to objects
+// that reference a matching provider context.
+type ProviderContextEnumTemplate struct {
+	// Provider, if non-nil, limits the results to provider contexts
+	// owned by that provider.
+	Provider *ProviderID
+	// Type is the type of provider context to match.
+	Type uint32
+}
@@ -0,0 +1,691 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"context"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// winBackend is the Backend for the local Windows Filtering Platform.
+type winBackend struct{}
+
+// winEngine is an Engine backed by a handle to the local Windows
+// Filtering Platform.
+type winEngine struct {
+	handle windows.Handle
+	// layerTypes is a map of layer ID -> field ID -> Go type for that field.
+	layerTypes layerTypes
+}
+
+// Open implements Backend.
+func (winBackend) Open(opts *Options) (Engine, error) {
+	var a arena
+	defer a.Dispose()
+
+	s0 := toSession0(&a, opts)
+
+	var handle windows.Handle
+	err := fwpmEngineOpen0(nil, authnServiceWinNT, nil, s0, &handle)
+	if err != nil {
+		return nil, err
+	}
+	ret := &winEngine{
+		handle:     handle,
+		layerTypes: layerTypes{},
+	}
+
+	if opts.Schema != nil {
+		ret.layerTypes = opts.Schema.types
+		return ret, nil
+	}
+
+	// Populate the layer type cache, starting from the static schema
+	// so that layers the engine doesn't report can still be used.
+	for id, fields := range defaultSchema.types {
+		ret.layerTypes[id] = fields
+	}
+	layers, err := ret.Layers()
+	if err != nil {
+		ret.Close()
+		return nil, err
+	}
+	for _, layer := range layers {
+		fields := fieldTypes{}
+		for _, field := range layer.Fields {
+			fields[field.ID] = field.Type
+		}
+		ret.layerTypes[layer.ID] = fields
+	}
+
+	return ret, nil
+}
+
+func (e *winEngine) Close() error {
+	if e.handle == 0 {
+		return nil
+	}
+	err := fwpmEngineClose0(e.handle)
+	e.handle = 0
+	return err
+}
+
+func (e *winEngine) Layers() ([]*Layer, error) {
+	var ret []*Layer
+	err := e.WalkLayers(context.Background(), func(layers []*Layer) error {
+		ret = append(ret, layers...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (e *winEngine) WalkLayers(ctx context.Context, fn func([]*Layer) error) error {
+	var enum windows.Handle
+	if err := fwpmLayerCreateEnumHandle0(e.handle, nil, &enum); err != nil {
+		return err
+	}
+	defer fwpmLayerDestroyEnumHandle0(e.handle, enum)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		layers, err := e.getLayerPage(enum)
+		if err != nil {
+			return err
+		}
+		if len(layers) == 0 {
+			return nil
+		}
+		if err := fn(layers); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *winEngine) getLayerPage(enum windows.Handle) ([]*Layer, error) {
+	var (
+		array **fwpmLayer0
+		num   uint32
+	)
+	if err := fwpmLayerEnum0(e.handle, enum, enumPageSize, &array, &num); err != nil {
+		return nil, err
+	}
+	if num == 0 {
+		return nil, nil
+	}
+	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&array)))
+
+	return fromLayer0(array, num)
+}
+
+func (e *winEngine) Sublayers(provider ProviderID) ([]*Sublayer, error) {
+	var ret []*Sublayer
+	err := e.WalkSublayers(context.Background(), provider, func(sublayers []*Sublayer) error {
+		ret = append(ret, sublayers...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (e *winEngine) WalkSublayers(ctx context.Context, provider ProviderID, fn func([]*Sublayer) error) error {
+	var a arena
+	defer a.Dispose()
+
+	tpl := toSublayerEnumTemplate0(&a, provider)
+
+	var enum windows.Handle
+	if err := fwpmSubLayerCreateEnumHandle0(e.handle, tpl, &enum); err != nil {
+		return err
+	}
+	defer fwpmSubLayerDestroyEnumHandle0(e.handle, enum)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		sublayers, err := e.getSublayerPage(enum)
+		if err != nil {
+			return err
+		}
+		if len(sublayers) == 0 {
+			return nil
+		}
+		if err := fn(sublayers); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *winEngine) getSublayerPage(enum windows.Handle) ([]*Sublayer, error) {
+	var (
+		array **fwpmSublayer0
+		num   uint32
+	)
+	if err := fwpmSubLayerEnum0(e.handle, enum, enumPageSize, &array, &num); err != nil {
+		return nil, err
+	}
+	if num == 0 {
+		return nil, nil
+	}
+	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&array)))
+
+	return fromSublayer0(array, num), nil
+}
+
+func (e *winEngine) AddSublayer(sl *Sublayer) error {
+	var a arena
+	defer a.Dispose()
+
+	sd, err := fromSDDL(sl.SecurityDescriptor)
+	if err != nil {
+		return err
+	}
+	sl0 := toSublayer0(&a, sl)
+	return fwpmSubLayerAdd0(e.handle, sl0, sd)
+}
+
+func (e *winEngine) DeleteSublayer(id SublayerID) error {
+	return fwpmSubLayerDeleteByKey0(e.handle, &id)
+}
+
+func (e *winEngine) Callouts() ([]*Callout, error) {
+	var ret []*Callout
+	err := e.WalkCallouts(context.Background(), func(callouts []*Callout) error {
+		ret = append(ret, callouts...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (e *winEngine) WalkCallouts(ctx context.Context, fn func([]*Callout) error) error {
+	var enum windows.Handle
+	if err := fwpmCalloutCreateEnumHandle0(e.handle, nil, &enum); err != nil {
+		return err
+	}
+	defer fwpmCalloutDestroyEnumHandle0(e.handle, enum)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		callouts, err := e.getCalloutPage(enum)
+		if err != nil {
+			return err
+		}
+		if len(callouts) == 0 {
+			return nil
+		}
+		if err := fn(callouts); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *winEngine) getCalloutPage(enum windows.Handle) ([]*Callout, error) {
+	var (
+		array **fwpmCallout0
+		num   uint32
+	)
+	if err := fwpmCalloutEnum0(e.handle, enum, enumPageSize, &array, &num); err != nil {
+		return nil, err
+	}
+	if num == 0 {
+		return nil, nil
+	}
+	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&array)))
+
+	return fromCallout0(array, num), nil
+}
+
+func (e *winEngine) AddCallout(c *Callout) error {
+	var a arena
+	defer a.Dispose()
+
+	c0 := toCallout0(&a, c)
+	sd, err := fromSDDL(c.SecurityDescriptor)
+	if err != nil {
+		return err
+	}
+	return fwpmCalloutAdd0(e.handle, c0, sd, nil)
+}
+
+func (e *winEngine) DeleteCallout(id CalloutID) error {
+	return fwpmCalloutDeleteByKey0(e.handle, &id)
+}
+
+func (e *winEngine) ProviderContexts() ([]*ProviderContext, error) {
+	var enum windows.Handle
+	if err := fwpmProviderContextCreateEnumHandle0(e.handle, nil, &enum); err != nil {
+		return nil, err
+	}
+	defer fwpmProviderContextDestroyEnumHandle0(e.handle, enum)
+
+	// FwpmProviderContextEnum2 was added in Windows 8, along with
+	// new types of IPsec context. All versions return general
+	// contexts the same way.
+	enumFn := fwpmProviderContextEnum2
+	if procFwpmProviderContextEnum2.Find() != nil {
+		enumFn = fwpmProviderContextEnum0
+	}
+
+	var ret []*ProviderContext
+	for {
+		var (
+			array **fwpmProviderContext0
+			num   uint32
+		)
+		if err := enumFn(e.handle, enum, enumPageSize, &array, &num); err != nil {
+			return nil, err
+		}
+		if num == 0 {
+			return ret, nil
+		}
+		ret = append(ret, fromProviderContext0(array, num)...)
+		fwpmFreeMemory0((*struct{})(unsafe.Pointer(&array)))
+	}
+}
+
+func (e *winEngine) AddProviderContext(pc *ProviderContext) error {
+	var a arena
+	defer a.Dispose()
+
+	pc0 := toProviderContext0(&a, pc)
+	sd, err := fromSDDL(pc.SecurityDescriptor)
+	if err != nil {
+		return err
+	}
+	return fwpmProviderContextAdd0(e.handle, pc0, sd, nil)
+}
+
+func (e *winEngine) DeleteProviderContext(id ProviderContextID) error {
+	return fwpmProviderContextDeleteByKey0(e.handle, &id)
+}
+
+func (e *winEngine) Providers() ([]*Provider, error) {
+	var ret []*Provider
+	err := e.WalkProviders(context.Background(), func(providers []*Provider) error {
+		ret = append(ret, providers...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (e *winEngine) WalkProviders(ctx context.Context, fn func([]*Provider) error) error {
+	var enum windows.Handle
+	if err := fwpmProviderCreateEnumHandle0(e.handle, nil, &enum); err != nil {
+		return err
+	}
+	defer fwpmProviderDestroyEnumHandle0(e.handle, enum)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		providers, err := e.getProviderPage(enum)
+		if err != nil {
+			return err
+		}
+		if len(providers) == 0 {
+			return nil
+		}
+		if err := fn(providers); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *winEngine) getProviderPage(enum windows.Handle) ([]*Provider, error) {
+	var (
+		array **fwpmProvider0
+		num   uint32
+	)
+	if err := fwpmProviderEnum0(e.handle, enum, enumPageSize, &array, &num); err != nil {
+		return nil, err
+	}
+	if num == 0 {
+		return nil, nil
+	}
+	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&array)))
+
+	return fromProvider0(array, num), nil
+}
+
+func (e *winEngine) AddProvider(p *Provider) error {
+	var a arena
+	defer a.Dispose()
+
+	sd, err := fromSDDL(p.SecurityDescriptor)
+	if err != nil {
+		return err
+	}
+	p0 := toProvider0(&a, p)
+
+	return fwpmProviderAdd0(e.handle, p0, sd)
+}
+
+func (e *winEngine) DeleteProvider(id ProviderID) error {
+	return fwpmProviderDeleteByKey0(e.handle, &id)
+}
+
+func (e *winEngine) Rules(tpl *RuleEnumTemplate) ([]*Rule, error) {
+	var ret []*Rule
+	err := e.WalkRules(context.Background(), tpl, func(rules []*Rule) error {
+		ret = append(ret, rules...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (e *winEngine) WalkRules(ctx context.Context, tpl *RuleEnumTemplate, fn func([]*Rule) error) error {
+	var a arena
+	defer a.Dispose()
+
+	tpl0, err := toFilterEnumTemplate0(&a, tpl, e.layerTypes)
+	if err != nil {
+		return err
+	}
+
+	var enum windows.Handle
+	if err := fwpmFilterCreateEnumHandle0(e.handle, tpl0, &enum); err != nil {
+		return err
+	}
+	defer fwpmFilterDestroyEnumHandle0(e.handle, enum)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rules, err := e.getRulePage(enum)
+		if err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		if err := fn(rules); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *winEngine) getRulePage(enum windows.Handle) ([]*Rule, error) {
+	var (
+		array **fwpmFilter0
+		num   uint32
+	)
+	if err := fwpmFilterEnum0(e.handle, enum, enumPageSize, &array, &num); err != nil {
+		return nil, err
+	}
+	if num == 0 {
+		return nil, nil
+	}
+	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&array)))
+
+	return fromFilter0(array, num, e.layerTypes)
+}
+
+func (e *winEngine) AddRule(r *Rule) error {
+	var a arena
+	defer a.Dispose()
+
+	f, err := toFilter0(&a, r, e.layerTypes)
+	if err != nil {
+		return err
+	}
+	sd, err := fromSDDL(r.SecurityDescriptor)
+	if err != nil {
+		return err
+	}
+	if err := fwpmFilterAdd0(e.handle, f, sd, &f.FilterID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (e *winEngine) DeleteRule(id RuleID) error {
+	return fwpmFilterDeleteByKey0(e.handle, &id)
+}
+
+func (e *winEngine) SubscribeRuleChanges(tpl *RuleEnumTemplate, deliver func(*RuleChange)) (func() error, error) {
+	// The template must outlive the subscription, so the arena is
+	// only disposed of when unsubscribing.
+	a := &arena{}
+	tpl0, err := toFilterEnumTemplate0(a, tpl, e.layerTypes)
+	if err != nil {
+		a.Dispose()
+		return nil, err
+	}
+	sub0 := (*fwpmFilterSubscription0)(a.Alloc(unsafe.Sizeof(fwpmFilterSubscription0{})))
+	sub0.EnumTemplate = tpl0
+	sub0.Flags = fwpmSubscriptionFlagNotifyOnAdd | fwpmSubscriptionFlagNotifyOnDelete
+
+	// Callbacks must return quickly, and can't look up the added
+	// rules, so they queue changes for a goroutine that does.
+	queue := make(chan *RuleChange, DefaultRuleWatchBuffer)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for c := range queue {
+			if c.Type == RuleAdded {
+				c.Rule, _ = e.rule(c.ID)
+			}
+			deliver(c)
+		}
+	}()
+
+	id := winCallbacks.add(ruleChangeCallback(func(c *RuleChange) {
+		if c == nil {
+			deliver(nil)
+			return
+		}
+		select {
+		case queue <- c:
+		default:
+			deliver(nil)
+		}
+	}))
+	stop := func() {
+		winCallbacks.remove(id)
+		close(queue)
+		<-done
+		a.Dispose()
+	}
+	var changes windows.Handle
+	if err := fwpmFilterSubscribeChanges0(e.handle, sub0, getCallback(), id, &changes); err != nil {
+		stop()
+		return nil, err
+	}
+
+	handle := e.handle
+	return func() error {
+		// FwpmFilterUnsubscribeChanges0 waits for callbacks in
+		// progress to return, after which nothing else sends to
+		// queue.
+		err := fwpmFilterUnsubscribeChanges0(handle, changes)
+		stop()
+		return err
+	}, nil
+}
+
+// rule returns the rule whose ID is id.
+func (e *winEngine) rule(id RuleID) (*Rule, error) {
+	var f *fwpmFilter0
+	if err := fwpmFilterGetByKey0(e.handle, &id, &f); err != nil {
+		return nil, err
+	}
+	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&f)))
+
+	rules, err := fromFilter0(&f, 1, e.layerTypes)
+	if err != nil {
+		return nil, err
+	}
+	return rules[0], nil
+}
+
+func (e *winEngine) NetEvents(tpl *NetEventEnumTemplate) ([]*NetEvent, error) {
+	var ret []*NetEvent
+	err := e.WalkNetEvents(context.Background(), tpl, func(events []*NetEvent) error {
+		ret = append(ret, events...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (e *winEngine) WalkNetEvents(ctx context.Context, tpl *NetEventEnumTemplate, fn func([]*NetEvent) error) error {
+	var a arena
+	defer a.Dispose()
+
+	tpl0, err := toNetEventEnumTemplate0(&a, tpl)
+	if err != nil {
+		return err
+	}
+
+	var enum windows.Handle
+	if err := fwpmNetEventCreateEnumHandle0(e.handle, tpl0, &enum); err != nil {
+		return err
+	}
+	defer fwpmNetEventDestroyEnumHandle0(e.handle, enum)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		events, err := e.getEventPage(enum)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		if err := fn(events); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *winEngine) getEventPage(enum windows.Handle) ([]*NetEvent, error) {
+	var (
+		array uintptr
+		num   uint32
+	)
+	// FwpmNetEventEnum2 was added in Windows 8, along with the
+	// classify allow and capability events. Older versions only
+	// have FwpmNetEventEnum1.
+	version := netEventVersion2
+	enumFn := fwpmNetEventEnum2
+	if procFwpmNetEventEnum2.Find() != nil {
+		version = netEventVersion1
+		enumFn = fwpmNetEventEnum1
+	}
+	if err := enumFn(e.handle, enum, enumPageSize, &array, &num); err != nil {
+		return nil, err
+	}
+	if num == 0 {
+		return nil, nil
+	}
+	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&array)))
+
+	return decodeNetEvents(cMemory{}, uint64(array), num, version)
+}
+
+// winCallbacks are the callbacks of all subscriptions, keyed by the
+// context value passed to the engine's subscribe functions.
+var winCallbacks callbacks
+
+var (
+	winCallbackOnce sync.Once
+	winCallback     uintptr
+)
+
+// getCallback returns the subscription callback shared by all
+// subscriptions, which is both an FWPM_NET_EVENT_CALLBACK and an
+// FWPM_FILTER_CHANGE_CALLBACK0. Callbacks made by windows.NewCallback
+// are never freed, and only a limited number can exist, so there is
+// only one, which dispatches each notification by its context value.
+func getCallback() uintptr {
+	winCallbackOnce.Do(func() {
+		winCallback = windows.NewCallback(func(context, notification uintptr) uintptr {
+			winCallbacks.dispatch(cMemory{}, context, uint64(notification))
+			return 0
+		})
+	})
+	return winCallback
+}
+
+func (e *winEngine) SubscribeNetEvents(tpl *NetEventEnumTemplate, deliver func(*NetEvent)) (func() error, error) {
+	// The template must outlive the subscription, so the arena is
+	// only disposed of when unsubscribing.
+	a := &arena{}
+	tpl0, err := toNetEventEnumTemplate0(a, tpl)
+	if err != nil {
+		a.Dispose()
+		return nil, err
+	}
+	sub0 := (*fwpmNetEventSubscription0)(a.Alloc(unsafe.Sizeof(fwpmNetEventSubscription0{})))
+	sub0.EnumTemplate = tpl0
+
+	// FwpmNetEventSubscribe1 delivers FWPM_NET_EVENT2, like
+	// FwpmNetEventEnum2. Older versions only have
+	// FwpmNetEventSubscribe0.
+	version := netEventVersion2
+	subscribeFn := fwpmNetEventSubscribe1
+	if procFwpmNetEventSubscribe1.Find() != nil {
+		version = netEventVersion1
+		subscribeFn = fwpmNetEventSubscribe0
+	}
+
+	id := winCallbacks.add(netEventCallback(version, deliver))
+	var events windows.Handle
+	if err := subscribeFn(e.handle, sub0, getCallback(), id, &events); err != nil {
+		winCallbacks.remove(id)
+		a.Dispose()
+		return nil, err
+	}
+
+	handle := e.handle
+	return func() error {
+		// FwpmNetEventUnsubscribe0 waits for callbacks in
+		// progress to return.
+		err := fwpmNetEventUnsubscribe0(handle, events)
+		winCallbacks.remove(id)
+		a.Dispose()
+		return err
+	}, nil
+}
+
+func (e *winEngine) BeginTransaction(flags TransactionFlag) error {
+	return fwpmTransactionBegin0(e.handle, uint32(flags))
+}
+
+func (e *winEngine) CommitTransaction() error {
+	return fwpmTransactionCommit0(e.handle)
+}
+
+func (e *winEngine) AbortTransaction() error {
+	return fwpmTransactionAbort0(e.handle)
+}
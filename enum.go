@@ -1,21 +1,35 @@
 package wf
 
-import (
-	"golang.org/x/sys/windows"
+type FilterEnumType uint32
+
+const (
+	FilterEnumTypeFullyContained FilterEnumType = iota
+	FilterEnumTypeOverlapping
+)
+
+type FilterEnumFlags uint32
+
+const (
+	FilterEnumFlagsBestTerminatingMatch FilterEnumFlags = 0x01
+	FilterEnumFlagsSorted                               = 0x02
+	FilterEnumFlagsBootTimeOnly                         = 0x04
+	FilterEnumFlagsIncludeBootTime                      = 0x08
+	FilterEnumFlagsIncludeDisabled                      = 0x10
+	FilterEnumFlagsReserved1                            = 0x20
 )
 
 type RuleEnumerator struct {
 	session      *Session
-	enumTemplate fwpmFilterEnumTemplate0
+	enumTemplate RuleEnumTemplate
 }
 
 func (e RuleEnumerator) WithProvider(provider ProviderID) RuleEnumerator {
-	e.enumTemplate.ProviderKey = &provider
+	e.enumTemplate.Provider = &provider
 	return e
 }
 
 func (e RuleEnumerator) WithLayer(layer LayerID) RuleEnumerator {
-	e.enumTemplate.LayerKey = layer
+	e.enumTemplate.Layer = layer
 	return e
 }
 
@@ -30,12 +44,19 @@ func (e RuleEnumerator) WithFlags(flags FilterEnumFlags) RuleEnumerator {
 }
 
 func (e RuleEnumerator) WithProviderContext(provider ProviderID, contextType uint32) RuleEnumerator {
-	contextEnumTemplate := fwpmProviderContextEnumTemplate0{
-		ProviderKey:         new(ProviderID),
-		ProviderContextType: contextType,
+	e.enumTemplate.ProviderContext = &ProviderContextEnumTemplate{
+		Provider: &provider,
+		Type:     contextType,
 	}
-	*contextEnumTemplate.ProviderKey = provider
-	e.enumTemplate.ProviderContextTemplate = &contextEnumTemplate
+	return e
+}
+
+// WithConditions limits the enumeration to rules whose conditions
+// are fully contained in or overlap ms, depending on the enumeration
+// type. The enumeration must have a layer, which determines the
+// types of ms's values. See FilterRules for the semantics.
+func (e RuleEnumerator) WithConditions(ms []*Match) RuleEnumerator {
+	e.enumTemplate.Conditions = ms
 	return e
 }
 
@@ -45,39 +66,21 @@ func (e RuleEnumerator) WithActionMask(mask ActionFlag) RuleEnumerator {
 }
 
 func (e RuleEnumerator) WithCalloutKey(key CalloutID) RuleEnumerator {
-	e.enumTemplate.CalloutKey = &key
+	e.enumTemplate.Callout = &key
 	return e
 }
 
 func (e RuleEnumerator) Execute() ([]*Rule, error) {
-	var enum windows.Handle
-	if err := fwpmFilterCreateEnumHandle0(e.session.handle, &e.enumTemplate, &enum); err != nil {
-		return nil, err
-	}
-	defer fwpmFilterDestroyEnumHandle0(e.session.handle, enum)
-
-	var ret []*Rule
-
-	for {
-		rules, err := e.session.getRulePage(enum)
-		if err != nil {
-			return nil, err
-		}
-
-		if len(rules) == 0 {
-			return ret, nil
-		}
-
-		ret = append(ret, rules...)
-	}
+	tpl := e.enumTemplate
+	return e.session.engine.Rules(&tpl)
 }
 
 func (s *Session) EnumerateRules(typ FilterEnumType, layer LayerID) RuleEnumerator {
 	return RuleEnumerator{
 		session: s,
-		enumTemplate: fwpmFilterEnumTemplate0{
+		enumTemplate: RuleEnumTemplate{
 			EnumType: typ,
-			LayerKey: layer,
+			Layer:    layer,
 		},
 	}
 }
@@ -7,14 +7,23 @@ import (
 // Detailed error code information available here:
 // https://learn.microsoft.com/en-us/windows/win32/fwp/wfp-error-codes
 const (
+	CalloutNotFound         syscall.Errno = 0x80320001
+	ConditionNotFound       syscall.Errno = 0x80320002
 	FilterNotFound          syscall.Errno = 0x80320003
 	LayerNotFound                         = 0x80320004
 	ProviderNotFound                      = 0x80320005
+	ProviderContextNotFound               = 0x80320006
 	SublayerNotFound                      = 0x80320007
 	NotFound                              = 0x80320008
+	AlreadyExists                         = 0x80320009
+	InUse                                 = 0x8032000A
 	NoTransactionInProgress               = 0x8032000D
 	TransactionInProgress                 = 0x8032000E
 	TransactionAborted                    = 0x8032000F
+	IncompatibleTransaction               = 0x80320011
 	Timeout                               = 0x80320012
+	IncompatibleLayer                     = 0x80320014
+	LifetimeMismatch                      = 0x80320016
+	BuiltinObject                         = 0x80320017
 	NilPointer                            = 0x8032001C
 )
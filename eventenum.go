@@ -0,0 +1,315 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"time"
+)
+
+// NetEventEnumTemplate selects the net events to enumerate.
+type NetEventEnumTemplate struct {
+	// StartTime and EndTime bound the timestamps of the events,
+	// inclusively. A zero time leaves that end unbounded.
+	StartTime time.Time
+	EndTime   time.Time
+	// Conditions are matched against the header fields of each
+	// event, with the same semantics as rule conditions: matches
+	// on the same field are ORed, and matches on different fields
+	// are ANDed. See ParseNetEventMatch for the fields that can be
+	// used.
+	Conditions []*Match
+}
+
+// netEventFieldTypes are the fields that net event enumeration can
+// match on, and the Go types of their values.
+var netEventFieldTypes = fieldTypes{
+	FieldIPProtocol:      typeUint8,
+	FieldIPLocalAddress:  typeIP,
+	FieldIPRemoteAddress: typeIP,
+	FieldIPLocalPort:     typeUint16,
+	FieldIPRemotePort:    typeUint16,
+	FieldALEAppID:        typeString,
+	FieldALEUserID:       typeSID,
+	FieldALEPackageID:    typeSID,
+	FieldNetEventType:    typeUint32,
+}
+
+// ParseNetEventMatch parses text into a Match on a net event field,
+// for use in NetEventEnumerator.WithConditions. See Schema.ParseMatch
+// for the syntax. The fields are IP_PROTOCOL, IP_LOCAL_ADDRESS,
+// IP_REMOTE_ADDRESS, IP_LOCAL_PORT, IP_REMOTE_PORT, ALE_APP_ID,
+// ALE_USER_ID, ALE_PACKAGE_ID and NET_EVENT_TYPE.
+func ParseNetEventMatch(text string) (*Match, error) {
+	return parseMatch(text, func(field FieldID) (reflect.Type, error) {
+		ftype, ok := netEventFieldTypes[field]
+		if !ok {
+			return nil, fmt.Errorf("net events have no field %s", field)
+		}
+		return ftype, nil
+	})
+}
+
+// validate checks the conditions of tpl, and returns a
+// ValidationErrors listing every problem found, or nil if they are
+// well-formed.
+func (tpl *NetEventEnumTemplate) validate() error {
+	var errs ValidationErrors
+	addErr := func(cond int, msg string, args ...interface{}) {
+		errs = append(errs, &ValidationError{cond, fmt.Sprintf(msg, args...)})
+	}
+	for i, m := range tpl.Conditions {
+		if m == nil {
+			addErr(i, "nil condition")
+			continue
+		}
+		ftype, ok := netEventFieldTypes[m.Field]
+		if !ok {
+			addErr(i, "field %s is not available for net events", m.Field)
+			continue
+		}
+		if _, ok := mtStr[m.Op]; !ok {
+			addErr(i, "unknown match type %s", m.Op)
+			continue
+		}
+		if err := validateValue(m.Value, ftype); err != "" {
+			addErr(i, "field %s: %s", m.Field, err)
+			continue
+		}
+		if err := validateOp(m.Op, m.Value, ftype); err != "" {
+			addErr(i, "field %s: %s", m.Field, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// matches reports whether e is selected by tpl. A nil template
+// selects every event.
+func (tpl *NetEventEnumTemplate) matches(e *NetEvent) bool {
+	if tpl == nil {
+		return true
+	}
+	if !tpl.StartTime.IsZero() && e.Timestamp.Before(tpl.StartTime) {
+		return false
+	}
+	if !tpl.EndTime.IsZero() && e.Timestamp.After(tpl.EndTime) {
+		return false
+	}
+	return conditionsMatch(tpl.Conditions, netEventConnection(e))
+}
+
+// netEventConnection returns the header fields of e as a Connection,
+// so that enumeration conditions can be evaluated like rule
+// conditions.
+func netEventConnection(e *NetEvent) *Connection {
+	c := &Connection{
+		Protocol:   IPProto(e.IPProtocol),
+		LocalAddr:  e.LocalAddr,
+		RemoteAddr: e.RemoteAddr,
+		AppID:      e.AppID,
+		Fields: map[FieldID]interface{}{
+			FieldNetEventType: uint32(e.Type),
+		},
+	}
+	if sid, err := sidFromString(e.UserID); err == nil {
+		c.Fields[FieldALEUserID] = sid
+	}
+	if sid, err := sidFromString(e.PackageID); err == nil {
+		c.Fields[FieldALEPackageID] = sid
+	}
+	return c
+}
+
+// NetEventEnumerator enumerates the net events that match a
+// NetEventEnumTemplate. Its With methods return a modified copy.
+type NetEventEnumerator struct {
+	session      *Session
+	enumTemplate NetEventEnumTemplate
+}
+
+// EnumerateNetEvents returns an enumerator of all the events recorded
+// by the filtering engine, to be narrowed by its With methods.
+func (s *Session) EnumerateNetEvents() NetEventEnumerator {
+	return NetEventEnumerator{session: s}
+}
+
+// WithTimeRange limits the enumeration to events recorded between
+// start and end, inclusive. A zero time leaves that end unbounded.
+func (e NetEventEnumerator) WithTimeRange(start, end time.Time) NetEventEnumerator {
+	e.enumTemplate.StartTime = start
+	e.enumTemplate.EndTime = end
+	return e
+}
+
+// WithConditions adds ms to the conditions that events' header fields
+// must match. See NetEventEnumTemplate.Conditions. The other With
+// methods below also add conditions, so for example
+// WithTypes(a).WithTypes(b) selects events of type a or b.
+func (e NetEventEnumerator) WithConditions(ms []*Match) NetEventEnumerator {
+	conds := append([]*Match(nil), e.enumTemplate.Conditions...)
+	e.enumTemplate.Conditions = append(conds, ms...)
+	return e
+}
+
+// WithTypes limits the enumeration to events of the given types.
+func (e NetEventEnumerator) WithTypes(types ...NetEventType) NetEventEnumerator {
+	var ms []*Match
+	for _, t := range types {
+		ms = append(ms, &Match{FieldNetEventType, MatchTypeEqual, uint32(t)})
+	}
+	return e.WithConditions(ms)
+}
+
+// WithRemoteAddr limits the enumeration to events whose remote
+// address is in p.
+func (e NetEventEnumerator) WithRemoteAddr(p netip.Prefix) NetEventEnumerator {
+	return e.WithConditions([]*Match{{FieldIPRemoteAddress, MatchTypeEqual, p.Masked()}})
+}
+
+// WithAppID limits the enumeration to events of the application whose
+// ID is appID, as returned by AppID.
+func (e NetEventEnumerator) WithAppID(appID string) NetEventEnumerator {
+	return e.WithConditions([]*Match{{FieldALEAppID, MatchTypeEqual, appID}})
+}
+
+// WithProtocol limits the enumeration to events of the IP protocol
+// proto.
+func (e NetEventEnumerator) WithProtocol(proto IPProto) NetEventEnumerator {
+	return e.WithConditions([]*Match{{FieldIPProtocol, MatchTypeEqual, proto}})
+}
+
+// Execute returns the events that match e.
+func (e NetEventEnumerator) Execute() ([]*NetEvent, error) {
+	tpl := e.enumTemplate
+	if err := tpl.validate(); err != nil {
+		return nil, err
+	}
+	return e.session.engine.NetEvents(&tpl)
+}
+
+// Walk calls fn with successive pages of the events that match e. It
+// is the streaming form of Execute.
+func (e NetEventEnumerator) Walk(ctx context.Context, fn func([]*NetEvent) error) error {
+	tpl := e.enumTemplate
+	if err := tpl.validate(); err != nil {
+		return err
+	}
+	return e.session.engine.WalkNetEvents(ctx, &tpl, fn)
+}
+
+// DropEvents returns the classify drops among the events that match
+// e.
+func (e NetEventEnumerator) DropEvents() ([]*DropEvent, error) {
+	events, err := e.WithTypes(NetEventTypeClassifyDrop).Execute()
+	if err != nil {
+		return nil, err
+	}
+	return dropEvents(events), nil
+}
+
+// Poller returns a NetEventPoller that enumerates the events that
+// match e, starting at e's start time. To skip the events recorded
+// so far, set the start time to the current time.
+func (e NetEventEnumerator) Poller() *NetEventPoller {
+	return &NetEventPoller{
+		e:    e,
+		last: e.enumTemplate.StartTime,
+	}
+}
+
+// A NetEventPoller enumerates net events incrementally: each Poll
+// returns only the events recorded since the previous one.
+//
+// Each Poll asks the filtering engine for the events at or after the
+// newest timestamp seen so far, so the engine doesn't re-read older
+// events. Events that share that newest timestamp are remembered, so
+// that they aren't returned twice.
+type NetEventPoller struct {
+	e    NetEventEnumerator
+	last time.Time
+	// seen is the set of events returned so far whose timestamp is
+	// last.
+	seen map[netEventKey]bool
+}
+
+// netEventKey identifies a net event among the events with the same
+// timestamp.
+type netEventKey struct {
+	typ        NetEventType
+	protocol   uint8
+	localAddr  netip.AddrPort
+	remoteAddr netip.AddrPort
+	appID      string
+	userID     string
+	filterID   uint64
+}
+
+func keyOf(e *NetEvent) netEventKey {
+	ret := netEventKey{
+		typ:        e.Type,
+		protocol:   e.IPProtocol,
+		localAddr:  e.LocalAddr,
+		remoteAddr: e.RemoteAddr,
+		appID:      e.AppID,
+		userID:     e.UserID,
+	}
+	switch {
+	case e.Classify != nil:
+		ret.filterID = e.Classify.FilterID
+	case e.Capability != nil:
+		ret.filterID = e.Capability.FilterID
+	case e.IKEFailure != nil:
+		ret.filterID = e.IKEFailure.FilterID
+	case e.IPsecDrop != nil:
+		ret.filterID = e.IPsecDrop.FilterID
+	}
+	return ret
+}
+
+// Poll returns the events recorded since the previous call to Poll,
+// in the order the filtering engine returns them.
+func (p *NetEventPoller) Poll() ([]*NetEvent, error) {
+	e := p.e
+	e.enumTemplate.StartTime = p.last
+	events, err := e.Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*NetEvent
+	for _, ev := range events {
+		if !p.last.IsZero() {
+			if ev.Timestamp.Before(p.last) {
+				continue
+			}
+			if ev.Timestamp.Equal(p.last) && p.seen[keyOf(ev)] {
+				continue
+			}
+		}
+		ret = append(ret, ev)
+	}
+
+	for _, ev := range ret {
+		if ev.Timestamp.After(p.last) {
+			p.last = ev.Timestamp
+			p.seen = nil
+		}
+	}
+	for _, ev := range ret {
+		if ev.Timestamp.Equal(p.last) {
+			if p.seen == nil {
+				p.seen = map[netEventKey]bool{}
+			}
+			p.seen[keyOf(ev)] = true
+		}
+	}
+	return ret, nil
+}
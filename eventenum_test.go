@@ -0,0 +1,223 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// addTestEvents adds a classify drop to m for each of remotes, one
+// second apart starting at start, and returns them.
+func addTestEvents(m *MemoryEngine, start time.Time, remotes ...string) []*NetEvent {
+	var ret []*NetEvent
+	for i, r := range remotes {
+		e := &NetEvent{
+			Type:       NetEventTypeClassifyDrop,
+			Timestamp:  start.Add(time.Duration(i) * time.Second),
+			IPProtocol: uint8(IPProtoTCP),
+			LocalAddr:  netip.MustParseAddrPort("10.0.0.1:49152"),
+			RemoteAddr: netip.MustParseAddrPort(r),
+			AppID:      testAppID,
+			Classify:   &ClassifyEvent{FilterID: uint64(i)},
+		}
+		m.AddNetEvent(e)
+		ret = append(ret, e)
+	}
+	return ret
+}
+
+// remotes returns the remote addresses of events.
+func remotes(events []*NetEvent) []string {
+	var ret []string
+	for _, e := range events {
+		ret = append(ret, e.RemoteAddr.String())
+	}
+	return ret
+}
+
+func TestEnumerateNetEvents(t *testing.T) {
+	m := NewMemoryEngine(nil)
+	s := newMemorySession(t, m, nil)
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	addTestEvents(m, start, "1.1.1.1:53", "8.8.8.8:53", "8.8.4.4:443", "[2001:db8::1]:443")
+	m.AddNetEvent(&NetEvent{
+		Type:       NetEventTypeCapabilityDrop,
+		Timestamp:  start.Add(10 * time.Second),
+		IPProtocol: uint8(IPProtoUDP),
+		RemoteAddr: netip.MustParseAddrPort("8.8.8.8:53"),
+		UserID:     "S-1-5-18",
+		Capability: &CapabilityEvent{},
+	})
+
+	parse := func(text string) *Match {
+		m, err := ParseNetEventMatch(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	tests := []struct {
+		name string
+		e    NetEventEnumerator
+		want []string
+	}{
+		{
+			name: "all",
+			e:    s.EnumerateNetEvents(),
+			want: []string{"1.1.1.1:53", "8.8.8.8:53", "8.8.4.4:443", "[2001:db8::1]:443", "8.8.8.8:53"},
+		},
+		{
+			name: "time range",
+			e:    s.EnumerateNetEvents().WithTimeRange(start.Add(time.Second), start.Add(2*time.Second)),
+			want: []string{"8.8.8.8:53", "8.8.4.4:443"},
+		},
+		{
+			name: "open-ended time range",
+			e:    s.EnumerateNetEvents().WithTimeRange(start.Add(3*time.Second), time.Time{}),
+			want: []string{"[2001:db8::1]:443", "8.8.8.8:53"},
+		},
+		{
+			name: "remote address",
+			e:    s.EnumerateNetEvents().WithRemoteAddr(netip.MustParsePrefix("8.8.0.0/16")),
+			want: []string{"8.8.8.8:53", "8.8.4.4:443", "8.8.8.8:53"},
+		},
+		{
+			name: "protocol and type",
+			e:    s.EnumerateNetEvents().WithProtocol(IPProtoUDP).WithTypes(NetEventTypeClassifyDrop),
+			want: nil,
+		},
+		{
+			name: "types are ORed",
+			e:    s.EnumerateNetEvents().WithTypes(NetEventTypeCapabilityDrop).WithTypes(NetEventTypeCapabilityAllow),
+			want: []string{"8.8.8.8:53"},
+		},
+		{
+			name: "app ID",
+			e:    s.EnumerateNetEvents().WithAppID(testAppID).WithTimeRange(start, start),
+			want: []string{"1.1.1.1:53"},
+		},
+		{
+			name: "parsed conditions",
+			e: s.EnumerateNetEvents().WithConditions([]*Match{
+				parse("IP_REMOTE_PORT == 443"),
+				parse("IP_REMOTE_ADDRESS in 2001:db8::/32"),
+			}),
+			want: []string{"[2001:db8::1]:443"},
+		},
+		{
+			name: "user ID",
+			e:    s.EnumerateNetEvents().WithConditions([]*Match{parse("ALE_USER_ID == S-1-5-18")}),
+			want: []string{"8.8.8.8:53"},
+		},
+	}
+
+	for _, test := range tests {
+		got, err := test.e.Execute()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if diff := cmp.Diff(remotes(got), test.want); diff != "" {
+			t.Errorf("%s: wrong events (-got+want):\n%s", test.name, diff)
+		}
+	}
+
+	drops, err := s.EnumerateNetEvents().WithRemoteAddr(netip.MustParsePrefix("8.8.8.8/32")).DropEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(drops) != 1 || drops[0].FilterID != 1 {
+		t.Errorf("DropEvents returned %v, want the drop of filter 1", drops)
+	}
+}
+
+func TestEnumerateNetEventsInvalid(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(nil), nil)
+
+	for _, ms := range [][]*Match{
+		{{FieldFlags, MatchTypeFlagsAllSet, ConditionFlagIsLoopback}},
+		{{FieldIPRemotePort, MatchTypeEqual, uint32(53)}},
+		{{FieldNetEventType, MatchTypeEqual, NetEventTypeClassifyDrop}},
+		{nil},
+	} {
+		if _, err := s.EnumerateNetEvents().WithConditions(ms).Execute(); err == nil {
+			t.Errorf("enumerating with %v succeeded", ms)
+		}
+	}
+
+	for _, text := range []string{
+		"FLAGS F[all] IsLoopback",
+		"IP_REMOTE_PORT == 70000",
+		"NO_SUCH_FIELD == 1",
+	} {
+		if m, err := ParseNetEventMatch(text); err == nil {
+			t.Errorf("ParseNetEventMatch(%q) = %v, want error", text, m)
+		}
+	}
+}
+
+func TestNetEventPoller(t *testing.T) {
+	m := NewMemoryEngine(nil)
+	s := newMemorySession(t, m, nil)
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	poll := func(p *NetEventPoller) []string {
+		t.Helper()
+		events, err := p.Poll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return remotes(events)
+	}
+
+	// Events recorded before the poller's start time are skipped.
+	addTestEvents(m, start.Add(-time.Hour), "9.9.9.9:53")
+	p := s.EnumerateNetEvents().WithTimeRange(start, time.Time{}).Poller()
+	if got := poll(p); len(got) != 0 {
+		t.Fatalf("first poll = %v, want nothing", got)
+	}
+
+	// Two events share the newest timestamp.
+	addTestEvents(m, start, "1.1.1.1:53", "1.1.1.2:53")
+	m.AddNetEvent(&NetEvent{
+		Type:       NetEventTypeClassifyDrop,
+		Timestamp:  start.Add(time.Second),
+		RemoteAddr: netip.MustParseAddrPort("1.1.1.3:53"),
+		Classify:   &ClassifyEvent{},
+	})
+	if diff := cmp.Diff(poll(p), []string{"1.1.1.1:53", "1.1.1.2:53", "1.1.1.3:53"}); diff != "" {
+		t.Fatalf("second poll is wrong (-got+want):\n%s", diff)
+	}
+	if got := poll(p); len(got) != 0 {
+		t.Fatalf("poll without new events = %v, want nothing", got)
+	}
+
+	// A new event with the same timestamp as the last seen ones is
+	// returned, and so are later events.
+	m.AddNetEvent(&NetEvent{
+		Type:       NetEventTypeClassifyDrop,
+		Timestamp:  start.Add(time.Second),
+		RemoteAddr: netip.MustParseAddrPort("1.1.1.4:53"),
+		Classify:   &ClassifyEvent{},
+	})
+	addTestEvents(m, start.Add(time.Minute), "1.1.1.5:53")
+	if diff := cmp.Diff(poll(p), []string{"1.1.1.4:53", "1.1.1.5:53"}); diff != "" {
+		t.Fatalf("third poll is wrong (-got+want):\n%s", diff)
+	}
+	if got := poll(p); len(got) != 0 {
+		t.Fatalf("poll without new events = %v, want nothing", got)
+	}
+
+	// Pollers keep the enumerator's conditions.
+	p = s.EnumerateNetEvents().WithRemoteAddr(netip.MustParsePrefix("1.1.1.5/32")).Poller()
+	if diff := cmp.Diff(poll(p), []string{"1.1.1.5:53"}); diff != "" {
+		t.Fatalf("filtered poll is wrong (-got+want):\n%s", diff)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import "fmt"
+
+// A DropExplanation is a DropEvent joined with the objects that
+// caused the drop.
+type DropExplanation struct {
+	Event *DropEvent
+	// Rule is the rule whose KernelID is Event.FilterID, or nil if
+	// there is no such rule, for example because it has been deleted
+	// since the drop.
+	Rule *Rule
+	// Layer is the layer whose KernelID is Event.LayerID, or nil if
+	// there is no such layer.
+	Layer *Layer
+	// Sublayer is Rule's sublayer, or nil if Rule is nil or the
+	// sublayer no longer exists.
+	Sublayer *Sublayer
+	// Provider is Rule's provider, or nil if Rule is nil, has no
+	// provider, or the provider no longer exists.
+	Provider *Provider
+}
+
+// RuleMissing reports whether the event names a rule that no longer
+// exists.
+func (e *DropExplanation) RuleMissing() bool {
+	return e.Rule == nil && e.Event.FilterID != 0
+}
+
+func (e *DropExplanation) String() string {
+	layer := fmt.Sprintf("layer %d", e.Event.LayerID)
+	if e.Layer != nil {
+		layer = e.Layer.ID.String()
+	}
+	switch {
+	case e.Rule != nil:
+		return fmt.Sprintf("dropped in %s by rule %s %q", layer, e.Rule.ID, e.Rule.Name)
+	case e.RuleMissing():
+		return fmt.Sprintf("dropped in %s by deleted rule %d", layer, e.Event.FilterID)
+	}
+	return fmt.Sprintf("dropped in %s", layer)
+}
+
+// ExplainDrops returns the filtering engine's drop events, each
+// joined with the rule, layer, sublayer and provider that caused it.
+func (s *Session) ExplainDrops() ([]*DropExplanation, error) {
+	events, err := s.DropEvents()
+	if err != nil {
+		return nil, fmt.Errorf("listing drop events: %w", err)
+	}
+	rules, err := s.Rules()
+	if err != nil {
+		return nil, fmt.Errorf("listing rules: %w", err)
+	}
+	layers, err := s.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("listing layers: %w", err)
+	}
+	sublayers, err := s.Sublayers()
+	if err != nil {
+		return nil, fmt.Errorf("listing sublayers: %w", err)
+	}
+	providers, err := s.Providers()
+	if err != nil {
+		return nil, fmt.Errorf("listing providers: %w", err)
+	}
+	return ExplainDropEvents(events, rules, layers, sublayers, providers), nil
+}
+
+// ExplainDropEvents joins each of events with the objects that
+// caused it, looking rules and layers up by their KernelID. It is
+// the offline form of Session.ExplainDrops, for events and objects
+// that were saved earlier.
+func ExplainDropEvents(events []*DropEvent, rules []*Rule, layers []*Layer, sublayers []*Sublayer, providers []*Provider) []*DropExplanation {
+	ruleByKID := map[uint64]*Rule{}
+	for _, r := range rules {
+		ruleByKID[r.KernelID] = r
+	}
+	layerByKID := map[uint16]*Layer{}
+	for _, l := range layers {
+		layerByKID[l.KernelID] = l
+	}
+	sublayerByID := map[SublayerID]*Sublayer{}
+	for _, sl := range sublayers {
+		sublayerByID[sl.ID] = sl
+	}
+	providerByID := map[ProviderID]*Provider{}
+	for _, p := range providers {
+		providerByID[p.ID] = p
+	}
+
+	var ret []*DropExplanation
+	for _, ev := range events {
+		e := &DropExplanation{
+			Event: ev,
+			Layer: layerByKID[ev.LayerID],
+		}
+		if ev.FilterID != 0 {
+			e.Rule = ruleByKID[ev.FilterID]
+		}
+		if e.Rule != nil {
+			e.Sublayer = sublayerByID[e.Rule.Sublayer]
+			if !e.Rule.Provider.IsZero() {
+				e.Provider = providerByID[e.Rule.Provider]
+			}
+		}
+		ret = append(ret, e)
+	}
+	return ret
+}
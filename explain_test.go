@@ -0,0 +1,70 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExplainDrops(t *testing.T) {
+	m := NewMemoryEngine(nil)
+	s := newMemorySession(t, m, nil)
+	defer s.Close()
+
+	provider := &Provider{ID: ProviderID{Data1: 1}, Name: "provider"}
+	if err := s.AddProvider(provider); err != nil {
+		t.Fatal(err)
+	}
+	rule := &Rule{
+		ID:       RuleID{Data1: 1},
+		Name:     "block",
+		Layer:    LayerALEAuthConnectV4,
+		Provider: provider.ID,
+		Action:   ActionBlock,
+	}
+	if err := s.AddRule(rule); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := s.Rules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid := rules[0].KernelID
+	layer := DefaultSchema().Layer(LayerALEAuthConnectV4)
+
+	m.AddDropEvent(&DropEvent{LayerID: layer.KernelID, FilterID: kid})
+	m.AddDropEvent(&DropEvent{LayerID: layer.KernelID, FilterID: kid + 100})
+	m.AddDropEvent(&DropEvent{LayerID: 9999})
+
+	exps, err := s.ExplainDrops()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range exps {
+		got = append(got, e.String())
+	}
+	want := []string{
+		`dropped in ALE_AUTH_CONNECT_V4 by rule {00000001-0000-0000-0000-000000000000} "block"`,
+		fmt.Sprintf("dropped in ALE_AUTH_CONNECT_V4 by deleted rule %d", kid+100),
+		`dropped in layer 9999`,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Fatalf("explanations are wrong (-got+want):\n%s", diff)
+	}
+
+	if exps[0].Provider == nil || exps[0].Provider.ID != provider.ID {
+		t.Fatalf("first drop has provider %v, want %v", exps[0].Provider, provider)
+	}
+	if exps[0].Sublayer == nil || exps[0].Sublayer.ID != layer.DefaultSublayer {
+		t.Fatalf("first drop has sublayer %v, want %s", exps[0].Sublayer, layer.DefaultSublayer)
+	}
+	if !exps[1].RuleMissing() || exps[2].RuleMissing() {
+		t.Fatalf("RuleMissing is wrong: got %v, %v, want true, false", exps[1].RuleMissing(), exps[2].RuleMissing())
+	}
+}
@@ -7,12 +7,11 @@ package wf
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/netip"
 	"reflect"
+	"sync"
 	"time"
-	"unsafe"
-
-	"golang.org/x/sys/windows"
 )
 
 type fieldTypes map[FieldID]reflect.Type
@@ -20,11 +19,14 @@ type layerTypes map[LayerID]fieldTypes
 
 // Session is a connection to the WFP API.
 type Session struct {
-	handle windows.Handle
-	// layerTypes is a map of layer ID -> field ID -> Go type for that field.
-	layerTypes layerTypes
+	engine Engine
 	// indicates if we are currently in a transaction
 	status TransactionStatus
+
+	// subsMu guards subs, the open subscriptions, which Close
+	// ends.
+	subsMu sync.Mutex
+	subs   map[*subscription]bool
 }
 
 // Options configures a Session.
@@ -51,6 +53,17 @@ type Options struct {
 	// TransactionFlags indicate if we want read-only or read/write
 	// access
 	TransactionFlags TransactionFlag
+	// Backend, if non-nil, is used to open the session's Engine
+	// instead of connecting to the local Windows Filtering
+	// Platform. Use a MemoryEngine to run code built on Session
+	// without a live filtering engine. Backend is required on
+	// platforms other than Windows.
+	Backend Backend
+	// Schema, if non-nil, describes the engine's layers and their
+	// fields, which are then not enumerated when the session
+	// opens. If nil, the layers are enumerated from the engine, and
+	// DefaultSchema describes any layer the engine doesn't report.
+	Schema *Schema
 }
 
 // Util enum to track different states
@@ -88,19 +101,16 @@ func New(opts *Options) (*Session, error) {
 		opts = &Options{}
 	}
 
-	var a arena
-	defer a.Dispose()
-
-	s0 := toSession0(&a, opts)
-
-	var handle windows.Handle
-	err := fwpmEngineOpen0(nil, authnServiceWinNT, nil, s0, &handle)
+	backend := opts.Backend
+	if backend == nil {
+		backend = defaultBackend
+	}
+	engine, err := backend.Open(opts)
 	if err != nil {
 		return nil, err
 	}
 	ret := &Session{
-		handle:     handle,
-		layerTypes: layerTypes{},
+		engine: engine,
 	}
 
 	if opts.StartTransaction {
@@ -109,47 +119,28 @@ func New(opts *Options) (*Session, error) {
 		ret.BeginTransaction(opts.TransactionFlags)
 	}
 
-	// Populate the layer type cache.
-	layers, err := ret.Layers()
-	if err != nil {
-		ret.Close()
-		return nil, err
-	}
-	for _, layer := range layers {
-		fields := fieldTypes{}
-		for _, field := range layer.Fields {
-			fields[field.ID] = field.Type
-		}
-		ret.layerTypes[layer.ID] = fields
-	}
-
 	return ret, nil
 }
 
 // Close implements io.Closer.
 func (s *Session) Close() error {
-	if s.handle == 0 {
-		return nil
-	}
-
 	// if we have a transaction in progress that was not commited, abort it now
 	if s.status.State == BeganTransaction {
 		s.AbortTransaction()
 	}
+	s.closeSubs()
 
-	err := fwpmEngineClose0(s.handle)
-	s.handle = 0
-	return err
+	return s.engine.Close()
 }
 
 // LayerID identifies a WFP layer.
-type LayerID windows.GUID
+type LayerID GUID
 
 func (id LayerID) String() string {
-	if s := guidNames[windows.GUID(id)]; s != "" {
+	if s := guidNames[GUID(id)]; s != "" {
 		return s
 	}
-	return windows.GUID(id).String()
+	return GUID(id).String()
 }
 
 // IsZero reports whether id is nil or the zero GUID.
@@ -192,19 +183,6 @@ const (
 	IPProtoUDP    IPProto = 17
 )
 
-// AppID returns the application ID associated with the provided file.
-func AppID(file string) (string, error) {
-	var a arena
-	defer a.Dispose()
-	fileBytes, _ := toBytesFromString(&a, file)
-	var appID *fwpByteBlob
-	if err := fwpmGetAppIdFromFileName0(fileBytes, &appID); err != nil {
-		return "", err
-	}
-	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&appID)))
-	return fromByteBlobToString(appID)
-}
-
 // Layer is a point in the packet processing path where filter rules
 // can be applied.
 type Layer struct {
@@ -225,13 +203,13 @@ type Layer struct {
 }
 
 // FieldID identifies a WFP layer field.
-type FieldID windows.GUID
+type FieldID GUID
 
 func (id FieldID) String() string {
-	if s := guidNames[windows.GUID(id)]; s != "" {
+	if s := guidNames[GUID(id)]; s != "" {
 		return s
 	}
-	return windows.GUID(id).String()
+	return GUID(id).String()
 }
 
 // IsZero reports whether id is nil or the zero GUID.
@@ -248,6 +226,24 @@ type Field struct {
 	Type reflect.Type
 }
 
+// Go types used to represent the values of layer fields. typeSID
+// and typeSecurityDescriptor are platform-specific.
+var (
+	typeUint8            = reflect.TypeOf(uint8(0))
+	typeUint16           = reflect.TypeOf(uint16(0))
+	typeUint32           = reflect.TypeOf(uint32(0))
+	typeUint64           = reflect.TypeOf(uint64(0))
+	typeArray16          = reflect.TypeOf([16]byte{})
+	typeBytes            = reflect.TypeOf([]byte(nil))
+	typeTokenInformation = reflect.TypeOf(TokenInformation{})
+	typeMAC              = reflect.TypeOf(net.HardwareAddr{})
+	typeBitmapIndex      = reflect.TypeOf(uint8(0))
+	typeIP               = reflect.TypeOf(netip.Addr{})
+	typePrefix           = reflect.TypeOf(netip.Prefix{})
+	typeRange            = reflect.TypeOf(Range{})
+	typeString           = reflect.TypeOf("")
+)
+
 // TokenAccessInformation represents all the information in a token
 // that is necessary to perform an access check.
 // This type is only present in Layer fields, and cannot be used
@@ -265,51 +261,17 @@ type TokenInformation struct{}
 
 // Layers returns information on available WFP layers.
 func (s *Session) Layers() ([]*Layer, error) {
-	var enum windows.Handle
-	if err := fwpmLayerCreateEnumHandle0(s.handle, nil, &enum); err != nil {
-		return nil, err
-	}
-	defer fwpmLayerDestroyEnumHandle0(s.handle, enum)
-
-	var ret []*Layer
-
-	for {
-		layers, err := s.getLayerPage(enum)
-		if err != nil {
-			return nil, err
-		}
-		if len(layers) == 0 {
-			return ret, nil
-		}
-		ret = append(ret, layers...)
-	}
-}
-
-func (s *Session) getLayerPage(enum windows.Handle) ([]*Layer, error) {
-	const pageSize = 100
-	var (
-		array **fwpmLayer0
-		num   uint32
-	)
-	if err := fwpmLayerEnum0(s.handle, enum, pageSize, &array, &num); err != nil {
-		return nil, err
-	}
-	if num == 0 {
-		return nil, nil
-	}
-	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&array)))
-
-	return fromLayer0(array, num)
+	return s.engine.Layers()
 }
 
 // SublayerID identifies a WFP sublayer.
-type SublayerID windows.GUID
+type SublayerID GUID
 
 func (id SublayerID) String() string {
-	if s := guidNames[windows.GUID(id)]; s != "" {
+	if s := guidNames[GUID(id)]; s != "" {
 		return s
 	}
-	return windows.GUID(id).String()
+	return GUID(id).String()
 }
 
 // IsZero reports whether id is nil or the zero GUID.
@@ -337,6 +299,11 @@ type Sublayer struct {
 	// Weight specifies the priority of this sublayer relative to
 	// other sublayers. Higher-weighted sublayers are invoked first.
 	Weight uint16
+	// SecurityDescriptor is an optional SDDL security descriptor
+	// that controls access to the sublayer. If empty, the
+	// filtering engine applies a default one. Only used on
+	// Sublayer creation; see Session.SublayerSecurity to read it.
+	SecurityDescriptor string
 }
 
 // Sublayers returns available Sublayers. If providers are given,
@@ -350,7 +317,7 @@ func (s *Session) Sublayers(providers ...ProviderID) ([]*Sublayer, error) {
 
 	var ret []*Sublayer
 	for _, provider := range providers {
-		sls, err := s.getOneProvider(provider)
+		sls, err := s.engine.Sublayers(provider)
 		if err != nil {
 			return nil, err
 		}
@@ -360,49 +327,6 @@ func (s *Session) Sublayers(providers ...ProviderID) ([]*Sublayer, error) {
 	return ret, nil
 }
 
-func (s *Session) getOneProvider(provider ProviderID) ([]*Sublayer, error) {
-	var a arena
-	defer a.Dispose()
-
-	tpl := toSublayerEnumTemplate0(&a, provider)
-
-	var enum windows.Handle
-	if err := fwpmSubLayerCreateEnumHandle0(s.handle, tpl, &enum); err != nil {
-		return nil, err
-	}
-	defer fwpmSubLayerDestroyEnumHandle0(s.handle, enum)
-
-	var ret []*Sublayer
-
-	for {
-		sublayers, err := s.getSublayerPage(enum)
-		if err != nil {
-			return nil, err
-		}
-		if len(sublayers) == 0 {
-			return ret, nil
-		}
-		ret = append(ret, sublayers...)
-	}
-}
-
-func (s *Session) getSublayerPage(enum windows.Handle) ([]*Sublayer, error) {
-	const pageSize = 100
-	var (
-		array **fwpmSublayer0
-		num   uint32
-	)
-	if err := fwpmSubLayerEnum0(s.handle, enum, pageSize, &array, &num); err != nil {
-		return nil, err
-	}
-	if num == 0 {
-		return nil, nil
-	}
-	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&array)))
-
-	return fromSublayer0(array, num), nil
-}
-
 // AddSublayer creates a new Sublayer.
 func (s *Session) AddSublayer(sl *Sublayer) error {
 	// the WFP API accepts zero GUIDs and interprets it as "give me a
@@ -412,11 +336,7 @@ func (s *Session) AddSublayer(sl *Sublayer) error {
 		return errors.New("Sublayer.ID cannot be zero")
 	}
 
-	var a arena
-	defer a.Dispose()
-
-	sl0 := toSublayer0(&a, sl)
-	return fwpmSubLayerAdd0(s.handle, sl0, nil) // TODO: security descriptor
+	return s.engine.AddSublayer(sl)
 }
 
 // DeleteSublayer deletes the Sublayer whose GUID is id.
@@ -425,17 +345,17 @@ func (s *Session) DeleteSublayer(id SublayerID) error {
 		return errors.New("GUID cannot be zero")
 	}
 
-	return fwpmSubLayerDeleteByKey0(s.handle, &id)
+	return s.engine.DeleteSublayer(id)
 }
 
 // ProviderID identifies a WFP provider.
-type ProviderID windows.GUID
+type ProviderID GUID
 
 func (id ProviderID) String() string {
-	if s := guidNames[windows.GUID(id)]; s != "" {
+	if s := guidNames[GUID(id)]; s != "" {
 		return s
 	}
-	return windows.GUID(id).String()
+	return GUID(id).String()
 }
 
 // IsZero reports whether id is nil or the zero GUID.
@@ -461,6 +381,11 @@ type Provider struct {
 	// the rules owned by this Provider are only activated when the
 	// service is active.
 	ServiceName string
+	// SecurityDescriptor is an optional SDDL security descriptor
+	// that controls access to the provider. If empty, the
+	// filtering engine applies a default one. Only used on
+	// Provider creation; see Session.ProviderSecurity to read it.
+	SecurityDescriptor string
 
 	// Disabled indicates whether the rules owned by this Provider are
 	// disabled due to its associated service being
@@ -468,42 +393,9 @@ type Provider struct {
 	Disabled bool
 }
 
+// Providers returns all the Providers known to the filtering engine.
 func (s *Session) Providers() ([]*Provider, error) {
-	var enum windows.Handle
-	if err := fwpmProviderCreateEnumHandle0(s.handle, nil, &enum); err != nil {
-		return nil, err
-	}
-	defer fwpmProviderDestroyEnumHandle0(s.handle, enum)
-
-	var ret []*Provider
-
-	for {
-		providers, err := s.getProviderPage(enum)
-		if err != nil {
-			return nil, err
-		}
-		if len(providers) == 0 {
-			return ret, nil
-		}
-		ret = append(ret, providers...)
-	}
-}
-
-func (s *Session) getProviderPage(enum windows.Handle) ([]*Provider, error) {
-	const pageSize = 100
-	var (
-		array **fwpmProvider0
-		num   uint32
-	)
-	if err := fwpmProviderEnum0(s.handle, enum, pageSize, &array, &num); err != nil {
-		return nil, err
-	}
-	if num == 0 {
-		return nil, nil
-	}
-	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&array)))
-
-	return fromProvider0(array, num), nil
+	return s.engine.Providers()
 }
 
 // AddProvider creates a new provider.
@@ -512,12 +404,7 @@ func (s *Session) AddProvider(p *Provider) error {
 		return errors.New("Provider.ID cannot be zero")
 	}
 
-	var a arena
-	defer a.Dispose()
-
-	p0 := toProvider0(&a, p)
-
-	return fwpmProviderAdd0(s.handle, p0, nil)
+	return s.engine.AddProvider(p)
 }
 
 // DeleteProvider deletes the Provider whose GUID is id. A provider
@@ -528,7 +415,94 @@ func (s *Session) DeleteProvider(id ProviderID) error {
 		return errors.New("GUID cannot be zero")
 	}
 
-	return fwpmProviderDeleteByKey0(s.handle, &id)
+	return s.engine.DeleteProvider(id)
+}
+
+// ProviderContextID identifies a WFP provider context.
+type ProviderContextID GUID
+
+func (id ProviderContextID) String() string {
+	if s := guidNames[GUID(id)]; s != "" {
+		return s
+	}
+	return GUID(id).String()
+}
+
+// IsZero reports whether id is nil or the zero GUID.
+func (id *ProviderContextID) IsZero() bool {
+	return id == nil || *id == ProviderContextID{}
+}
+
+// ProviderContextType is the kind of data held by a ProviderContext.
+type ProviderContextType uint32 // do not change type, used in C calls
+
+// ProviderContextGeneral is the type of provider contexts holding
+// opaque data for callout drivers. The other types of provider
+// context hold IPsec policy, which this package doesn't model.
+const ProviderContextGeneral ProviderContextType = 8 // FWPM_GENERAL_CONTEXT
+
+// A ProviderContext is configuration data stored in the filtering
+// engine, that rules pass to the callouts they invoke. See
+// Rule.ProviderContext.
+type ProviderContext struct {
+	// ID is the unique identifier for this provider context.
+	ID ProviderContextID
+	// KernelID is the kernel ID for this provider context.
+	// Read-only, ignored on ProviderContext creation.
+	KernelID uint64
+	// Name is a short descriptive name.
+	Name string
+	// Description is a longer description of the provider context.
+	Description string
+	// Persistent indicates whether the provider context is
+	// preserved across restarts of the filtering engine.
+	Persistent bool
+	// Provider optionally identifies the Provider that manages this
+	// provider context.
+	Provider ProviderID
+	// ProviderData is optional opaque data that can be held on behalf
+	// of the Provider.
+	ProviderData []byte
+	// Type is the type of the provider context. Only
+	// ProviderContextGeneral contexts can be created.
+	Type ProviderContextType
+	// Data is the data passed to callouts, for ProviderContextGeneral
+	// contexts.
+	Data []byte
+	// SecurityDescriptor is an optional SDDL security descriptor
+	// that controls access to the provider context. If empty, the
+	// filtering engine applies a default one. Only used on
+	// ProviderContext creation.
+	SecurityDescriptor string
+}
+
+// ProviderContexts returns all the ProviderContexts known to the
+// filtering engine.
+func (s *Session) ProviderContexts() ([]*ProviderContext, error) {
+	return s.engine.ProviderContexts()
+}
+
+// AddProviderContext creates a new ProviderContext.
+func (s *Session) AddProviderContext(pc *ProviderContext) error {
+	if pc.ID.IsZero() {
+		return errors.New("ProviderContext.ID cannot be zero")
+	}
+	if pc.Type != ProviderContextGeneral {
+		return fmt.Errorf("cannot create provider context of type %d, only ProviderContextGeneral is supported", pc.Type)
+	}
+
+	return s.engine.AddProviderContext(pc)
+}
+
+// DeleteProviderContext deletes the ProviderContext whose GUID is
+// id. Rules that reference the provider context must be deleted
+// first.
+func (s *Session) DeleteProviderContext(id ProviderContextID) error {
+	if id.IsZero() {
+		return errors.New("GUID cannot be zero")
+	}
+
+	return s.engine.DeleteProviderContext(id)
 }
 
 // MatchType is the operator to use when testing a field in a Match.
@@ -610,13 +584,13 @@ const (
 )
 
 // RuleID identifies a WFP filtering rule.
-type RuleID windows.GUID
+type RuleID GUID
 
 func (id RuleID) String() string {
-	if s := guidNames[windows.GUID(id)]; s != "" {
+	if s := guidNames[GUID(id)]; s != "" {
 		return s
 	}
-	return windows.GUID(id).String()
+	return GUID(id).String()
 }
 
 // IsZero reports whether id is nil or the zero GUID.
@@ -625,13 +599,13 @@ func (id *RuleID) IsZero() bool {
 }
 
 // CalloutID identifies a WFP callout function.
-type CalloutID windows.GUID
+type CalloutID GUID
 
 func (id CalloutID) String() string {
-	if s := guidNames[windows.GUID(id)]; s != "" {
+	if s := guidNames[GUID(id)]; s != "" {
 		return s
 	}
-	return windows.GUID(id).String()
+	return GUID(id).String()
 }
 
 // IsZero reports whether id is nil or the zero GUID.
@@ -639,6 +613,74 @@ func (id *CalloutID) IsZero() bool {
 	return id == nil || *id == CalloutID{}
 }
 
+// A Callout is the management-side registration of a callout: a set
+// of functions in a kernel driver that rules can invoke to inspect
+// or modify traffic. Only the driver can register the functions
+// themselves; rules that invoke a callout whose functions aren't
+// registered block or permit according to their PermitIfMissing.
+type Callout struct {
+	// ID is the unique identifier for this callout.
+	ID CalloutID
+	// KernelID is the kernel ID for this callout. Read-only,
+	// ignored on Callout creation.
+	KernelID uint32
+	// Name is a short descriptive name.
+	Name string
+	// Description is a longer description of the callout.
+	Description string
+	// Layer is the ID of the layer in which the callout can be
+	// used. Rules that invoke the callout must be in this layer.
+	Layer LayerID
+	// Persistent indicates whether the callout is preserved across
+	// restarts of the filtering engine.
+	Persistent bool
+	// UsesProviderContext indicates that the callout uses the
+	// provider context of the rules that invoke it.
+	UsesProviderContext bool
+	// Provider optionally identifies the Provider that manages this
+	// callout.
+	Provider ProviderID
+	// ProviderData is optional opaque data that can be held on behalf
+	// of the Provider.
+	ProviderData []byte
+	// SecurityDescriptor is an optional SDDL security descriptor
+	// that controls access to the callout. If empty, the filtering
+	// engine applies a default one. Only used on Callout creation.
+	SecurityDescriptor string
+
+	// Registered indicates whether a driver has registered the
+	// callout's functions with the filtering engine. Read-only,
+	// ignored on Callout creation.
+	Registered bool
+}
+
+// Callouts returns all the Callouts known to the filtering engine.
+func (s *Session) Callouts() ([]*Callout, error) {
+	return s.engine.Callouts()
+}
+
+// AddCallout creates a new Callout.
+func (s *Session) AddCallout(c *Callout) error {
+	if c.ID.IsZero() {
+		return errors.New("Callout.ID cannot be zero")
+	}
+	if c.Layer.IsZero() {
+		return errors.New("Callout.Layer cannot be zero")
+	}
+
+	return s.engine.AddCallout(c)
+}
+
+// DeleteCallout deletes the Callout whose GUID is id. Rules that
+// invoke the callout must be deleted first.
+func (s *Session) DeleteCallout(id CalloutID) error {
+	if id.IsZero() {
+		return errors.New("GUID cannot be zero")
+	}
+
+	return s.engine.DeleteCallout(id)
+}
+
 // A Rule is an action to take on packets that match a set of
 // conditions.
 type Rule struct {
@@ -674,6 +716,13 @@ type Rule struct {
 	// HardAction, if set, indicates that the action type is hard and cannot
 	// be overridden except by a Veto.
 	HardAction bool
+	// ProviderContext optionally identifies a ProviderContext whose
+	// data is passed to the callout invoked by the rule.
+	ProviderContext ProviderContextID
+	// RawContext is an optional opaque value passed to the callout
+	// invoked by the rule. It cannot be set along with
+	// ProviderContext.
+	RawContext uint64
 
 	// Persistent indicates whether the rule is preserved across
 	// restarts of the filtering engine.
@@ -690,6 +739,12 @@ type Rule struct {
 	// of the Provider.
 	ProviderData []byte
 
+	// SecurityDescriptor is an optional SDDL security descriptor
+	// that controls access to the rule, e.g. who may delete it. If
+	// empty, the filtering engine applies a default one. Only used
+	// on Rule creation; see Session.RuleSecurity to read it.
+	SecurityDescriptor string
+
 	// Disabled indicates whether the rule is currently disabled due
 	// to its provider being associated with an inactive Windows
 	// service. See Provider.ServiceName for details.
@@ -697,73 +752,36 @@ type Rule struct {
 }
 
 // TODO: figure out what currently unexposed flags do: Indexed
-// TODO: figure out what ProviderContextKey is about. MSDN doesn't explain what contexts are.
-
-func (s *Session) Rules() ([]*Rule, error) { // TODO: support filter settings
-	var enum windows.Handle
-	if err := fwpmFilterCreateEnumHandle0(s.handle, nil, &enum); err != nil {
-		return nil, err
-	}
-	defer fwpmFilterDestroyEnumHandle0(s.handle, enum)
 
-	var ret []*Rule
-
-	for {
-		rules, err := s.getRulePage(enum)
-		if err != nil {
-			return nil, err
-		}
-		if len(rules) == 0 {
-			return ret, nil
-		}
-		ret = append(ret, rules...)
-	}
-}
-
-func (s *Session) getRulePage(enum windows.Handle) ([]*Rule, error) {
-	const pageSize = 100
-	var (
-		array **fwpmFilter0
-		num   uint32
-	)
-	if err := fwpmFilterEnum0(s.handle, enum, pageSize, &array, &num); err != nil {
-		return nil, err
-	}
-	if num == 0 {
-		return nil, nil
-	}
-	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&array)))
-
-	return fromFilter0(array, num, s.layerTypes)
+// Rules returns all the Rules known to the filtering engine. Use
+// EnumerateRules to restrict the results.
+func (s *Session) Rules() ([]*Rule, error) {
+	return s.engine.Rules(nil)
 }
 
+// AddRule creates a new Rule.
 func (s *Session) AddRule(r *Rule) error {
 	if r.ID.IsZero() {
 		return errors.New("Provider.ID cannot be zero")
 	}
-
-	var a arena
-	defer a.Dispose()
-
-	f, err := toFilter0(&a, r, s.layerTypes)
-	if err != nil {
-		return err
-	}
-	if err := fwpmFilterAdd0(s.handle, f, nil, &f.FilterID); err != nil {
-		return err
+	if !r.ProviderContext.IsZero() && r.RawContext != 0 {
+		return errors.New("Rule.ProviderContext and Rule.RawContext cannot both be set")
 	}
 
-	return nil
+	return s.engine.AddRule(r)
 }
 
+// DeleteRule deletes the Rule whose GUID is id.
 func (s *Session) DeleteRule(id RuleID) error {
 	if id.IsZero() {
 		return errors.New("GUID cannot be zero")
 	}
 
-	return fwpmFilterDeleteByKey0(s.handle, &id)
+	return s.engine.DeleteRule(id)
 }
 
+// A DropEvent is a packet dropped by a rule. It is a summary of a
+// classify drop NetEvent.
 type DropEvent struct {
 	Timestamp  time.Time
 	IPProtocol uint8
@@ -775,46 +793,18 @@ type DropEvent struct {
 	FilterID uint64
 }
 
+// DropEvents returns the packet drop events recorded by the
+// filtering engine.
 func (s *Session) DropEvents() ([]*DropEvent, error) {
-	var enum windows.Handle
-	if err := fwpmNetEventCreateEnumHandle0(s.handle, nil, &enum); err != nil {
-		return nil, err
-	}
-	defer fwpmNetEventDestroyEnumHandle0(s.handle, enum)
-
-	var ret []*DropEvent
-
-	for {
-		events, err := s.getEventPage(enum)
-		if err != nil {
-			return nil, err
-		}
-		if len(events) == 0 {
-			return ret, nil
-		}
-		ret = append(ret, events...)
-	}
-}
-
-func (s *Session) getEventPage(enum windows.Handle) ([]*DropEvent, error) {
-	const pageSize = 100
-	var (
-		array **fwpmNetEvent1
-		num   uint32
-	)
-	if err := fwpmNetEventEnum1(s.handle, enum, pageSize, &array, &num); err != nil {
+	events, err := s.engine.NetEvents(nil)
+	if err != nil {
 		return nil, err
 	}
-	if num == 0 {
-		return nil, nil
-	}
-	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&array)))
-
-	return fromNetEvent1(array, num)
+	return dropEvents(events), nil
 }
 
 func (s *Session) BeginTransaction(p TransactionFlag) {
-	err := fwpmTransactionBegin0(s.handle, uint32(p))
+	err := s.engine.BeginTransaction(p)
 	if err == nil {
 		s.status.State = BeganTransaction
 		return
@@ -824,7 +814,7 @@ func (s *Session) BeginTransaction(p TransactionFlag) {
 }
 
 func (s *Session) AbortTransaction() {
-	err := fwpmTransactionAbort0(s.handle)
+	err := s.engine.AbortTransaction()
 	if err == nil {
 		s.status.State = AbortedTransaction
 		return
@@ -834,7 +824,7 @@ func (s *Session) AbortTransaction() {
 }
 
 func (s *Session) CommitTransaction() {
-	err := fwpmTransactionCommit0(s.handle)
+	err := s.engine.CommitTransaction()
 	if err == nil {
 		s.status.State = CommittedTransaction
 		return
@@ -0,0 +1,24 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package wf
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// defaultBackend is the Backend used when Options.Backend is nil.
+var defaultBackend Backend = unsupportedBackend{}
+
+// unsupportedBackend is the default Backend on platforms that don't
+// have a Windows Filtering Platform.
+type unsupportedBackend struct{}
+
+func (unsupportedBackend) Open(*Options) (Engine, error) {
+	return nil, fmt.Errorf("the Windows Filtering Platform is not available on %s, use Options.Backend", runtime.GOOS)
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"unsafe"
+)
+
+// defaultBackend is the Backend used when Options.Backend is nil.
+var defaultBackend Backend = winBackend{}
+
+// AppID returns the application ID associated with the provided file.
+func AppID(file string) (string, error) {
+	var a arena
+	defer a.Dispose()
+	fileBytes, _ := toBytesFromString(&a, file)
+	var appID *fwpByteBlob
+	if err := fwpmGetAppIdFromFileName0(fileBytes, &appID); err != nil {
+		return "", err
+	}
+	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&appID)))
+	return fromByteBlobToString(appID)
+}
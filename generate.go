@@ -7,13 +7,20 @@ package wf
 //go:generate go run generators/gen_guids.go includes/fwpmu.h zguids.go
 //go:generate go run golang.org/x/sys/windows/mkwinsyscall -output zsyscall_windows.go syscall.go
 
-//go:generate stringer -output=zfieldtype_strings.go -type=fwpmFieldType -trimprefix=fwpmFieldtype
-//go:generate stringer -output=zsublayerflags_strings.go -type=fwpmSublayerFlags -trimprefix=fwpmSublayerFlags
+//go:generate stringer -output=zfieldtype_strings_windows.go -type=fwpmFieldType -trimprefix=fwpmFieldtype
+//go:generate stringer -output=zsublayerflags_strings_windows.go -type=fwpmSublayerFlags -trimprefix=fwpmSublayerFlags
 //go:generate stringer -output=zfilterenumtype_strings.go -type=filterEnumType -trimprefix=filterEnumType
 //go:generate stringer -output=zfilterenumflags_strings.go -type=filterEnumFlags -trimprefix=filterEnumFlags
 //go:generate stringer -output=zaction_strings.go -type=Action -trimprefix=Action
-//go:generate stringer -output=zfilterflags_strings.go -type=fwpmFilterFlags -trimprefix=fwpmFilterFlags
-//go:generate stringer -output=zproviderflags_strings.go -type=fwpmProviderFlags -trimprefix=fwpmProviderFlags
-//go:generate stringer -output=zdatatype_strings.go -type=dataType -trimprefix=dataType
+//go:generate stringer -output=zfilterflags_strings_windows.go -type=fwpmFilterFlags -trimprefix=fwpmFilterFlags
+//go:generate stringer -output=zproviderflags_strings_windows.go -type=fwpmProviderFlags -trimprefix=fwpmProviderFlags
+//go:generate stringer -output=zdatatype_strings_windows.go -type=dataType -trimprefix=dataType
 //go:generate stringer -output=zconditionflag_strings.go -type=ConditionFlag -trimprefix=ConditionFlag
 //go:generate stringer -output=zipproto_strings.go -type=IPProto -trimprefix=IPProto
+//go:generate stringer -output=znetevent_strings.go -type=NetEventType -trimprefix=NetEventType
+
+// zschema.go is generated from a live filtering engine, which needs
+// Windows and administrator rights, so it is refreshed separately
+// with:
+//
+//	go run generators/gen_schema.go -zguids zguids.go -o zschema.go
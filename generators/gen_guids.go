@@ -35,8 +35,6 @@ func main() {
 	var out bytes.Buffer
 	out.WriteString(`package wf
 
-import "golang.org/x/sys/windows"
-
 `)
 
 	defs := map[string][]string{}
@@ -82,11 +80,11 @@ import "golang.org/x/sys/windows"
 
 	sort.Slice(generated, func(i, j int) bool { return generated[i] < generated[j] })
 
-	out.WriteString("var guidNames = map[windows.GUID]string{\n")
+	out.WriteString("var guidNames = map[GUID]string{\n")
 	for _, name := range generated {
 		v := name.VarName()
-		if name.GoType() != "windows.GUID" {
-			v = fmt.Sprintf("windows.GUID(%s)", v)
+		if name.GoType() != "GUID" {
+			v = fmt.Sprintf("GUID(%s)", v)
 		}
 		fmt.Fprintf(&out, "%s: %q,\n", v, name.String())
 	}
@@ -131,7 +129,7 @@ func (g guidName) GoType() string {
 	if g.Exported() || g.Type() == "sublayer" {
 		return strings.Title(g.Type()) + "ID"
 	}
-	return "windows.GUID"
+	return "GUID"
 }
 
 // String returns the pretty string for the GUID.
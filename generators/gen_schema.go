@@ -0,0 +1,176 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+// +build ignore
+
+// gen_schema writes zschema.go from the layers reported by the local
+// filtering engine. It must run on Windows, as an administrator, so it
+// isn't part of go generate. Run it from the package directory with:
+//
+//	go run generators/gen_schema.go -zguids zguids.go -o zschema.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"inet.af/wf"
+)
+
+func fatalf(msg string, args ...interface{}) {
+	fmt.Printf(msg+"\n", args...)
+	os.Exit(1)
+}
+
+// typeVars maps the string form of field types to the names of the
+// variables that hold them in package wf.
+var typeVars = map[string]string{
+	"uint8":                       "typeUint8",
+	"uint16":                      "typeUint16",
+	"uint32":                      "typeUint32",
+	"uint64":                      "typeUint64",
+	"[16]uint8":                   "typeArray16",
+	"[]uint8":                     "typeBytes",
+	"wf.TokenInformation":         "typeTokenInformation",
+	"net.HardwareAddr":            "typeMAC",
+	"netip.Addr":                  "typeIP",
+	"netip.Prefix":                "typePrefix",
+	"wf.Range":                    "typeRange",
+	"string":                      "typeString",
+	"*windows.SID":                "typeSID",
+	"windows.SECURITY_DESCRIPTOR": "typeSecurityDescriptor",
+}
+
+// goName returns the Go expression for a well-known ID whose String
+// form is name, using the exported variables of package wf.
+func goName(prefix, name string, vars map[string]string) string {
+	if v, ok := vars[name]; ok {
+		return v
+	}
+	fatalf("no Go variable for %s %s", prefix, name)
+	return ""
+}
+
+var (
+	zguidsPath = flag.String("zguids", "zguids.go", "path of zguids.go, to find the Go names of layers and fields")
+	outPath    = flag.String("o", "zschema.go", "path of the output file")
+)
+
+func main() {
+	flag.Parse()
+
+	sess, err := wf.New(&wf.Options{Name: "gen_schema", Dynamic: true})
+	if err != nil {
+		fatalf("opening session: %v", err)
+	}
+	defer sess.Close()
+	layers, err := sess.Layers()
+	if err != nil {
+		fatalf("listing layers: %v", err)
+	}
+	sort.Slice(layers, func(i, j int) bool {
+		return layers[i].KernelID < layers[j].KernelID
+	})
+
+	layerVars, fieldVars := knownVars(*zguidsPath)
+	fieldTypes := map[string]string{}
+
+	var out bytes.Buffer
+	out.WriteString(`// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by generators/gen_schema.go. DO NOT EDIT.
+
+// This file describes the well-known WFP layers and their fields, as
+// reported by the filtering engine. See generators/gen_schema.go for
+// how to refresh it.
+
+package wf
+
+import "reflect"
+
+`)
+
+	var layerOut bytes.Buffer
+	layerOut.WriteString("// staticLayers describes the well-known layers and the fields\n// available in each.\nvar staticLayers = []staticLayer{\n")
+	for _, l := range layers {
+		fmt.Fprintf(&layerOut, "{\nID: %s,\nKernelID: %d,\nName: %q,\n", goName("layer", l.ID.String(), layerVars), l.KernelID, l.Name)
+		var fields []string
+		for _, f := range l.Fields {
+			name := goName("field", f.ID.String(), fieldVars)
+			typ, ok := typeVars[f.Type.String()]
+			if !ok {
+				fatalf("no Go variable for type %s", f.Type)
+			}
+			if prev, ok := fieldTypes[name]; ok && prev != typ {
+				fatalf("field %s has type %s and %s", name, prev, typ)
+			}
+			fieldTypes[name] = typ
+			fields = append(fields, name)
+		}
+		sort.Strings(fields)
+		if len(fields) > 0 {
+			layerOut.WriteString("Fields: []FieldID{\n")
+			for _, f := range fields {
+				fmt.Fprintf(&layerOut, "%s,\n", f)
+			}
+			layerOut.WriteString("},\n")
+		}
+		layerOut.WriteString("},\n")
+	}
+	layerOut.WriteString("}\n")
+
+	var names []string
+	for name := range fieldTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out.WriteString("// staticFieldTypes maps each well-known field to the Go type of its\n// values.\nvar staticFieldTypes = map[FieldID]reflect.Type{\n")
+	for _, name := range names {
+		fmt.Fprintf(&out, "%s: %s,\n", name, fieldTypes[name])
+	}
+	out.WriteString("}\n\n")
+	out.Write(layerOut.Bytes())
+
+	bs, err := format.Source(out.Bytes())
+	if err != nil {
+		fatalf("formatting output: %v", err)
+	}
+	if err := ioutil.WriteFile(*outPath, bs, 0644); err != nil {
+		fatalf("writing output: %v", err)
+	}
+}
+
+// knownVars returns maps from the String form of well-known layer and
+// field IDs to the names of their Go variables in package wf, read
+// from the guidNames map in zguids.go.
+func knownVars(zguidsPath string) (layers, fields map[string]string) {
+	bs, err := ioutil.ReadFile(zguidsPath)
+	if err != nil {
+		fatalf("reading zguids.go: %v", err)
+	}
+	layers, fields = map[string]string{}, map[string]string{}
+	for _, m := range guidNameRe.FindAllStringSubmatch(string(bs), -1) {
+		varName, name := m[1], m[2]
+		switch {
+		case strings.HasPrefix(varName, "Layer"):
+			layers[name] = varName
+		case strings.HasPrefix(varName, "Field"):
+			fields[name] = varName
+		}
+	}
+	return layers, fields
+}
+
+// guidNameRe matches the entries of guidNames in zguids.go.
+var guidNameRe = regexp.MustCompile(`GUID\((\w+)\):\s*"([^"]+)"`)
@@ -0,0 +1,662 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go4.org/netipx"
+)
+
+// This file implements JSON encoding for Rule, Match, Provider,
+// Sublayer and Layer. IDs are written as their well-known names when
+// they have one, and as GUID strings otherwise, unless MarshalJSON is
+// asked for IDGUIDs; both forms are accepted when decoding. Match
+// values carry an explicit type tag, so that they decode back to the
+// same Go type.
+
+// IDFormat selects how MarshalJSON writes IDs.
+type IDFormat int
+
+const (
+	// IDNames writes IDs as their well-known names when they have
+	// one, and as GUID strings otherwise, like json.Marshal.
+	IDNames IDFormat = iota
+	// IDGUIDs writes all IDs as GUID strings.
+	IDGUIDs
+)
+
+// MarshalJSON returns the JSON encoding of v, like json.Marshal, but
+// writes IDs in the format ids. json.Unmarshal decodes both formats
+// to the same values.
+//
+// With IDGUIDs, maps are encoded by json.Marshal, so IDs used as map
+// keys are still written as names.
+func MarshalJSON(v interface{}, ids IDFormat) ([]byte, error) {
+	if ids == IDNames {
+		return json.Marshal(v)
+	}
+	return marshalGUIDs(reflect.ValueOf(v))
+}
+
+// idTypes are the types that hold a GUID and marshal as a name.
+var idTypes = map[reflect.Type]bool{
+	reflect.TypeOf(LayerID{}):           true,
+	reflect.TypeOf(FieldID{}):           true,
+	reflect.TypeOf(SublayerID{}):        true,
+	reflect.TypeOf(ProviderID{}):        true,
+	reflect.TypeOf(RuleID{}):            true,
+	reflect.TypeOf(ProviderContextID{}): true,
+	reflect.TypeOf(CalloutID{}):         true,
+}
+
+var (
+	typeGUID  = reflect.TypeOf(GUID{})
+	typeMatch = reflect.TypeOf(Match{})
+	typeField = reflect.TypeOf(Field{})
+
+	typeJSONMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	typeTextMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// marshalID returns the JSON encoding of id, which is a GUID or one of
+// the idTypes, in the format ids.
+func marshalID(id reflect.Value, ids IDFormat) ([]byte, error) {
+	if ids == IDGUIDs {
+		return json.Marshal(id.Convert(typeGUID).Interface().(GUID).String())
+	}
+	return json.Marshal(id.Interface())
+}
+
+// marshalGUIDs is MarshalJSON for IDGUIDs. It walks v the way
+// json.Marshal does, down to values that can't contain IDs, which it
+// leaves to json.Marshal.
+func marshalGUIDs(v reflect.Value) ([]byte, error) {
+	if !v.IsValid() {
+		return []byte("null"), nil
+	}
+	t := v.Type()
+	if k := t.Kind(); k == reflect.Ptr || k == reflect.Interface {
+		if v.IsNil() {
+			return []byte("null"), nil
+		}
+		return marshalGUIDs(v.Elem())
+	}
+	switch {
+	case idTypes[t]:
+		return marshalID(v, IDGUIDs)
+	case t == typeMatch:
+		return v.Interface().(Match).marshalJSON(IDGUIDs)
+	case t == typeField:
+		return v.Interface().(Field).marshalJSON(IDGUIDs)
+	case t.Implements(typeJSONMarshaler) || t.Implements(typeTextMarshaler):
+		return json.Marshal(v.Interface())
+	}
+
+	var b bytes.Buffer
+	switch t.Kind() {
+	case reflect.Struct:
+		b.WriteByte('{')
+		n := 0
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			if f.Anonymous {
+				// Embedded fields are promoted by json.Marshal,
+				// which isn't worth replicating for types that
+				// don't have any.
+				return json.Marshal(v.Interface())
+			}
+			key, err := json.Marshal(f.Name)
+			if err != nil {
+				return nil, err
+			}
+			val, err := marshalGUIDs(v.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			if n > 0 {
+				b.WriteByte(',')
+			}
+			b.Write(key)
+			b.WriteByte(':')
+			b.Write(val)
+			n++
+		}
+		b.WriteByte('}')
+		return b.Bytes(), nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return []byte("null"), nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			return json.Marshal(v.Interface())
+		}
+		fallthrough
+	case reflect.Array:
+		b.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			val, err := marshalGUIDs(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.Write(val)
+		}
+		b.WriteByte(']')
+		return b.Bytes(), nil
+	}
+	return json.Marshal(v.Interface())
+}
+
+// guidsByName is the reverse of guidNames.
+var guidsByName = func() map[string]GUID {
+	ret := make(map[string]GUID, len(guidNames))
+	for guid, name := range guidNames {
+		ret[name] = guid
+	}
+	return ret
+}()
+
+// parseGUID parses a GUID in its canonical string form, with or
+// without the surrounding braces.
+func parseGUID(s string) (GUID, error) {
+	var ret GUID
+	str := strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+	if len(str) != 36 || str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+		return ret, fmt.Errorf("invalid GUID %q", s)
+	}
+	bs, err := hex.DecodeString(str[0:8] + str[9:13] + str[14:18] + str[19:23] + str[24:36])
+	if err != nil {
+		return ret, fmt.Errorf("invalid GUID %q", s)
+	}
+	ret.Data1 = uint32(bs[0])<<24 | uint32(bs[1])<<16 | uint32(bs[2])<<8 | uint32(bs[3])
+	ret.Data2 = uint16(bs[4])<<8 | uint16(bs[5])
+	ret.Data3 = uint16(bs[6])<<8 | uint16(bs[7])
+	copy(ret.Data4[:], bs[8:])
+	return ret, nil
+}
+
+// parseNamedGUID parses s as either a well-known name from
+// guidNames, the FWPM_* constant name of the same GUID, or a GUID
+// string.
+func parseNamedGUID(s string) (GUID, error) {
+	if guid, ok := guidsByName[s]; ok {
+		return guid, nil
+	}
+	if guid, ok := guidsBySDKName[s]; ok {
+		return guid, nil
+	}
+	return parseGUID(s)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (g GUID) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (g *GUID) UnmarshalText(b []byte) error {
+	guid, err := parseGUID(string(b))
+	if err != nil {
+		return err
+	}
+	*g = guid
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id LayerID) MarshalText() ([]byte, error) { return []byte(id.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *LayerID) UnmarshalText(b []byte) error {
+	return unmarshalID((*GUID)(id), b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id FieldID) MarshalText() ([]byte, error) { return []byte(id.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *FieldID) UnmarshalText(b []byte) error {
+	return unmarshalID((*GUID)(id), b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id SublayerID) MarshalText() ([]byte, error) { return []byte(id.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *SublayerID) UnmarshalText(b []byte) error {
+	return unmarshalID((*GUID)(id), b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id ProviderID) MarshalText() ([]byte, error) { return []byte(id.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *ProviderID) UnmarshalText(b []byte) error {
+	return unmarshalID((*GUID)(id), b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id RuleID) MarshalText() ([]byte, error) { return []byte(id.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *RuleID) UnmarshalText(b []byte) error {
+	return unmarshalID((*GUID)(id), b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id ProviderContextID) MarshalText() ([]byte, error) { return []byte(id.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *ProviderContextID) UnmarshalText(b []byte) error {
+	return unmarshalID((*GUID)(id), b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id CalloutID) MarshalText() ([]byte, error) { return []byte(id.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *CalloutID) UnmarshalText(b []byte) error {
+	return unmarshalID((*GUID)(id), b)
+}
+
+func unmarshalID(g *GUID, b []byte) error {
+	guid, err := parseNamedGUID(string(b))
+	if err != nil {
+		return err
+	}
+	*g = guid
+	return nil
+}
+
+// knownActions are the actions that have a name in Action.String.
+var knownActions = []Action{
+	ActionBlock,
+	ActionPermit,
+	ActionCalloutTerminating,
+	ActionCalloutInspection,
+	ActionCalloutUnknown,
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (a Action) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (a *Action) UnmarshalText(b []byte) error {
+	s := string(b)
+	for _, known := range knownActions {
+		if known.String() == s {
+			*a = known
+			return nil
+		}
+	}
+	n, err := parseUnknownEnum(s, "Action")
+	if err != nil {
+		return err
+	}
+	*a = Action(n)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m MatchType) MarshalText() ([]byte, error) {
+	if s, ok := mtStr[m]; ok {
+		return []byte(s), nil
+	}
+	return []byte(fmt.Sprintf("MatchType(%d)", uint32(m))), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *MatchType) UnmarshalText(b []byte) error {
+	s := string(b)
+	for mt, str := range mtStr {
+		if str == s {
+			*m = mt
+			return nil
+		}
+	}
+	n, err := parseUnknownEnum(s, "MatchType")
+	if err != nil {
+		return err
+	}
+	*m = MatchType(n)
+	return nil
+}
+
+// parseUnknownEnum parses the "Type(123)" form that String methods
+// produce for values of type typ that have no name.
+func parseUnknownEnum(s, typ string) (uint32, error) {
+	if !strings.HasPrefix(s, typ+"(") || !strings.HasSuffix(s, ")") {
+		return 0, fmt.Errorf("unknown %s %q", typ, s)
+	}
+	n, err := strconv.ParseUint(s[len(typ)+1:len(s)-1], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unknown %s %q", typ, s)
+	}
+	return uint32(n), nil
+}
+
+// jsonMatch is the JSON form of a Match.
+type jsonMatch struct {
+	Field json.RawMessage `json:"field"`
+	Op    MatchType       `json:"op"`
+	Type  string          `json:"type,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// jsonValue is the JSON form of a typed value, used for the bounds
+// of a Range.
+type jsonValue struct {
+	Type  string          `json:"type,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// jsonRange is the JSON form of a Range.
+type jsonRange struct {
+	From jsonValue `json:"from"`
+	To   jsonValue `json:"to"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m Match) MarshalJSON() ([]byte, error) {
+	return m.marshalJSON(IDNames)
+}
+
+func (m Match) marshalJSON(ids IDFormat) ([]byte, error) {
+	field, err := marshalID(reflect.ValueOf(m.Field), ids)
+	if err != nil {
+		return nil, err
+	}
+	typ, val, err := encodeValue(m.Value)
+	if err != nil {
+		return nil, fmt.Errorf("encoding value of %s: %w", m.Field, err)
+	}
+	return json.Marshal(jsonMatch{
+		Field: field,
+		Op:    m.Op,
+		Type:  typ,
+		Value: val,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *Match) UnmarshalJSON(b []byte) error {
+	var jm jsonMatch
+	if err := json.Unmarshal(b, &jm); err != nil {
+		return err
+	}
+	var field FieldID
+	if err := json.Unmarshal(jm.Field, &field); err != nil {
+		return err
+	}
+	v, err := decodeValue(jm.Type, jm.Value)
+	if err != nil {
+		return fmt.Errorf("decoding value of %s: %w", field, err)
+	}
+	*m = Match{
+		Field: field,
+		Op:    jm.Op,
+		Value: v,
+	}
+	return nil
+}
+
+// fieldTypeNames are the names of field types in the JSON form of a
+// Field. They match the type tags of values where possible.
+var fieldTypeNames = map[reflect.Type]string{
+	typeUint8:              "uint8",
+	typeUint16:             "uint16",
+	typeUint32:             "uint32",
+	typeUint64:             "uint64",
+	typeArray16:            "array16",
+	typeBytes:              "bytes",
+	typeString:             "string",
+	typeMAC:                "mac",
+	typeIP:                 "addr",
+	typePrefix:             "prefix",
+	typeRange:              "range",
+	typeSID:                "sid",
+	typeSecurityDescriptor: "sd",
+	typeTokenInformation:   "tokeninformation",
+}
+
+// jsonField is the JSON form of a Field.
+type jsonField struct {
+	ID   json.RawMessage `json:"id"`
+	Type string          `json:"type"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (f Field) MarshalJSON() ([]byte, error) {
+	return f.marshalJSON(IDNames)
+}
+
+func (f Field) marshalJSON(ids IDFormat) ([]byte, error) {
+	name, ok := fieldTypeNames[f.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %v of field %s", f.Type, f.ID)
+	}
+	id, err := marshalID(reflect.ValueOf(f.ID), ids)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonField{id, name})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *Field) UnmarshalJSON(b []byte) error {
+	var jf jsonField
+	if err := json.Unmarshal(b, &jf); err != nil {
+		return err
+	}
+	var id FieldID
+	if err := json.Unmarshal(jf.ID, &id); err != nil {
+		return err
+	}
+	for typ, name := range fieldTypeNames {
+		if name == jf.Type {
+			*f = Field{id, typ}
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown type %q of field %s", jf.Type, id)
+}
+
+// encodeValue returns the type tag and JSON encoding of the match
+// value v.
+func encodeValue(v interface{}) (string, json.RawMessage, error) {
+	var (
+		typ string
+		val interface{}
+	)
+	switch u := v.(type) {
+	case nil:
+		return "", nil, nil
+	case uint8:
+		typ, val = "uint8", u
+	case uint16:
+		typ, val = "uint16", u
+	case uint32:
+		typ, val = "uint32", u
+	case uint64:
+		typ, val = "uint64", u
+	case IPProto:
+		typ, val = "ipproto", uint8(u)
+	case ConditionFlag:
+		typ, val = "conditionflag", uint32(u)
+	case string:
+		typ, val = "string", u
+	case []byte:
+		typ, val = "bytes", u
+	case [16]byte:
+		typ, val = "array16", hex.EncodeToString(u[:])
+	case net.HardwareAddr:
+		typ, val = "mac", u.String()
+	case netip.Addr:
+		typ, val = "addr", u
+	case netip.Prefix:
+		typ, val = "prefix", u
+	case netipx.IPRange:
+		typ, val = "iprange", u
+	case Range:
+		var r jsonRange
+		var err error
+		if r.From.Type, r.From.Value, err = encodeValue(u.From); err != nil {
+			return "", nil, err
+		}
+		if r.To.Type, r.To.Value, err = encodeValue(u.To); err != nil {
+			return "", nil, err
+		}
+		typ, val = "range", r
+	default:
+		if s, ok := sidString(v); ok {
+			typ, val = "sid", s
+		} else if s, ok := sdString(v); ok {
+			typ, val = "sd", s
+		} else {
+			return "", nil, fmt.Errorf("cannot encode Go type %T", v)
+		}
+	}
+	bs, err := json.Marshal(val)
+	if err != nil {
+		return "", nil, err
+	}
+	return typ, bs, nil
+}
+
+// decodeValue decodes the JSON encoding of a match value whose type
+// tag is typ.
+func decodeValue(typ string, b json.RawMessage) (interface{}, error) {
+	if typ == "" {
+		if len(b) != 0 && string(b) != "null" {
+			return nil, errors.New("value has no type")
+		}
+		return nil, nil
+	}
+
+	var err error
+	unmarshal := func(v interface{}) {
+		err = json.Unmarshal(b, v)
+	}
+	var ret interface{}
+	switch typ {
+	case "uint8":
+		var u uint8
+		unmarshal(&u)
+		ret = u
+	case "uint16":
+		var u uint16
+		unmarshal(&u)
+		ret = u
+	case "uint32":
+		var u uint32
+		unmarshal(&u)
+		ret = u
+	case "uint64":
+		var u uint64
+		unmarshal(&u)
+		ret = u
+	case "ipproto":
+		var u uint8
+		unmarshal(&u)
+		ret = IPProto(u)
+	case "conditionflag":
+		var u uint32
+		unmarshal(&u)
+		ret = ConditionFlag(u)
+	case "string":
+		var s string
+		unmarshal(&s)
+		ret = s
+	case "bytes":
+		var bs []byte
+		unmarshal(&bs)
+		ret = bs
+	case "array16":
+		var s string
+		unmarshal(&s)
+		if err != nil {
+			break
+		}
+		var bs []byte
+		bs, err = hex.DecodeString(s)
+		if err == nil && len(bs) != 16 {
+			err = fmt.Errorf("array16 value %q is not 16 bytes long", s)
+		}
+		var a [16]byte
+		copy(a[:], bs)
+		ret = a
+	case "mac":
+		var s string
+		unmarshal(&s)
+		if err != nil {
+			break
+		}
+		ret, err = net.ParseMAC(s)
+	case "addr":
+		var a netip.Addr
+		unmarshal(&a)
+		ret = a
+	case "prefix":
+		var p netip.Prefix
+		unmarshal(&p)
+		ret = p
+	case "iprange":
+		var r netipx.IPRange
+		unmarshal(&r)
+		ret = r
+	case "range":
+		var r jsonRange
+		unmarshal(&r)
+		if err != nil {
+			break
+		}
+		var from, to interface{}
+		if from, err = decodeValue(r.From.Type, r.From.Value); err != nil {
+			break
+		}
+		if to, err = decodeValue(r.To.Type, r.To.Value); err != nil {
+			break
+		}
+		ret = Range{from, to}
+	case "sid":
+		var s string
+		unmarshal(&s)
+		if err != nil {
+			break
+		}
+		ret, err = sidFromString(s)
+	case "sd":
+		var s string
+		unmarshal(&s)
+		if err != nil {
+			break
+		}
+		ret, err = sdFromString(s)
+	default:
+		return nil, fmt.Errorf("unknown value type %q", typ)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"encoding/json"
+	"net"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go4.org/netipx"
+)
+
+// netipComparers lets cmp compare values from net/netip and netipx,
+// which have unexported fields.
+var netipComparers = []cmp.Option{
+	cmp.Comparer(func(a, b netip.Addr) bool { return a == b }),
+	cmp.Comparer(func(a, b netip.Prefix) bool { return a == b }),
+	cmp.Comparer(func(a, b netip.AddrPort) bool { return a == b }),
+	cmp.Comparer(func(a, b netipx.IPRange) bool { return a == b }),
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	mac, err := net.ParseMAC("01:23:45:67:89:ab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sid, err := sidFromString("S-1-5-18")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := &Rule{
+		ID:          RuleID{Data1: 0x12345678, Data2: 0x9abc, Data3: 0xdef0, Data4: [8]byte{1, 2, 3, 4, 5, 6, 7, 8}},
+		KernelID:    1 << 60,
+		Name:        "test rule",
+		Description: "a rule with every kind of value",
+		Layer:       LayerALEAuthConnectV4,
+		Sublayer:    guidSublayerUniversal,
+		Weight:      42,
+		Conditions: []*Match{
+			{FieldIPProtocol, MatchTypeEqual, uint8(6)},
+			{FieldIPProtocol, MatchTypeEqual, IPProtoUDP},
+			{FieldIPRemotePort, MatchTypeRange, Range{uint16(80), uint16(443)}},
+			{FieldFlags, MatchTypeFlagsAnySet, ConditionFlagIsLoopback},
+			{FieldFlags, MatchTypeFlagsNoneSet, uint32(3)},
+			{FieldIPLocalInterface, MatchTypeEqual, uint64(1 << 63)},
+			{FieldALEAppID, MatchTypeEqual, `\device\harddiskvolume1\app.exe`},
+			{FieldALEUserID, MatchTypeEqual, []byte{0, 1, 2}},
+			{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParseAddr("1.2.3.4")},
+			{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParsePrefix("fe80::/10")},
+			{FieldIPRemoteAddress, MatchTypeRange, netipx.IPRangeFrom(netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.9"))},
+			{FieldIPLocalAddress, MatchTypeEqual, [16]byte{0: 0xfe, 15: 1}},
+			{FieldMACLocalAddress, MatchTypeEqual, mac},
+			{FieldALERemoteUserID, MatchTypeEqual, sid},
+			{FieldDirection, MatchTypeRange, Range{Range{uint8(1), uint8(2)}, nil}},
+			{FieldIPLocalPort, MatchTypeLess, nil},
+		},
+		Action:          ActionCalloutUnknown,
+		Callout:         CalloutID{Data1: 7},
+		PermitIfMissing: true,
+		HardAction:      true,
+		Persistent:      true,
+		BootTime:        true,
+		Provider:        ProviderID{Data1: 1},
+		ProviderData:    []byte("provider data"),
+		Disabled:        true,
+	}
+	provider := &Provider{
+		ID:          ProviderID{Data1: 1},
+		Name:        "provider",
+		Description: "a provider",
+		Persistent:  true,
+		Data:        []byte{1, 2, 3},
+		ServiceName: "svc",
+		Disabled:    true,
+	}
+	sublayer := &Sublayer{
+		ID:           SublayerID{Data1: 2},
+		Name:         "sublayer",
+		Description:  "a sublayer",
+		Persistent:   true,
+		Provider:     provider.ID,
+		ProviderData: []byte{4, 5, 6},
+		Weight:       0x8000,
+	}
+
+	for _, v := range []interface{}{rule, provider, sublayer} {
+		bs, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("marshaling %T: %v", v, err)
+		}
+		got := reflect.New(reflect.TypeOf(v).Elem()).Interface()
+		if err := json.Unmarshal(bs, got); err != nil {
+			t.Fatalf("unmarshaling %T: %v\n%s", v, err, bs)
+		}
+		if diff := cmp.Diff(got, v, netipComparers...); diff != "" {
+			t.Fatalf("%T round trip is wrong (-got+want):\n%s", v, diff)
+		}
+		bs2, err := json.Marshal(got)
+		if err != nil {
+			t.Fatalf("marshaling %T: %v", v, err)
+		}
+		if string(bs) != string(bs2) {
+			t.Fatalf("%T encoding is not stable:\n%s\n%s", v, bs, bs2)
+		}
+	}
+}
+
+func TestJSONNames(t *testing.T) {
+	bs, err := json.Marshal(&Match{FieldIPRemotePort, MatchTypeEqual, uint16(443)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"field":"IP_REMOTE_PORT","op":"==","type":"uint16","value":443}`
+	if string(bs) != want {
+		t.Fatalf("got %s, want %s", bs, want)
+	}
+
+	var r Rule
+	in := `{"ID": "{12345678-9ABC-DEF0-0102-030405060708}", "Layer": "ALE_AUTH_CONNECT_V4", "Sublayer": "eebecc03-ced4-4380-819a-2734397b 2b74", "Action": "Block"}`
+	if err := json.Unmarshal([]byte(in), &r); err == nil || !strings.Contains(err.Error(), "invalid GUID") {
+		t.Fatalf("unmarshaling malformed GUID: got err %v, want invalid GUID", err)
+	}
+	in = strings.Replace(in, " 2b74", "2b74", 1)
+	if err := json.Unmarshal([]byte(in), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Layer != LayerALEAuthConnectV4 || r.Sublayer != guidSublayerUniversal || r.Action != ActionBlock {
+		t.Fatalf("unmarshaled rule is wrong: %+v", r)
+	}
+	if r.ID != (RuleID{0x12345678, 0x9abc, 0xdef0, [8]byte{1, 2, 3, 4, 5, 6, 7, 8}}) {
+		t.Fatalf("unmarshaled rule ID is wrong: %s", GUID(r.ID))
+	}
+
+	var m Match
+	if err := json.Unmarshal([]byte(`{"field":"IP_REMOTE_PORT","op":"==","type":"float","value":1.5}`), &m); err == nil {
+		t.Fatalf("unmarshaling unknown value type succeeded")
+	}
+}
+
+func TestMarshalJSONIDFormat(t *testing.T) {
+	rule := &Rule{
+		ID:       RuleID{Data1: 1},
+		Name:     "IP_REMOTE_PORT",
+		Layer:    LayerALEAuthConnectV4,
+		Sublayer: guidSublayerUniversal,
+		Conditions: []*Match{
+			{FieldIPRemotePort, MatchTypeEqual, uint16(443)},
+		},
+		Action: ActionBlock,
+	}
+	layer := DefaultSchema().Layer(LayerALEAuthConnectV4)
+
+	for _, v := range []interface{}{rule, []*Rule{rule}, layer} {
+		names, err := MarshalJSON(v, IDNames)
+		if err != nil {
+			t.Fatalf("marshaling %T with names: %v", v, err)
+		}
+		std, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("marshaling %T: %v", v, err)
+		}
+		if string(names) != string(std) {
+			t.Errorf("IDNames encoding of %T differs from json.Marshal:\n%s\n%s", v, names, std)
+		}
+
+		guids, err := MarshalJSON(v, IDGUIDs)
+		if err != nil {
+			t.Fatalf("marshaling %T with GUIDs: %v", v, err)
+		}
+		// The only difference is that well-known names, but not
+		// strings that happen to look like them, are GUIDs.
+		want := string(names)
+		for guid, name := range guidNames {
+			want = strings.ReplaceAll(want, `:"`+name+`"`, `:"`+guid.String()+`"`)
+		}
+		want = strings.Replace(want, `"Name":"`+GUID(FieldIPRemotePort).String()+`"`, `"Name":"IP_REMOTE_PORT"`, 1)
+		if string(guids) != want {
+			t.Errorf("IDGUIDs encoding of %T is wrong:\ngot  %s\nwant %s", v, guids, want)
+		}
+
+		got := reflect.New(reflect.TypeOf(v))
+		if err := json.Unmarshal(guids, got.Interface()); err != nil {
+			t.Fatalf("unmarshaling %T: %v\n%s", v, err, guids)
+		}
+		if diff := cmp.Diff(got.Elem().Interface(), v, cmp.Comparer(func(a, b *Field) bool { return *a == *b })); diff != "" {
+			t.Errorf("%T round trip with GUIDs is wrong (-got+want):\n%s", v, diff)
+		}
+	}
+}
+
+func TestLayerJSON(t *testing.T) {
+	layer := DefaultSchema().Layer(LayerALEAuthConnectV4)
+	bs, err := json.Marshal(layer)
+	if err != nil {
+		t.Fatalf("marshaling layer: %v", err)
+	}
+	var got Layer
+	if err := json.Unmarshal(bs, &got); err != nil {
+		t.Fatalf("unmarshaling layer: %v\n%s", err, bs)
+	}
+	if diff := cmp.Diff(&got, layer, cmp.Comparer(func(a, b *Field) bool { return *a == *b })); diff != "" {
+		t.Fatalf("layer round trip is wrong (-got+want):\n%s", diff)
+	}
+	if !strings.Contains(string(bs), `{"id":"IP_REMOTE_PORT","type":"uint16"}`) {
+		t.Fatalf("layer JSON doesn't describe IP_REMOTE_PORT:\n%s", bs)
+	}
+}
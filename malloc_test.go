@@ -3,6 +3,9 @@
 // This file is part of Huntress. Unauthorized copying of this file, via any medium is
 // strictly prohibited without the express written consent of Huntress Labs, Inc.
 
+//go:build windows
+// +build windows
+
 package wf
 
 import (
@@ -0,0 +1,344 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go4.org/netipx"
+)
+
+// matchValueTypes are the names of the Go types that Match.String
+// appends to its output in parentheses.
+var matchValueTypes = func() map[string]bool {
+	ret := map[string]bool{}
+	for _, typ := range []reflect.Type{
+		typeUint8, typeUint16, typeUint32, typeUint64,
+		typeArray16, typeBytes, typeString, typeMAC,
+		typeIP, typePrefix, typeRange, typeSID, typeSecurityDescriptor,
+		reflect.TypeOf(IPProto(0)),
+		reflect.TypeOf(ConditionFlag(0)),
+		reflect.TypeOf(netipx.IPRange{}),
+	} {
+		ret[typ.String()] = true
+	}
+	return ret
+}()
+
+// ParseMatch parses text into a Match on a field of layer, using the
+// default schema to find the field's type. See Schema.ParseMatch for
+// the syntax.
+func ParseMatch(layer LayerID, text string) (*Match, error) {
+	return defaultSchema.ParseMatch(layer, text)
+}
+
+// ParseMatch parses text into a Match on a field of layer, using s to
+// find the field's type.
+//
+// text has the form "FIELD op value", where FIELD is a field name
+// such as "IP_REMOTE_PORT" or a GUID string, and op is one of the
+// MatchType spellings ==, !=, <, <=, >, >=, in, F[all], F[any],
+// F[none], i==, pfx and !pfx. The value is parsed according to the
+// field's type, and produces the Go type that the filtering engine
+// expects for that field:
+//
+//   - numbers, in decimal or with a 0x prefix. IP_PROTOCOL also
+//     accepts protocol names such as "tcp", and FLAGS accepts
+//     ConditionFlag names such as "IsLoopback", joined with "|".
+//   - IP addresses, CIDR prefixes, and address ranges such as
+//     "10.0.0.1-10.0.0.9".
+//   - MAC addresses, SIDs, and security descriptors in SDDL form.
+//   - strings such as app paths, optionally in double quotes.
+//   - byte arrays as hex digits, or as a list such as "[1 2 3]".
+//
+// The "in" operator takes a range "from-to" or "{from to}", or a
+// CIDR prefix for address fields. A trailing Go type in
+// parentheses, as printed by Match.String, is ignored, so
+// ParseMatch accepts the output of Match.String for most values.
+func (s *Schema) ParseMatch(layer LayerID, text string) (*Match, error) {
+	return parseMatch(text, func(field FieldID) (reflect.Type, error) {
+		if s.Layer(layer) == nil {
+			return nil, fmt.Errorf("unknown layer %s", layer)
+		}
+		ftype, ok := s.FieldType(layer, field)
+		if !ok {
+			return nil, fmt.Errorf("layer %s has no field %s", layer, field)
+		}
+		return ftype, nil
+	})
+}
+
+// parseMatch parses text into a Match, using fieldType to find the
+// type of its field. See Schema.ParseMatch for the syntax.
+func parseMatch(text string, fieldType func(FieldID) (reflect.Type, error)) (*Match, error) {
+	text = strings.TrimSpace(text)
+	f := strings.Fields(text)
+	if len(f) < 3 {
+		return nil, fmt.Errorf("match %q is not of the form \"FIELD op value\"", text)
+	}
+	fieldName, opName := f[0], f[1]
+	// Slice the value out of text rather than joining f, to keep the
+	// spacing inside values such as paths.
+	value := strings.TrimSpace(text[len(fieldName):])
+	value = strings.TrimSpace(value[len(opName):])
+	value = trimTypeSuffix(value)
+
+	guid, err := parseNamedGUID(fieldName)
+	if err != nil {
+		return nil, fmt.Errorf("match %q: unknown field %q", text, fieldName)
+	}
+	field := FieldID(guid)
+	ftype, err := fieldType(field)
+	if err != nil {
+		return nil, fmt.Errorf("match %q: %w", text, err)
+	}
+
+	var op MatchType
+	if err := op.UnmarshalText([]byte(opName)); err != nil {
+		return nil, fmt.Errorf("match %q: %w", text, err)
+	}
+
+	v, err := parseMatchValue(field, ftype, op, value)
+	if err != nil {
+		return nil, fmt.Errorf("match %q: %w", text, err)
+	}
+	return &Match{
+		Field: field,
+		Op:    op,
+		Value: v,
+	}, nil
+}
+
+// trimTypeSuffix removes a trailing " (T)" from s, if T is the name
+// of a Go type that Match values can have.
+func trimTypeSuffix(s string) string {
+	i := strings.LastIndex(s, " (")
+	if i < 0 || !strings.HasSuffix(s, ")") {
+		return s
+	}
+	if !matchValueTypes[s[i+2:len(s)-1]] {
+		return s
+	}
+	return strings.TrimSpace(s[:i])
+}
+
+// parseMatchValue parses s as a value of field, whose type is ftype,
+// for use with op.
+func parseMatchValue(field FieldID, ftype reflect.Type, op MatchType, s string) (interface{}, error) {
+	if ftype == typeIP {
+		return parseIPMatchValue(op, s)
+	}
+	if op != MatchTypeRange {
+		return parseScalarValue(field, ftype, s)
+	}
+
+	var from, to string
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		f := strings.Fields(s[1 : len(s)-1])
+		if len(f) != 2 {
+			return nil, fmt.Errorf("range %q is not of the form \"{from to}\"", s)
+		}
+		from, to = f[0], f[1]
+	} else {
+		var ok bool
+		from, to, ok = strings.Cut(s, "-")
+		if !ok {
+			return nil, fmt.Errorf("range %q is not of the form \"from-to\"", s)
+		}
+	}
+	fv, err := parseScalarValue(field, ftype, strings.TrimSpace(from))
+	if err != nil {
+		return nil, err
+	}
+	tv, err := parseScalarValue(field, ftype, strings.TrimSpace(to))
+	if err != nil {
+		return nil, err
+	}
+	return Range{From: fv, To: tv}, nil
+}
+
+// parseIPMatchValue parses s as an address, a CIDR prefix or an
+// address range. With the "in" operator, a CIDR prefix is converted
+// to the range of addresses it covers.
+func parseIPMatchValue(op MatchType, s string) (interface{}, error) {
+	if op == MatchTypeRange {
+		if strings.Contains(s, "/") {
+			p, err := netip.ParsePrefix(s)
+			if err != nil {
+				return nil, err
+			}
+			return netipx.RangeOfPrefix(p.Masked()), nil
+		}
+		return netipx.ParseIPRange(s)
+	}
+	if strings.Contains(s, "/") {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, err
+		}
+		return p.Masked(), nil
+	}
+	return netip.ParseAddr(s)
+}
+
+// parseScalarValue parses s as a single value of field, whose type
+// is ftype.
+func parseScalarValue(field FieldID, ftype reflect.Type, s string) (interface{}, error) {
+	switch ftype {
+	case typeUint8:
+		if field == FieldIPProtocol {
+			return parseIPProto(s)
+		}
+		n, err := strconv.ParseUint(s, 0, 8)
+		if err != nil {
+			return nil, err
+		}
+		return uint8(n), nil
+	case typeUint16:
+		n, err := strconv.ParseUint(s, 0, 16)
+		if err != nil {
+			return nil, err
+		}
+		return uint16(n), nil
+	case typeUint32:
+		if field == FieldFlags {
+			return parseConditionFlag(s)
+		}
+		n, err := strconv.ParseUint(s, 0, 32)
+		if err != nil {
+			return nil, err
+		}
+		return uint32(n), nil
+	case typeUint64:
+		return strconv.ParseUint(s, 0, 64)
+	case typeString:
+		return unquote(s)
+	case typeBytes:
+		return parseBytes(s)
+	case typeArray16:
+		bs, err := parseBytes(s)
+		if err != nil {
+			return nil, err
+		}
+		var ret [16]byte
+		if len(bs) != len(ret) {
+			return nil, fmt.Errorf("%q is %d bytes long, want 16", s, len(bs))
+		}
+		copy(ret[:], bs)
+		return ret, nil
+	case typeMAC:
+		mac, err := net.ParseMAC(s)
+		if err != nil {
+			return nil, err
+		}
+		if len(mac) != 6 {
+			return nil, fmt.Errorf("MAC address %q is not 6 bytes long", s)
+		}
+		return mac, nil
+	case typeSID:
+		return sidFromString(s)
+	case typeSecurityDescriptor:
+		return sdFromString(s)
+	}
+	return nil, fmt.Errorf("parsing values of field type %s is not supported", ftype)
+}
+
+// knownIPProtos are the protocols that have a name in IPProto.String.
+var knownIPProtos = []IPProto{
+	IPProtoICMP,
+	IPProtoICMPV6,
+	IPProtoTCP,
+	IPProtoUDP,
+}
+
+// parseIPProto parses s as a protocol name, a number, or the
+// "IPProto(N)" form of IPProto.String.
+func parseIPProto(s string) (IPProto, error) {
+	for _, p := range knownIPProtos {
+		if strings.EqualFold(p.String(), s) {
+			return p, nil
+		}
+	}
+	if n, err := parseUnknownEnum(s, "IPProto"); err == nil {
+		return IPProto(n), nil
+	}
+	n, err := strconv.ParseUint(s, 0, 8)
+	if err != nil {
+		return 0, fmt.Errorf("unknown IP protocol %q", s)
+	}
+	return IPProto(n), nil
+}
+
+// parseConditionFlag parses s as a "|"-separated list of
+// ConditionFlag names, with or without their "ConditionFlag" prefix,
+// or numbers.
+func parseConditionFlag(s string) (ConditionFlag, error) {
+	var ret ConditionFlag
+	for _, f := range strings.Split(s, "|") {
+		f = strings.TrimPrefix(strings.TrimSpace(f), "ConditionFlag")
+		flag, err := parseOneConditionFlag(f)
+		if err != nil {
+			return 0, err
+		}
+		ret |= flag
+	}
+	return ret, nil
+}
+
+func parseOneConditionFlag(s string) (ConditionFlag, error) {
+	for i := 0; i < 32; i++ {
+		flag := ConditionFlag(1 << i)
+		if strings.EqualFold(flag.String(), s) {
+			return flag, nil
+		}
+	}
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		// The "ConditionFlag(N)" form, minus the already-trimmed
+		// prefix.
+		s = s[1 : len(s)-1]
+	}
+	n, err := strconv.ParseUint(s, 0, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unknown condition flag %q", s)
+	}
+	return ConditionFlag(n), nil
+}
+
+// unquote returns s without surrounding double quotes, if it has
+// them.
+func unquote(s string) (string, error) {
+	if len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) {
+		return strconv.Unquote(s)
+	}
+	return s, nil
+}
+
+// parseBytes parses s as hex digits with an optional 0x prefix, or
+// as the "[1 2 3]" form that fmt prints for byte slices.
+func parseBytes(s string) ([]byte, error) {
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		ret := []byte{}
+		for _, f := range strings.Fields(s[1 : len(s)-1]) {
+			n, err := strconv.ParseUint(f, 0, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid byte %q in %q", f, s)
+			}
+			ret = append(ret, byte(n))
+		}
+		return ret, nil
+	}
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	ret, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex bytes %q", s)
+	}
+	return ret, nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go4.org/netipx"
+)
+
+func TestParseMatch(t *testing.T) {
+	mac, err := net.ParseMAC("01:23:45:67:89:ab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var appID [16]byte
+	for i := range appID {
+		appID[i] = byte(i)
+	}
+
+	tests := []struct {
+		layer LayerID
+		in    string
+		want  *Match
+	}{
+		{LayerALEAuthConnectV4, "IP_REMOTE_PORT == 53", &Match{FieldIPRemotePort, MatchTypeEqual, uint16(53)}},
+		{LayerALEAuthConnectV4, "IP_REMOTE_PORT in 1024-65535", &Match{FieldIPRemotePort, MatchTypeRange, Range{uint16(1024), uint16(65535)}}},
+		{LayerALEAuthConnectV4, "IP_REMOTE_PORT in {1 2}", &Match{FieldIPRemotePort, MatchTypeRange, Range{uint16(1), uint16(2)}}},
+		{LayerALEAuthConnectV4, "IP_PROTOCOL == tcp", &Match{FieldIPProtocol, MatchTypeEqual, IPProtoTCP}},
+		{LayerALEAuthConnectV4, "IP_PROTOCOL == 47", &Match{FieldIPProtocol, MatchTypeEqual, IPProto(47)}},
+		{LayerALEAuthConnectV4, "IP_PROTOCOL != UDP (wf.IPProto)", &Match{FieldIPProtocol, MatchTypeNotEqual, IPProtoUDP}},
+		{LayerALEAuthConnectV4, "FLAGS F[any] IsLoopback|IsReauthorize", &Match{FieldFlags, MatchTypeFlagsAnySet, ConditionFlagIsLoopback | ConditionFlagIsReauthorize}},
+		{LayerALEAuthConnectV4, "FLAGS F[none] ConditionFlagIsLoopback", &Match{FieldFlags, MatchTypeFlagsNoneSet, ConditionFlagIsLoopback}},
+		{LayerALEAuthConnectV4, "FLAGS F[all] ConditionFlag(3) (wf.ConditionFlag)", &Match{FieldFlags, MatchTypeFlagsAllSet, ConditionFlag(3)}},
+		{LayerALEAuthConnectV4, "IP_LOCAL_INTERFACE == 0x10", &Match{FieldIPLocalInterface, MatchTypeEqual, uint64(16)}},
+		{LayerALEAuthConnectV4, "IP_REMOTE_ADDRESS == 10.0.0.1", &Match{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParseAddr("10.0.0.1")}},
+		{LayerALEAuthConnectV4, "IP_REMOTE_ADDRESS == 10.1.2.3/8", &Match{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParsePrefix("10.0.0.0/8")}},
+		{LayerALEAuthConnectV4, "IP_REMOTE_ADDRESS in 10.0.0.0/8", &Match{FieldIPRemoteAddress, MatchTypeRange, netipx.MustParseIPRange("10.0.0.0-10.255.255.255")}},
+		{LayerALEAuthConnectV4, "IP_REMOTE_ADDRESS in 10.0.0.1-10.0.0.9 (netipx.IPRange)", &Match{FieldIPRemoteAddress, MatchTypeRange, netipx.MustParseIPRange("10.0.0.1-10.0.0.9")}},
+		{LayerALEAuthConnectV6, "IP_REMOTE_ADDRESS == ::1", &Match{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParseAddr("::1")}},
+		{LayerALEAuthConnectV4, `ALE_APP_ID == C:\Program Files (x86)\app.exe`, &Match{FieldALEAppID, MatchTypeEqual, `C:\Program Files (x86)\app.exe`}},
+		{LayerALEAuthConnectV4, `ALE_APP_ID  ==   "C:\\app.exe"  (string)`, &Match{FieldALEAppID, MatchTypeEqual, `C:\app.exe`}},
+		{LayerALEAuthConnectV4, "ALE_EFFECTIVE_NAME pfx 0xdeadbeef", &Match{FieldALEEffectiveName, MatchTypePrefix, []byte{0xde, 0xad, 0xbe, 0xef}}},
+		{LayerALEAuthConnectV4, "ALE_EFFECTIVE_NAME == [1 2 3] ([]uint8)", &Match{FieldALEEffectiveName, MatchTypeEqual, []byte{1, 2, 3}}},
+		{LayerRPCUM, "DCOM_APP_ID == 000102030405060708090a0b0c0d0e0f", &Match{FieldDCOMAppID, MatchTypeEqual, appID}},
+		{LayerIngressVSwitchEthernet, "MAC_DESTINATION_ADDRESS == 01:23:45:67:89:ab", &Match{FieldMACDestinationAddress, MatchTypeEqual, mac}},
+		{LayerALEAuthConnectV4, "{c35a604d-d22b-4e1a-91b4-68f674ee674b} == 53", &Match{FieldIPRemotePort, MatchTypeEqual, uint16(53)}},
+	}
+
+	for _, test := range tests {
+		got, err := ParseMatch(test.layer, test.in)
+		if err != nil {
+			t.Errorf("ParseMatch(%s, %q): %v", test.layer, test.in, err)
+			continue
+		}
+		if diff := cmp.Diff(got, test.want, netipComparers...); diff != "" {
+			t.Errorf("ParseMatch(%s, %q) is wrong (-got+want):\n%s", test.layer, test.in, diff)
+		}
+		if got.Op != MatchTypeRange {
+			if err := Validate(&Rule{ID: RuleID{Data1: 1}, Layer: test.layer, Sublayer: SublayerID{Data1: 1}, Action: ActionBlock, Conditions: []*Match{got}}, nil); err != nil {
+				t.Errorf("ParseMatch(%s, %q) returned an invalid match: %v", test.layer, test.in, err)
+			}
+		}
+	}
+}
+
+func TestParseMatchString(t *testing.T) {
+	sid, err := sidFromString("S-1-5-18")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms := []*Match{
+		{FieldIPRemotePort, MatchTypeEqual, uint16(53)},
+		{FieldIPProtocol, MatchTypeEqual, IPProtoICMP},
+		{FieldIPRemoteAddress, MatchTypePrefix, netip.MustParsePrefix("192.168.0.0/16")},
+		{FieldIPRemoteAddress, MatchTypeRange, netipx.MustParseIPRange("10.0.0.1-10.0.0.9")},
+		{FieldIPLocalPort, MatchTypeRange, Range{uint16(1), uint16(1023)}},
+		{FieldALEAppID, MatchTypeEqual, `C:\Windows\System32\svchost.exe`},
+		{FieldFlags, MatchTypeFlagsAllSet, ConditionFlagIsLoopback},
+		{FieldALEPackageID, MatchTypeEqual, sid},
+	}
+	for _, m := range ms {
+		got, err := ParseMatch(LayerALEAuthConnectV4, m.String())
+		if err != nil {
+			t.Errorf("ParseMatch(%q): %v", m, err)
+			continue
+		}
+		if got.String() != m.String() {
+			t.Errorf("ParseMatch(%q) = %q", m, got)
+		}
+	}
+}
+
+func TestParseMatchErrors(t *testing.T) {
+	tests := []struct {
+		layer LayerID
+		in    string
+	}{
+		{LayerALEAuthConnectV4, "IP_REMOTE_PORT =="},
+		{LayerALEAuthConnectV4, "NO_SUCH_FIELD == 1"},
+		{LayerALEAuthConnectV4, "MAC_DESTINATION_ADDRESS == 01:23:45:67:89:ab"},
+		{LayerALEAuthConnectV4, "IP_REMOTE_PORT ~= 53"},
+		{LayerALEAuthConnectV4, "IP_REMOTE_PORT == 65536"},
+		{LayerALEAuthConnectV4, "IP_REMOTE_PORT in 53"},
+		{LayerALEAuthConnectV4, "IP_PROTOCOL == sctpx"},
+		{LayerALEAuthConnectV4, "FLAGS F[any] IsLoopback|NoSuchFlag"},
+		{LayerALEAuthConnectV4, "IP_REMOTE_ADDRESS == 10.0.0.256"},
+		{LayerALEAuthConnectV4, "ALE_EFFECTIVE_NAME == xyz"},
+		{LayerRPCUM, "DCOM_APP_ID == 0001"},
+		{LayerID{Data1: 1}, "IP_REMOTE_PORT == 53"},
+	}
+	for _, test := range tests {
+		if m, err := ParseMatch(test.layer, test.in); err == nil {
+			t.Errorf("ParseMatch(%s, %q) = %v, want error", test.layer, test.in, m)
+		}
+	}
+}
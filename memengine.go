@@ -0,0 +1,1296 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"go4.org/netipx"
+)
+
+// defaultTransactionStartTimeout is how long WFP waits for the global
+// transaction lock when Options.TransactionStartTimeout is zero.
+const defaultTransactionStartTimeout = 15 * time.Second
+
+// MemoryEngine is a Backend that implements a filtering engine
+// entirely in memory. It enforces the same rules as WFP for object
+// ownership, duplicate keys and transactions, and returns the same
+// errors, so that code built on Session can be tested without a live
+// filtering engine.
+//
+// Sessions opened on the same MemoryEngine share its state, like
+// sessions opened on the same Windows machine. Only one session at a
+// time can hold a read-write transaction; others wait for up to
+// their Options.TransactionStartTimeout, then fail with Timeout.
+// Changes made inside a transaction are invisible to other sessions
+// until the transaction commits.
+type MemoryEngine struct {
+	// txnLock is the global write transaction lock. It is held by
+	// sending to it, and released by receiving from it.
+	txnLock chan struct{}
+
+	mu           sync.Mutex
+	layers       []*Layer
+	state        *memState
+	events       []*NetEvent
+	nextKernelID uint64
+	// nextCalloutID and nextContextID are the next callout and
+	// provider context kernel IDs, which WFP allocates separately
+	// from rule IDs.
+	nextCalloutID uint32
+	nextContextID uint64
+	// subs are the net event subscriptions of all sessions.
+	subs map[*memNetEventSub]bool
+	// ruleSubs are the rule change subscriptions of all sessions.
+	ruleSubs map[*memRuleSub]bool
+}
+
+// memNetEventSub is a net event subscription of a memSession.
+type memNetEventSub struct {
+	session *memSession
+	tpl     *NetEventEnumTemplate
+	deliver func(*NetEvent)
+}
+
+// memRuleSub is a rule change subscription of a memSession.
+type memRuleSub struct {
+	session *memSession
+	tpl     *RuleEnumTemplate
+	deliver func(*RuleChange)
+}
+
+// memState is a snapshot of all the mutable objects in a
+// MemoryEngine. Stored objects are never modified in place, so
+// snapshots only need to copy the maps.
+type memState struct {
+	providers map[ProviderID]*Provider
+	sublayers map[SublayerID]*Sublayer
+	callouts  map[CalloutID]*Callout
+	contexts  map[ProviderContextID]*ProviderContext
+	rules     map[RuleID]*Rule
+	// security maps the ID of each provider, sublayer, callout,
+	// provider context and rule to its SDDL security descriptor. Objects without an entry have
+	// memDefaultSecurity.
+	security map[interface{}]string
+	// owners maps the ID of each object created by a dynamic
+	// session to that session.
+	owners map[interface{}]*memSession
+	// builtin is the set of objects that are part of the engine
+	// itself, and cannot be deleted.
+	builtin map[interface{}]bool
+}
+
+// NewMemoryEngine returns an empty MemoryEngine that has the given
+// layers, or the layers of DefaultSchema if layers is nil. Rules can
+// only be added to those layers, and can only match on the fields
+// the layers declare. The universal sublayer, which is the default
+// sublayer of every WFP layer, is built in. No callouts are, so
+// rules can only invoke callouts added with Session.AddCallout.
+func NewMemoryEngine(layers []*Layer) *MemoryEngine {
+	if layers == nil {
+		layers = defaultSchema.layers
+	}
+	ret := &MemoryEngine{
+		txnLock: make(chan struct{}, 1),
+		state: &memState{
+			providers: map[ProviderID]*Provider{},
+			sublayers: map[SublayerID]*Sublayer{},
+			callouts:  map[CalloutID]*Callout{},
+			contexts:  map[ProviderContextID]*ProviderContext{},
+			rules:     map[RuleID]*Rule{},
+			security:  map[interface{}]string{},
+			owners:    map[interface{}]*memSession{},
+			builtin:   map[interface{}]bool{},
+		},
+		nextKernelID:  1,
+		nextCalloutID: 1,
+		nextContextID: 1,
+	}
+	for _, l := range layers {
+		ret.layers = append(ret.layers, cloneLayer(l))
+	}
+	ret.state.sublayers[guidSublayerUniversal] = &Sublayer{
+		ID:         guidSublayerUniversal,
+		Persistent: true,
+	}
+	ret.state.builtin[guidSublayerUniversal] = true
+	return ret
+}
+
+// AddDropEvent records a packet drop event, which is returned by
+// subsequent calls to Session.DropEvents, and as a classify drop by
+// Engine.NetEvents.
+func (m *MemoryEngine) AddDropEvent(e *DropEvent) {
+	m.AddNetEvent(netEventFromDrop(e))
+}
+
+// AddNetEvent records an event, which is returned by subsequent calls
+// to Engine.NetEvents, and delivered to the matching subscriptions.
+func (m *MemoryEngine) AddNetEvent(e *NetEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, cloneNetEvent(e))
+	// Subscribers must not block, so they can be called with mu
+	// held, which guarantees that unsubscribing waits for them.
+	for sub := range m.subs {
+		if sub.tpl.matches(e) {
+			sub.deliver(cloneNetEvent(e))
+		}
+	}
+}
+
+// Open implements Backend.
+func (m *MemoryEngine) Open(opts *Options) (Engine, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	timeout := opts.TransactionStartTimeout
+	if timeout == 0 {
+		timeout = defaultTransactionStartTimeout
+	}
+	return &memSession{
+		m:       m,
+		dynamic: opts.Dynamic,
+		timeout: timeout,
+	}, nil
+}
+
+// setState makes st the committed state, and notifies rule change
+// subscribers of the rules it adds and deletes. m.mu must be held.
+func (m *MemoryEngine) setState(st *memState) {
+	old := m.state
+	m.state = st
+	if len(m.ruleSubs) == 0 {
+		return
+	}
+
+	// A rule that was deleted and added again within a transaction
+	// is reported as both.
+	var deleted, added []*Rule
+	for id, r := range old.rules {
+		if st.rules[id] != r {
+			deleted = append(deleted, r)
+		}
+	}
+	for id, r := range st.rules {
+		if old.rules[id] != r {
+			added = append(added, r)
+		}
+	}
+	sortByKernelID(deleted)
+	sortByKernelID(added)
+
+	// Subscribers must not block, so they can be called with mu
+	// held, which guarantees that unsubscribing waits for them.
+	for sub := range m.ruleSubs {
+		for _, r := range deleted {
+			if sub.tpl.matches(r, old.contexts) {
+				sub.deliver(&RuleChange{Type: RuleDeleted, ID: r.ID, KernelID: r.KernelID})
+			}
+		}
+		for _, r := range added {
+			if sub.tpl.matches(r, st.contexts) {
+				sub.deliver(&RuleChange{Type: RuleAdded, ID: r.ID, KernelID: r.KernelID, Rule: cloneRule(r)})
+			}
+		}
+	}
+}
+
+func sortByKernelID(rules []*Rule) {
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].KernelID < rules[j].KernelID
+	})
+}
+
+// lockTxn acquires the global write transaction lock, waiting for at
+// most timeout.
+func (m *MemoryEngine) lockTxn(timeout time.Duration) error {
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+	select {
+	case m.txnLock <- struct{}{}:
+		return nil
+	case <-t.C:
+		return syscall.Errno(Timeout)
+	}
+}
+
+// unlockTxn releases the global write transaction lock.
+func (m *MemoryEngine) unlockTxn() {
+	<-m.txnLock
+}
+
+// layer returns the layer whose ID is id, or nil.
+func (m *MemoryEngine) layer(id LayerID) *Layer {
+	for _, l := range m.layers {
+		if l.ID == id {
+			return l
+		}
+	}
+	return nil
+}
+
+// memSession is a session opened on a MemoryEngine.
+type memSession struct {
+	m       *MemoryEngine
+	dynamic bool
+	timeout time.Duration
+	closed  bool
+
+	// txn is the session's private copy of the engine state while
+	// an explicit transaction is in progress, or nil.
+	txn      *memState
+	txnFlags TransactionFlag
+}
+
+// read calls fn with the state visible to s.
+func (s *memSession) read(fn func(st *memState) error) error {
+	if s.closed {
+		return syscall.Errno(NilPointer)
+	}
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	if s.txn != nil {
+		return fn(s.txn)
+	}
+	return fn(s.m.state)
+}
+
+// write calls fn with a copy of the state visible to s, and keeps
+// the copy if fn succeeds. Outside of an explicit transaction, the
+// write is its own transaction.
+func (s *memSession) write(fn func(st *memState) error) error {
+	if s.closed {
+		return syscall.Errno(NilPointer)
+	}
+	if s.txn != nil {
+		if s.txnFlags == TransactionReadOnly {
+			return syscall.Errno(IncompatibleTransaction)
+		}
+		s.m.mu.Lock()
+		defer s.m.mu.Unlock()
+		st := s.txn.clone()
+		if err := fn(st); err != nil {
+			return err
+		}
+		s.txn = st
+		return nil
+	}
+
+	if err := s.m.lockTxn(s.timeout); err != nil {
+		return err
+	}
+	defer s.m.unlockTxn()
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	st := s.m.state.clone()
+	if err := fn(st); err != nil {
+		return err
+	}
+	s.m.setState(st)
+	return nil
+}
+
+func (s *memSession) Close() error {
+	if s.closed {
+		return nil
+	}
+	if s.txn != nil {
+		s.AbortTransaction()
+	}
+	if s.dynamic {
+		// Dynamic objects go away with their session, even if
+		// someone else is holding the transaction lock.
+		s.m.mu.Lock()
+		s.m.setState(s.m.state.withoutOwner(s))
+		s.m.mu.Unlock()
+	}
+	s.m.mu.Lock()
+	for sub := range s.m.subs {
+		if sub.session == s {
+			delete(s.m.subs, sub)
+		}
+	}
+	for sub := range s.m.ruleSubs {
+		if sub.session == s {
+			delete(s.m.ruleSubs, sub)
+		}
+	}
+	s.m.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *memSession) Layers() ([]*Layer, error) {
+	if s.closed {
+		return nil, syscall.Errno(NilPointer)
+	}
+	var ret []*Layer
+	for _, l := range s.m.layers {
+		ret = append(ret, cloneLayer(l))
+	}
+	return ret, nil
+}
+
+func (s *memSession) Providers() ([]*Provider, error) {
+	var ret []*Provider
+	err := s.read(func(st *memState) error {
+		for _, p := range st.providers {
+			ret = append(ret, cloneProvider(p))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID.String() < ret[j].ID.String()
+	})
+	return ret, nil
+}
+
+func (s *memSession) AddProvider(p *Provider) error {
+	return s.write(func(st *memState) error {
+		if _, ok := st.providers[p.ID]; ok {
+			return syscall.Errno(AlreadyExists)
+		}
+		sd, err := memSecurity(p.SecurityDescriptor)
+		if err != nil {
+			return err
+		}
+		n := cloneProvider(p)
+		n.Disabled = false
+		n.SecurityDescriptor = ""
+		st.providers[n.ID] = n
+		st.security[n.ID] = sd
+		s.own(st, n.ID)
+		return nil
+	})
+}
+
+func (s *memSession) DeleteProvider(id ProviderID) error {
+	return s.write(func(st *memState) error {
+		if _, ok := st.providers[id]; !ok {
+			return syscall.Errno(ProviderNotFound)
+		}
+		if st.builtin[id] {
+			return syscall.Errno(BuiltinObject)
+		}
+		for _, sl := range st.sublayers {
+			if sl.Provider == id {
+				return syscall.Errno(InUse)
+			}
+		}
+		for _, c := range st.callouts {
+			if c.Provider == id {
+				return syscall.Errno(InUse)
+			}
+		}
+		for _, pc := range st.contexts {
+			if pc.Provider == id {
+				return syscall.Errno(InUse)
+			}
+		}
+		for _, r := range st.rules {
+			if r.Provider == id {
+				return syscall.Errno(InUse)
+			}
+		}
+		delete(st.providers, id)
+		delete(st.security, id)
+		delete(st.owners, id)
+		return nil
+	})
+}
+
+func (s *memSession) ProviderSecurity(id ProviderID) (string, error) {
+	return s.security(id, ProviderNotFound, func(st *memState) bool {
+		_, ok := st.providers[id]
+		return ok
+	})
+}
+
+func (s *memSession) SetProviderDACL(id ProviderID, sddl string) error {
+	return s.setDACL(id, sddl, ProviderNotFound, func(st *memState) bool {
+		_, ok := st.providers[id]
+		return ok
+	})
+}
+
+func (s *memSession) Sublayers(provider ProviderID) ([]*Sublayer, error) {
+	var ret []*Sublayer
+	err := s.read(func(st *memState) error {
+		for _, sl := range st.sublayers {
+			if !provider.IsZero() && sl.Provider != provider {
+				continue
+			}
+			ret = append(ret, cloneSublayer(sl))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID.String() < ret[j].ID.String()
+	})
+	return ret, nil
+}
+
+func (s *memSession) AddSublayer(sl *Sublayer) error {
+	return s.write(func(st *memState) error {
+		if _, ok := st.sublayers[sl.ID]; ok {
+			return syscall.Errno(AlreadyExists)
+		}
+		if !sl.Provider.IsZero() {
+			p, ok := st.providers[sl.Provider]
+			if !ok {
+				return syscall.Errno(ProviderNotFound)
+			}
+			if sl.Persistent && !p.Persistent {
+				return syscall.Errno(LifetimeMismatch)
+			}
+		}
+		sd, err := memSecurity(sl.SecurityDescriptor)
+		if err != nil {
+			return err
+		}
+		n := cloneSublayer(sl)
+		n.SecurityDescriptor = ""
+		st.sublayers[n.ID] = n
+		st.security[n.ID] = sd
+		s.own(st, n.ID)
+		return nil
+	})
+}
+
+func (s *memSession) DeleteSublayer(id SublayerID) error {
+	return s.write(func(st *memState) error {
+		if _, ok := st.sublayers[id]; !ok {
+			return syscall.Errno(SublayerNotFound)
+		}
+		if st.builtin[id] {
+			return syscall.Errno(BuiltinObject)
+		}
+		for _, r := range st.rules {
+			if r.Sublayer == id {
+				return syscall.Errno(InUse)
+			}
+		}
+		delete(st.sublayers, id)
+		delete(st.security, id)
+		delete(st.owners, id)
+		return nil
+	})
+}
+
+func (s *memSession) SublayerSecurity(id SublayerID) (string, error) {
+	return s.security(id, SublayerNotFound, func(st *memState) bool {
+		_, ok := st.sublayers[id]
+		return ok
+	})
+}
+
+func (s *memSession) SetSublayerDACL(id SublayerID, sddl string) error {
+	return s.setDACL(id, sddl, SublayerNotFound, func(st *memState) bool {
+		_, ok := st.sublayers[id]
+		return ok
+	})
+}
+
+func (s *memSession) Callouts() ([]*Callout, error) {
+	var ret []*Callout
+	err := s.read(func(st *memState) error {
+		for _, c := range st.callouts {
+			ret = append(ret, cloneCallout(c))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID.String() < ret[j].ID.String()
+	})
+	return ret, nil
+}
+
+func (s *memSession) AddCallout(c *Callout) error {
+	return s.write(func(st *memState) error {
+		if _, ok := st.callouts[c.ID]; ok {
+			return syscall.Errno(AlreadyExists)
+		}
+		if s.m.layer(c.Layer) == nil {
+			return syscall.Errno(LayerNotFound)
+		}
+		if !c.Provider.IsZero() {
+			p, ok := st.providers[c.Provider]
+			if !ok {
+				return syscall.Errno(ProviderNotFound)
+			}
+			if c.Persistent && !p.Persistent {
+				return syscall.Errno(LifetimeMismatch)
+			}
+		}
+		sd, err := memSecurity(c.SecurityDescriptor)
+		if err != nil {
+			return err
+		}
+		n := cloneCallout(c)
+		n.KernelID = s.m.nextCalloutID
+		s.m.nextCalloutID++
+		// No driver can register callout functions with a
+		// MemoryEngine.
+		n.Registered = false
+		n.SecurityDescriptor = ""
+		st.callouts[n.ID] = n
+		st.security[n.ID] = sd
+		s.own(st, n.ID)
+		return nil
+	})
+}
+
+func (s *memSession) DeleteCallout(id CalloutID) error {
+	return s.write(func(st *memState) error {
+		if _, ok := st.callouts[id]; !ok {
+			return syscall.Errno(CalloutNotFound)
+		}
+		for _, r := range st.rules {
+			if ActionFlag(r.Action)&ActionFlagCallout != 0 && r.Callout == id {
+				return syscall.Errno(InUse)
+			}
+		}
+		delete(st.callouts, id)
+		delete(st.security, id)
+		delete(st.owners, id)
+		return nil
+	})
+}
+
+func (s *memSession) ProviderContexts() ([]*ProviderContext, error) {
+	var ret []*ProviderContext
+	err := s.read(func(st *memState) error {
+		for _, pc := range st.contexts {
+			ret = append(ret, cloneProviderContext(pc))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID.String() < ret[j].ID.String()
+	})
+	return ret, nil
+}
+
+func (s *memSession) AddProviderContext(pc *ProviderContext) error {
+	return s.write(func(st *memState) error {
+		if _, ok := st.contexts[pc.ID]; ok {
+			return syscall.Errno(AlreadyExists)
+		}
+		if !pc.Provider.IsZero() {
+			p, ok := st.providers[pc.Provider]
+			if !ok {
+				return syscall.Errno(ProviderNotFound)
+			}
+			if pc.Persistent && !p.Persistent {
+				return syscall.Errno(LifetimeMismatch)
+			}
+		}
+		sd, err := memSecurity(pc.SecurityDescriptor)
+		if err != nil {
+			return err
+		}
+		n := cloneProviderContext(pc)
+		n.KernelID = s.m.nextContextID
+		s.m.nextContextID++
+		n.SecurityDescriptor = ""
+		st.contexts[n.ID] = n
+		st.security[n.ID] = sd
+		s.own(st, n.ID)
+		return nil
+	})
+}
+
+func (s *memSession) DeleteProviderContext(id ProviderContextID) error {
+	return s.write(func(st *memState) error {
+		if _, ok := st.contexts[id]; !ok {
+			return syscall.Errno(ProviderContextNotFound)
+		}
+		for _, r := range st.rules {
+			if r.ProviderContext == id {
+				return syscall.Errno(InUse)
+			}
+		}
+		delete(st.contexts, id)
+		delete(st.security, id)
+		delete(st.owners, id)
+		return nil
+	})
+}
+
+func (s *memSession) Rules(tpl *RuleEnumTemplate) ([]*Rule, error) {
+	var ret []*Rule
+	err := s.read(func(st *memState) error {
+		for _, r := range st.rules {
+			if tpl.matches(r, st.contexts) {
+				ret = append(ret, cloneRule(r))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortByKernelID(ret)
+	if tpl != nil && tpl.Flags&FilterEnumFlagsSorted != 0 {
+		sort.SliceStable(ret, func(i, j int) bool {
+			return ret[i].Weight > ret[j].Weight
+		})
+	}
+	return ret, nil
+}
+
+// matches reports whether r should be returned by an enumeration
+// using tpl. contexts are the provider contexts that r may
+// reference.
+func (tpl *RuleEnumTemplate) matches(r *Rule, contexts map[ProviderContextID]*ProviderContext) bool {
+	if tpl == nil {
+		return true
+	}
+	if tpl.Provider != nil && r.Provider != *tpl.Provider {
+		return false
+	}
+	if !tpl.Layer.IsZero() && r.Layer != tpl.Layer {
+		return false
+	}
+	if tpl.ActionMask != ActionFlagIgnore && ActionFlag(r.Action)&tpl.ActionMask == 0 {
+		return false
+	}
+	if tpl.Callout != nil && (ActionFlag(r.Action)&ActionFlagCallout == 0 || r.Callout != *tpl.Callout) {
+		return false
+	}
+	if t := tpl.ProviderContext; t != nil {
+		pc := contexts[r.ProviderContext]
+		if pc == nil || uint32(pc.Type) != t.Type || (t.Provider != nil && pc.Provider != *t.Provider) {
+			return false
+		}
+	}
+	if !conditionsSelected(r.Conditions, tpl.EnumType, tpl.Conditions) {
+		return false
+	}
+	switch {
+	case tpl.Flags&FilterEnumFlagsBootTimeOnly != 0:
+		return r.BootTime
+	case tpl.Flags&FilterEnumFlagsIncludeBootTime == 0:
+		return !r.BootTime
+	}
+	return true
+}
+
+func (s *memSession) AddRule(r *Rule) error {
+	return s.write(func(st *memState) error {
+		if _, ok := st.rules[r.ID]; ok {
+			return syscall.Errno(AlreadyExists)
+		}
+		layer := s.m.layer(r.Layer)
+		if layer == nil {
+			return syscall.Errno(LayerNotFound)
+		}
+		n := cloneRule(r)
+		if n.Sublayer.IsZero() {
+			n.Sublayer = layer.DefaultSublayer
+		}
+		sl, ok := st.sublayers[n.Sublayer]
+		if !ok {
+			return syscall.Errno(SublayerNotFound)
+		}
+		if n.Persistent && !sl.Persistent {
+			return syscall.Errno(LifetimeMismatch)
+		}
+		if !n.Provider.IsZero() {
+			p, ok := st.providers[n.Provider]
+			if !ok {
+				return syscall.Errno(ProviderNotFound)
+			}
+			if n.Persistent && !p.Persistent {
+				return syscall.Errno(LifetimeMismatch)
+			}
+		}
+		for _, c := range n.Conditions {
+			ftype, ok := layer.fieldType(c.Field)
+			if !ok {
+				return syscall.Errno(ConditionNotFound)
+			}
+			v, err := memValue(c.Value, ftype)
+			if err != nil {
+				return fmt.Errorf("invalid match %v: %w", c, err)
+			}
+			c.Value = v
+		}
+		if ActionFlag(n.Action)&ActionFlagCallout != 0 {
+			c, ok := st.callouts[n.Callout]
+			if !ok {
+				return syscall.Errno(CalloutNotFound)
+			}
+			if c.Layer != n.Layer {
+				return syscall.Errno(IncompatibleLayer)
+			}
+		}
+		if !n.ProviderContext.IsZero() {
+			if _, ok := st.contexts[n.ProviderContext]; !ok {
+				return syscall.Errno(ProviderContextNotFound)
+			}
+		}
+		sd, err := memSecurity(n.SecurityDescriptor)
+		if err != nil {
+			return err
+		}
+
+		n.KernelID = s.m.nextKernelID
+		s.m.nextKernelID++
+		n.Disabled = false
+		n.SecurityDescriptor = ""
+		st.rules[n.ID] = n
+		st.security[n.ID] = sd
+		s.own(st, n.ID)
+		return nil
+	})
+}
+
+func (s *memSession) DeleteRule(id RuleID) error {
+	return s.write(func(st *memState) error {
+		if _, ok := st.rules[id]; !ok {
+			return syscall.Errno(FilterNotFound)
+		}
+		delete(st.rules, id)
+		delete(st.security, id)
+		delete(st.owners, id)
+		return nil
+	})
+}
+
+func (s *memSession) RuleSecurity(id RuleID) (string, error) {
+	return s.security(id, FilterNotFound, func(st *memState) bool {
+		_, ok := st.rules[id]
+		return ok
+	})
+}
+
+func (s *memSession) SetRuleDACL(id RuleID, sddl string) error {
+	return s.setDACL(id, sddl, FilterNotFound, func(st *memState) bool {
+		_, ok := st.rules[id]
+		return ok
+	})
+}
+
+func (s *memSession) NetEvents(tpl *NetEventEnumTemplate) ([]*NetEvent, error) {
+	if s.closed {
+		return nil, syscall.Errno(NilPointer)
+	}
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	var ret []*NetEvent
+	for _, e := range s.m.events {
+		if tpl.matches(e) {
+			ret = append(ret, cloneNetEvent(e))
+		}
+	}
+	return ret, nil
+}
+
+func (s *memSession) SubscribeNetEvents(tpl *NetEventEnumTemplate, deliver func(*NetEvent)) (func() error, error) {
+	if s.closed {
+		return nil, syscall.Errno(NilPointer)
+	}
+	sub := &memNetEventSub{
+		session: s,
+		tpl:     tpl,
+		deliver: deliver,
+	}
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	if s.m.subs == nil {
+		s.m.subs = map[*memNetEventSub]bool{}
+	}
+	s.m.subs[sub] = true
+	return func() error {
+		s.m.mu.Lock()
+		defer s.m.mu.Unlock()
+		delete(s.m.subs, sub)
+		return nil
+	}, nil
+}
+
+func (s *memSession) SubscribeRuleChanges(tpl *RuleEnumTemplate, deliver func(*RuleChange)) (func() error, error) {
+	if s.closed {
+		return nil, syscall.Errno(NilPointer)
+	}
+	sub := &memRuleSub{
+		session: s,
+		tpl:     tpl,
+		deliver: deliver,
+	}
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	if s.m.ruleSubs == nil {
+		s.m.ruleSubs = map[*memRuleSub]bool{}
+	}
+	s.m.ruleSubs[sub] = true
+	return func() error {
+		s.m.mu.Lock()
+		defer s.m.mu.Unlock()
+		delete(s.m.ruleSubs, sub)
+		return nil
+	}, nil
+}
+
+func (s *memSession) WalkLayers(ctx context.Context, fn func([]*Layer) error) error {
+	layers, err := s.Layers()
+	if err != nil {
+		return err
+	}
+	return memPages(ctx, len(layers), func(lo, hi int) error {
+		return fn(layers[lo:hi:hi])
+	})
+}
+
+func (s *memSession) WalkProviders(ctx context.Context, fn func([]*Provider) error) error {
+	providers, err := s.Providers()
+	if err != nil {
+		return err
+	}
+	return memPages(ctx, len(providers), func(lo, hi int) error {
+		return fn(providers[lo:hi:hi])
+	})
+}
+
+func (s *memSession) WalkSublayers(ctx context.Context, provider ProviderID, fn func([]*Sublayer) error) error {
+	sublayers, err := s.Sublayers(provider)
+	if err != nil {
+		return err
+	}
+	return memPages(ctx, len(sublayers), func(lo, hi int) error {
+		return fn(sublayers[lo:hi:hi])
+	})
+}
+
+func (s *memSession) WalkCallouts(ctx context.Context, fn func([]*Callout) error) error {
+	callouts, err := s.Callouts()
+	if err != nil {
+		return err
+	}
+	return memPages(ctx, len(callouts), func(lo, hi int) error {
+		return fn(callouts[lo:hi:hi])
+	})
+}
+
+func (s *memSession) WalkRules(ctx context.Context, tpl *RuleEnumTemplate, fn func([]*Rule) error) error {
+	rules, err := s.Rules(tpl)
+	if err != nil {
+		return err
+	}
+	return memPages(ctx, len(rules), func(lo, hi int) error {
+		return fn(rules[lo:hi:hi])
+	})
+}
+
+func (s *memSession) WalkNetEvents(ctx context.Context, tpl *NetEventEnumTemplate, fn func([]*NetEvent) error) error {
+	events, err := s.NetEvents(tpl)
+	if err != nil {
+		return err
+	}
+	return memPages(ctx, len(events), func(lo, hi int) error {
+		return fn(events[lo:hi:hi])
+	})
+}
+
+// memPages splits n objects into pages of at most enumPageSize, and
+// calls fn with the bounds of each page, like a real engine's enum
+// handle would.
+func memPages(ctx context.Context, n int, fn func(lo, hi int) error) error {
+	for lo := 0; lo < n; lo += enumPageSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hi := lo + enumPageSize
+		if hi > n {
+			hi = n
+		}
+		if err := fn(lo, hi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memSession) BeginTransaction(flags TransactionFlag) error {
+	if s.closed {
+		return syscall.Errno(NilPointer)
+	}
+	if s.txn != nil {
+		return syscall.Errno(TransactionInProgress)
+	}
+	if flags != TransactionReadOnly {
+		if err := s.m.lockTxn(s.timeout); err != nil {
+			return err
+		}
+	}
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	s.txn = s.m.state.clone()
+	s.txnFlags = flags
+	return nil
+}
+
+func (s *memSession) CommitTransaction() error {
+	if s.closed {
+		return syscall.Errno(NilPointer)
+	}
+	if s.txn == nil {
+		return syscall.Errno(NoTransactionInProgress)
+	}
+	if s.txnFlags != TransactionReadOnly {
+		s.m.mu.Lock()
+		s.m.setState(s.txn)
+		s.m.mu.Unlock()
+		s.m.unlockTxn()
+	}
+	s.txn = nil
+	return nil
+}
+
+func (s *memSession) AbortTransaction() error {
+	if s.closed {
+		return syscall.Errno(NilPointer)
+	}
+	if s.txn == nil {
+		return syscall.Errno(NoTransactionInProgress)
+	}
+	if s.txnFlags != TransactionReadOnly {
+		s.m.unlockTxn()
+	}
+	s.txn = nil
+	return nil
+}
+
+// own records that the object whose ID is id was created by s, if s
+// is a dynamic session.
+func (s *memSession) own(st *memState, id interface{}) {
+	if s.dynamic {
+		st.owners[id] = s
+	}
+}
+
+// memDefaultSecurity is the security descriptor of objects created
+// without one. Like WFP's default, it is owned by and grants full
+// access to Administrators and LocalSystem.
+const memDefaultSecurity = "O:BAG:SYD:(A;;GA;;;BA)(A;;GA;;;SY)"
+
+// memSecurity returns the security descriptor stored for an object
+// created with the security descriptor sddl. Components missing from
+// sddl are taken from memDefaultSecurity, as WFP fills them in from
+// the caller's token.
+func memSecurity(sddl string) (string, error) {
+	if sddl == "" {
+		return memDefaultSecurity, nil
+	}
+	parts, err := parseSDDL(sddl)
+	if err != nil {
+		return "", err
+	}
+	def, err := parseSDDL(memDefaultSecurity)
+	if err != nil {
+		panic(err)
+	}
+	for _, c := range []byte("OGD") {
+		if _, ok := parts[c]; !ok {
+			parts[c] = def[c]
+		}
+	}
+	return formatSDDL(parts), nil
+}
+
+// security returns the security descriptor of the object whose ID is
+// id, or notFound if exists reports that it doesn't exist.
+func (s *memSession) security(id interface{}, notFound syscall.Errno, exists func(*memState) bool) (string, error) {
+	var ret string
+	err := s.read(func(st *memState) error {
+		if !exists(st) {
+			return notFound
+		}
+		ret = memDefaultSecurity
+		if sd, ok := st.security[id]; ok {
+			ret = sd
+		}
+		return nil
+	})
+	return ret, err
+}
+
+// setDACL replaces the DACL of the object whose ID is id with the DACL
+// of sddl, or returns notFound if exists reports that the object
+// doesn't exist.
+func (s *memSession) setDACL(id interface{}, sddl string, notFound syscall.Errno, exists func(*memState) bool) error {
+	return s.write(func(st *memState) error {
+		if !exists(st) {
+			return notFound
+		}
+		cur, ok := st.security[id]
+		if !ok {
+			cur = memDefaultSecurity
+		}
+		sd, err := replaceDACL(cur, sddl)
+		if err != nil {
+			return err
+		}
+		st.security[id] = sd
+		return nil
+	})
+}
+
+// clone returns a copy of st that can be modified without affecting
+// st.
+func (st *memState) clone() *memState {
+	ret := &memState{
+		providers: make(map[ProviderID]*Provider, len(st.providers)),
+		sublayers: make(map[SublayerID]*Sublayer, len(st.sublayers)),
+		callouts:  make(map[CalloutID]*Callout, len(st.callouts)),
+		contexts:  make(map[ProviderContextID]*ProviderContext, len(st.contexts)),
+		rules:     make(map[RuleID]*Rule, len(st.rules)),
+		security:  make(map[interface{}]string, len(st.security)),
+		owners:    make(map[interface{}]*memSession, len(st.owners)),
+		builtin:   st.builtin,
+	}
+	for k, v := range st.providers {
+		ret.providers[k] = v
+	}
+	for k, v := range st.sublayers {
+		ret.sublayers[k] = v
+	}
+	for k, v := range st.callouts {
+		ret.callouts[k] = v
+	}
+	for k, v := range st.contexts {
+		ret.contexts[k] = v
+	}
+	for k, v := range st.rules {
+		ret.rules[k] = v
+	}
+	for k, v := range st.security {
+		ret.security[k] = v
+	}
+	for k, v := range st.owners {
+		ret.owners[k] = v
+	}
+	return ret
+}
+
+// withoutOwner returns a copy of st without the objects created by
+// the dynamic session s.
+func (st *memState) withoutOwner(s *memSession) *memState {
+	ret := st.clone()
+	for id, owner := range st.owners {
+		if owner != s {
+			continue
+		}
+		switch id := id.(type) {
+		case ProviderID:
+			delete(ret.providers, id)
+		case SublayerID:
+			delete(ret.sublayers, id)
+		case CalloutID:
+			delete(ret.callouts, id)
+		case ProviderContextID:
+			delete(ret.contexts, id)
+		case RuleID:
+			delete(ret.rules, id)
+		}
+		delete(ret.security, id)
+		delete(ret.owners, id)
+	}
+	return ret
+}
+
+// fieldType returns the type of l's field whose ID is id, and
+// whether l has such a field.
+func (l *Layer) fieldType(id FieldID) (reflect.Type, bool) {
+	for _, f := range l.Fields {
+		if f.ID == id {
+			return f.Type, true
+		}
+	}
+	return nil, false
+}
+
+// memValue returns v as a filtering engine stores it in a field of
+// type ftype, which is the value that Session.Rules returns for it:
+// IPProto values become uint8, ConditionFlag values become uint32,
+// IPv4-mapped addresses are unmapped, and so on. It returns the same
+// errors as the Windows engine for values that can't be stored in
+// the field.
+func memValue(v interface{}, ftype reflect.Type) (interface{}, error) {
+	mapErr := func() (interface{}, error) {
+		return nil, fmt.Errorf("cannot map Go type %T to field type %s", v, ftype)
+	}
+
+	if r, ok := v.(Range); ok {
+		switch ftype {
+		case typeUint8, typeUint16, typeUint32, typeUint64, typeBytes, typeString, typeArray16, typeRange:
+		default:
+			return mapErr()
+		}
+		if _, ok := r.From.(Range); ok {
+			return nil, errors.New("can't have a Range of Ranges")
+		}
+		if _, ok := r.To.(Range); ok {
+			return nil, errors.New("can't have a Range of Ranges")
+		}
+		from, err := memValue(r.From, ftype)
+		if err != nil {
+			return nil, err
+		}
+		to, err := memValue(r.To, ftype)
+		if err != nil {
+			return nil, err
+		}
+		if reflect.TypeOf(from) != reflect.TypeOf(to) {
+			return nil, fmt.Errorf("range type mismatch: %T vs. %T", r.From, r.To)
+		}
+		return Range{from, to}, nil
+	}
+
+	switch ftype {
+	case typeUint8:
+		switch u := v.(type) {
+		case uint8:
+			return u, nil
+		case IPProto:
+			return uint8(u), nil
+		}
+	case typeUint16:
+		if u, ok := v.(uint16); ok {
+			return u, nil
+		}
+	case typeUint32:
+		switch u := v.(type) {
+		case uint32:
+			return u, nil
+		case ConditionFlag:
+			return uint32(u), nil
+		}
+	case typeUint64:
+		if u, ok := v.(uint64); ok {
+			return u, nil
+		}
+	case typeBytes:
+		if bs, ok := v.([]byte); ok {
+			return cloneBytes(bs), nil
+		}
+	case typeString:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case typeArray16:
+		if bs, ok := v.([16]byte); ok {
+			return bs, nil
+		}
+	case typeSID, typeSecurityDescriptor:
+		if reflect.TypeOf(v) == ftype {
+			return v, nil
+		}
+	case typeMAC:
+		if mac, ok := v.(net.HardwareAddr); ok && len(mac) == 6 {
+			return net.HardwareAddr(cloneBytes(mac)), nil
+		}
+	case typeIP:
+		switch ip := v.(type) {
+		case netip.Addr:
+			if ip.Is4() {
+				return ip, nil
+			}
+			return netip.AddrFrom16(ip.As16()).Unmap(), nil
+		case netip.Prefix:
+			if ip.Addr().Is4() {
+				return ip.Masked(), nil
+			}
+			return netip.PrefixFrom(netip.AddrFrom16(ip.Addr().As16()).Unmap(), ip.Bits()), nil
+		case netipx.IPRange:
+			if !ip.IsValid() {
+				return nil, fmt.Errorf("invalid IPRange %v", ip)
+			}
+			from, _ := memValue(ip.From(), ftype)
+			to, _ := memValue(ip.To(), ftype)
+			return netipx.IPRangeFrom(from.(netip.Addr), to.(netip.Addr)), nil
+		}
+	}
+	return mapErr()
+}
+
+func cloneLayer(l *Layer) *Layer {
+	ret := *l
+	ret.Fields = nil
+	for _, f := range l.Fields {
+		nf := *f
+		ret.Fields = append(ret.Fields, &nf)
+	}
+	return &ret
+}
+
+func cloneProvider(p *Provider) *Provider {
+	ret := *p
+	ret.Data = cloneBytes(p.Data)
+	return &ret
+}
+
+func cloneSublayer(sl *Sublayer) *Sublayer {
+	ret := *sl
+	ret.ProviderData = cloneBytes(sl.ProviderData)
+	return &ret
+}
+
+func cloneCallout(c *Callout) *Callout {
+	ret := *c
+	ret.ProviderData = cloneBytes(c.ProviderData)
+	return &ret
+}
+
+func cloneProviderContext(pc *ProviderContext) *ProviderContext {
+	ret := *pc
+	ret.ProviderData = cloneBytes(pc.ProviderData)
+	ret.Data = cloneBytes(pc.Data)
+	return &ret
+}
+
+func cloneRule(r *Rule) *Rule {
+	ret := *r
+	ret.ProviderData = cloneBytes(r.ProviderData)
+	ret.Conditions = nil
+	for _, c := range r.Conditions {
+		nc := *c
+		if bs, ok := nc.Value.([]byte); ok {
+			nc.Value = cloneBytes(bs)
+		}
+		ret.Conditions = append(ret.Conditions, &nc)
+	}
+	return &ret
+}
+
+func cloneBytes(bs []byte) []byte {
+	if bs == nil {
+		return nil
+	}
+	return append([]byte(nil), bs...)
+}
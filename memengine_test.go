@@ -0,0 +1,696 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"net/netip"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testLayers is a minimal set of layers for MemoryEngine tests.
+var testLayers = []*Layer{
+	{
+		ID:              LayerALEAuthConnectV4,
+		KernelID:        48,
+		DefaultSublayer: guidSublayerUniversal,
+		Fields: []*Field{
+			{FieldALEAppID, typeString},
+			{FieldIPProtocol, typeUint8},
+			{FieldIPRemoteAddress, typeIP},
+			{FieldIPRemotePort, typeUint16},
+			{FieldFlags, typeUint32},
+		},
+	},
+}
+
+func newMemorySession(t *testing.T, m *MemoryEngine, opts *Options) *Session {
+	t.Helper()
+	if opts == nil {
+		opts = &Options{}
+	}
+	opts.Backend = m
+	s, err := New(opts)
+	if err != nil {
+		t.Fatalf("opening session: %v", err)
+	}
+	return s
+}
+
+func TestMemoryEngineProviders(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(testLayers), nil)
+	defer s.Close()
+
+	p := &Provider{
+		ID:   ProviderID{Data1: 1},
+		Name: "test provider",
+		Data: []byte("byte blob"),
+	}
+	if err := s.AddProvider(p); err != nil {
+		t.Fatalf("add provider failed: %v", err)
+	}
+	if err := s.AddProvider(p); err != syscall.Errno(AlreadyExists) {
+		t.Fatalf("adding duplicate provider: got err %v, want AlreadyExists", err)
+	}
+
+	providers, err := s.Providers()
+	if err != nil {
+		t.Fatalf("get providers failed: %v", err)
+	}
+	if diff := cmp.Diff(providers, []*Provider{p}); diff != "" {
+		t.Fatalf("providers are wrong (-got+want):\n%s", diff)
+	}
+
+	// Returned objects must not alias engine state.
+	providers[0].Data[0] = 'X'
+	providers, err = s.Providers()
+	if err != nil {
+		t.Fatalf("get providers failed: %v", err)
+	}
+	if string(providers[0].Data) != "byte blob" {
+		t.Fatalf("engine state was modified through returned provider")
+	}
+
+	if err := s.DeleteProvider(p.ID); err != nil {
+		t.Fatalf("delete provider failed: %v", err)
+	}
+	if err := s.DeleteProvider(p.ID); err != syscall.Errno(ProviderNotFound) {
+		t.Fatalf("deleting missing provider: got err %v, want ProviderNotFound", err)
+	}
+}
+
+func TestMemoryEngineOwnership(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(testLayers), nil)
+	defer s.Close()
+
+	p := &Provider{ID: ProviderID{Data1: 1}}
+	sl := &Sublayer{ID: SublayerID{Data1: 2}, Provider: p.ID}
+	r := &Rule{
+		ID:       RuleID{Data1: 3},
+		Layer:    LayerALEAuthConnectV4,
+		Sublayer: sl.ID,
+		Provider: p.ID,
+		Action:   ActionBlock,
+	}
+
+	if err := s.AddSublayer(sl); err != syscall.Errno(ProviderNotFound) {
+		t.Fatalf("adding sublayer with missing provider: got err %v, want ProviderNotFound", err)
+	}
+	if err := s.AddRule(r); err != syscall.Errno(SublayerNotFound) {
+		t.Fatalf("adding rule with missing sublayer: got err %v, want SublayerNotFound", err)
+	}
+	if err := s.AddProvider(p); err != nil {
+		t.Fatalf("add provider failed: %v", err)
+	}
+	if err := s.AddSublayer(sl); err != nil {
+		t.Fatalf("add sublayer failed: %v", err)
+	}
+
+	bad := *r
+	bad.Layer = LayerID{Data1: 42}
+	if err := s.AddRule(&bad); err != syscall.Errno(LayerNotFound) {
+		t.Fatalf("adding rule to unknown layer: got err %v, want LayerNotFound", err)
+	}
+	bad = *r
+	bad.Conditions = []*Match{{Field: FieldIPLocalPort, Op: MatchTypeEqual, Value: uint16(1)}}
+	if err := s.AddRule(&bad); err != syscall.Errno(ConditionNotFound) {
+		t.Fatalf("adding rule with unknown field: got err %v, want ConditionNotFound", err)
+	}
+	bad = *r
+	bad.Persistent = true
+	if err := s.AddRule(&bad); err != syscall.Errno(LifetimeMismatch) {
+		t.Fatalf("adding persistent rule to non-persistent sublayer: got err %v, want LifetimeMismatch", err)
+	}
+
+	if err := s.AddRule(r); err != nil {
+		t.Fatalf("add rule failed: %v", err)
+	}
+	if err := s.DeleteSublayer(sl.ID); err != syscall.Errno(InUse) {
+		t.Fatalf("deleting sublayer in use: got err %v, want InUse", err)
+	}
+	if err := s.DeleteProvider(p.ID); err != syscall.Errno(InUse) {
+		t.Fatalf("deleting provider in use: got err %v, want InUse", err)
+	}
+	if err := s.DeleteSublayer(guidSublayerUniversal); err != syscall.Errno(BuiltinObject) {
+		t.Fatalf("deleting built-in sublayer: got err %v, want BuiltinObject", err)
+	}
+
+	if err := s.DeleteRule(r.ID); err != nil {
+		t.Fatalf("delete rule failed: %v", err)
+	}
+	if err := s.DeleteRule(r.ID); err != syscall.Errno(FilterNotFound) {
+		t.Fatalf("deleting missing rule: got err %v, want FilterNotFound", err)
+	}
+	if err := s.DeleteSublayer(sl.ID); err != nil {
+		t.Fatalf("delete sublayer failed: %v", err)
+	}
+	if err := s.DeleteProvider(p.ID); err != nil {
+		t.Fatalf("delete provider failed: %v", err)
+	}
+}
+
+func TestMemoryEngineCallouts(t *testing.T) {
+	m := NewMemoryEngine(testLayers)
+	s := newMemorySession(t, m, nil)
+	defer s.Close()
+
+	p := &Provider{ID: ProviderID{Data1: 1}}
+	c := &Callout{
+		ID:           CalloutID{Data1: 2},
+		Name:         "test callout",
+		Layer:        LayerALEAuthConnectV4,
+		Provider:     p.ID,
+		ProviderData: []byte("byte blob"),
+	}
+	r := &Rule{
+		ID:      RuleID{Data1: 3},
+		Layer:   LayerALEAuthConnectV4,
+		Action:  ActionCalloutTerminating,
+		Callout: c.ID,
+	}
+
+	if err := s.AddRule(r); err != syscall.Errno(CalloutNotFound) {
+		t.Fatalf("adding rule with missing callout: got err %v, want CalloutNotFound", err)
+	}
+	if err := s.AddCallout(c); err != syscall.Errno(ProviderNotFound) {
+		t.Fatalf("adding callout with missing provider: got err %v, want ProviderNotFound", err)
+	}
+	if err := s.AddProvider(p); err != nil {
+		t.Fatalf("add provider failed: %v", err)
+	}
+	bad := *c
+	bad.Layer = LayerID{Data1: 42}
+	if err := s.AddCallout(&bad); err != syscall.Errno(LayerNotFound) {
+		t.Fatalf("adding callout to unknown layer: got err %v, want LayerNotFound", err)
+	}
+	bad = *c
+	bad.Persistent = true
+	if err := s.AddCallout(&bad); err != syscall.Errno(LifetimeMismatch) {
+		t.Fatalf("adding persistent callout to non-persistent provider: got err %v, want LifetimeMismatch", err)
+	}
+	bad = *c
+	bad.SecurityDescriptor = "not SDDL"
+	if err := s.AddCallout(&bad); err == nil {
+		t.Fatal("adding callout with invalid security descriptor succeeded")
+	}
+	withSD := *c
+	withSD.SecurityDescriptor = "D:(A;;GA;;;SY)"
+	c = &withSD
+	if err := s.AddCallout(c); err != nil {
+		t.Fatalf("add callout failed: %v", err)
+	}
+	if err := s.AddCallout(c); err != syscall.Errno(AlreadyExists) {
+		t.Fatalf("adding duplicate callout: got err %v, want AlreadyExists", err)
+	}
+
+	callouts, err := s.Callouts()
+	if err != nil {
+		t.Fatalf("get callouts failed: %v", err)
+	}
+	want := *c
+	want.KernelID = 1
+	// Security descriptors are only used on creation.
+	want.SecurityDescriptor = ""
+	if diff := cmp.Diff(callouts, []*Callout{&want}); diff != "" {
+		t.Fatalf("callouts are wrong (-got+want):\n%s", diff)
+	}
+	// Returned objects must not alias engine state.
+	callouts[0].ProviderData[0] = 'X'
+	if callouts, _ = s.Callouts(); string(callouts[0].ProviderData) != "byte blob" {
+		t.Fatalf("engine state was modified through returned callout")
+	}
+
+	if err := s.AddRule(r); err != nil {
+		t.Fatalf("add rule failed: %v", err)
+	}
+	if err := s.DeleteCallout(c.ID); err != syscall.Errno(InUse) {
+		t.Fatalf("deleting callout in use: got err %v, want InUse", err)
+	}
+	if err := s.DeleteRule(r.ID); err != nil {
+		t.Fatalf("delete rule failed: %v", err)
+	}
+	if err := s.DeleteProvider(p.ID); err != syscall.Errno(InUse) {
+		t.Fatalf("deleting provider of callout: got err %v, want InUse", err)
+	}
+	if err := s.DeleteCallout(c.ID); err != nil {
+		t.Fatalf("delete callout failed: %v", err)
+	}
+	if err := s.DeleteCallout(c.ID); err != syscall.Errno(CalloutNotFound) {
+		t.Fatalf("deleting missing callout: got err %v, want CalloutNotFound", err)
+	}
+
+	// The callouts of a dynamic session are deleted when it closes.
+	dyn := newMemorySession(t, m, &Options{Dynamic: true})
+	if err := dyn.AddCallout(&Callout{ID: CalloutID{Data1: 4}, Layer: LayerALEAuthConnectV4}); err != nil {
+		t.Fatalf("add dynamic callout failed: %v", err)
+	}
+	if err := dyn.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if callouts, err := s.Callouts(); err != nil || len(callouts) != 0 {
+		t.Fatalf("callouts after dynamic session closed: %v, %v, want none", callouts, err)
+	}
+
+	// Rules can only invoke callouts of their own layer.
+	all := newMemorySession(t, NewMemoryEngine(nil), nil)
+	defer all.Close()
+	v6 := &Callout{ID: CalloutID{Data1: 5}, Layer: LayerALEAuthConnectV6}
+	if err := all.AddCallout(v6); err != nil {
+		t.Fatalf("add callout failed: %v", err)
+	}
+	mismatched := &Rule{ID: RuleID{Data1: 6}, Layer: LayerALEAuthConnectV4, Action: ActionCalloutTerminating, Callout: v6.ID}
+	if err := all.AddRule(mismatched); err != syscall.Errno(IncompatibleLayer) {
+		t.Fatalf("adding rule invoking callout of another layer: got err %v, want IncompatibleLayer", err)
+	}
+}
+
+func TestMemoryEngineProviderContexts(t *testing.T) {
+	m := NewMemoryEngine(testLayers)
+	s := newMemorySession(t, m, nil)
+	defer s.Close()
+
+	p := &Provider{ID: ProviderID{Data1: 1}}
+	pc := &ProviderContext{
+		ID:       ProviderContextID{Data1: 2},
+		Name:     "test context",
+		Provider: p.ID,
+		Type:     ProviderContextGeneral,
+		Data:     []byte("config"),
+	}
+	r := &Rule{
+		ID:              RuleID{Data1: 3},
+		Layer:           LayerALEAuthConnectV4,
+		Action:          ActionBlock,
+		ProviderContext: pc.ID,
+	}
+
+	if err := s.AddRule(r); err != syscall.Errno(ProviderContextNotFound) {
+		t.Fatalf("adding rule with missing provider context: got err %v, want ProviderContextNotFound", err)
+	}
+	if err := s.AddProviderContext(pc); err != syscall.Errno(ProviderNotFound) {
+		t.Fatalf("adding provider context with missing provider: got err %v, want ProviderNotFound", err)
+	}
+	if err := s.AddProvider(p); err != nil {
+		t.Fatalf("add provider failed: %v", err)
+	}
+	bad := *pc
+	bad.Type = 0
+	if err := s.AddProviderContext(&bad); err == nil {
+		t.Fatal("adding provider context of unsupported type succeeded")
+	}
+	bad = *pc
+	bad.SecurityDescriptor = "not SDDL"
+	if err := s.AddProviderContext(&bad); err == nil {
+		t.Fatal("adding provider context with invalid security descriptor succeeded")
+	}
+	withSD := *pc
+	withSD.SecurityDescriptor = "D:(A;;GA;;;SY)"
+	pc = &withSD
+	if err := s.AddProviderContext(pc); err != nil {
+		t.Fatalf("add provider context failed: %v", err)
+	}
+	if err := s.AddProviderContext(pc); err != syscall.Errno(AlreadyExists) {
+		t.Fatalf("adding duplicate provider context: got err %v, want AlreadyExists", err)
+	}
+
+	contexts, err := s.ProviderContexts()
+	if err != nil {
+		t.Fatalf("get provider contexts failed: %v", err)
+	}
+	want := *pc
+	want.KernelID = 1
+	// Security descriptors are only used on creation.
+	want.SecurityDescriptor = ""
+	if diff := cmp.Diff(contexts, []*ProviderContext{&want}); diff != "" {
+		t.Fatalf("provider contexts are wrong (-got+want):\n%s", diff)
+	}
+	// Returned objects must not alias engine state.
+	contexts[0].Data[0] = 'X'
+	if contexts, _ = s.ProviderContexts(); string(contexts[0].Data) != "config" {
+		t.Fatalf("engine state was modified through returned provider context")
+	}
+
+	both := *r
+	both.RawContext = 42
+	if err := s.AddRule(&both); err == nil {
+		t.Fatal("adding rule with both a provider context and a raw context succeeded")
+	}
+	raw := *r
+	raw.ID = RuleID{Data1: 5}
+	raw.ProviderContext = ProviderContextID{}
+	raw.RawContext = 42
+	for _, r := range []*Rule{r, &raw} {
+		if err := s.AddRule(r); err != nil {
+			t.Fatalf("add rule failed: %v", err)
+		}
+	}
+	rules, err := s.Rules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 2 || rules[0].ProviderContext != pc.ID || rules[1].RawContext != 42 {
+		t.Fatalf("rules lost their contexts: %v", rules)
+	}
+	// Enumerating by provider context only finds the rule that
+	// references a matching one.
+	for _, test := range []struct {
+		tpl  *ProviderContextEnumTemplate
+		want int
+	}{
+		{&ProviderContextEnumTemplate{Type: uint32(ProviderContextGeneral)}, 1},
+		{&ProviderContextEnumTemplate{Provider: &p.ID, Type: uint32(ProviderContextGeneral)}, 1},
+		{&ProviderContextEnumTemplate{Provider: &ProviderID{Data1: 9}, Type: uint32(ProviderContextGeneral)}, 0},
+		{&ProviderContextEnumTemplate{Type: 0}, 0},
+	} {
+		got, err := s.engine.Rules(&RuleEnumTemplate{ProviderContext: test.tpl, ActionMask: ActionFlagIgnore})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != test.want || (test.want == 1 && got[0].ID != r.ID) {
+			t.Errorf("enumerating with %+v got %v, want %d rules", test.tpl, got, test.want)
+		}
+	}
+
+	if err := s.DeleteProviderContext(pc.ID); err != syscall.Errno(InUse) {
+		t.Fatalf("deleting provider context in use: got err %v, want InUse", err)
+	}
+	if err := s.DeleteRule(r.ID); err != nil {
+		t.Fatalf("delete rule failed: %v", err)
+	}
+	if err := s.DeleteProvider(p.ID); err != syscall.Errno(InUse) {
+		t.Fatalf("deleting provider of provider context: got err %v, want InUse", err)
+	}
+	if err := s.DeleteProviderContext(pc.ID); err != nil {
+		t.Fatalf("delete provider context failed: %v", err)
+	}
+	if err := s.DeleteProviderContext(pc.ID); err != syscall.Errno(ProviderContextNotFound) {
+		t.Fatalf("deleting missing provider context: got err %v, want ProviderContextNotFound", err)
+	}
+}
+
+func TestMemoryEngineSecurity(t *testing.T) {
+	m := NewMemoryEngine(testLayers)
+	s := newMemorySession(t, m, nil)
+	defer s.Close()
+
+	const serviceOnly = "D:P(A;;GA;;;SY)"
+	p := &Provider{ID: ProviderID{Data1: 1}, SecurityDescriptor: "O:SYG:SY" + serviceOnly}
+	sl := &Sublayer{ID: SublayerID{Data1: 2}}
+	r := &Rule{
+		ID:                 RuleID{Data1: 3},
+		Layer:              LayerALEAuthConnectV4,
+		Action:             ActionBlock,
+		SecurityDescriptor: serviceOnly,
+	}
+
+	bad := *p
+	bad.SecurityDescriptor = "X:garbage"
+	if err := s.AddProvider(&bad); err == nil {
+		t.Fatal("adding provider with invalid security descriptor succeeded")
+	}
+	if err := s.AddProvider(p); err != nil {
+		t.Fatalf("add provider failed: %v", err)
+	}
+	if err := s.AddSublayer(sl); err != nil {
+		t.Fatalf("add sublayer failed: %v", err)
+	}
+	if err := s.AddRule(r); err != nil {
+		t.Fatalf("add rule failed: %v", err)
+	}
+
+	// Security descriptors aren't returned by enumeration.
+	rules, err := s.Rules()
+	if err != nil {
+		t.Fatalf("get rules failed: %v", err)
+	}
+	if got := rules[0].SecurityDescriptor; got != "" {
+		t.Errorf("enumerated rule has security descriptor %q, want none", got)
+	}
+
+	get := func(name string, fn func() (string, error), want string) {
+		t.Helper()
+		got, err := fn()
+		if err != nil {
+			t.Fatalf("getting %s security failed: %v", name, err)
+		}
+		if got != want {
+			t.Errorf("%s security descriptor = %q, want %q", name, got, want)
+		}
+	}
+	get("provider", func() (string, error) { return s.ProviderSecurity(p.ID) }, "O:SYG:SYD:P(A;;GA;;;SY)")
+	get("sublayer", func() (string, error) { return s.SublayerSecurity(sl.ID) }, memDefaultSecurity)
+	// Missing components are filled in from the default.
+	get("rule", func() (string, error) { return s.RuleSecurity(r.ID) }, "O:BAG:SYD:P(A;;GA;;;SY)")
+	get("universal sublayer", func() (string, error) { return s.SublayerSecurity(guidSublayerUniversal) }, memDefaultSecurity)
+
+	// Setting the DACL only changes the DACL.
+	if err := s.SetSublayerDACL(sl.ID, "O:SY"+serviceOnly); err != nil {
+		t.Fatalf("set sublayer DACL failed: %v", err)
+	}
+	get("sublayer", func() (string, error) { return s.SublayerSecurity(sl.ID) }, "O:BAG:SYD:P(A;;GA;;;SY)")
+	if err := s.SetRuleDACL(r.ID, "O:SY"); err == nil {
+		t.Error("setting rule DACL from a security descriptor without one succeeded")
+	}
+	if err := s.SetProviderDACL(p.ID, ""); err == nil {
+		t.Error("setting empty provider DACL succeeded")
+	}
+
+	// DACL changes made in an aborted transaction are discarded.
+	s.BeginTransaction(TransactionReadWrite)
+	if err := s.SetRuleDACL(r.ID, "D:(A;;GA;;;WD)"); err != nil {
+		t.Fatalf("set rule DACL failed: %v", err)
+	}
+	s.AbortTransaction()
+	get("rule", func() (string, error) { return s.RuleSecurity(r.ID) }, "O:BAG:SYD:P(A;;GA;;;SY)")
+
+	missing := RuleID{Data1: 42}
+	if _, err := s.RuleSecurity(missing); err != FilterNotFound {
+		t.Errorf("getting security of missing rule: got err %v, want FilterNotFound", err)
+	}
+	if err := s.SetProviderDACL(ProviderID{Data1: 42}, serviceOnly); err != syscall.Errno(ProviderNotFound) {
+		t.Errorf("setting DACL of missing provider: got err %v, want ProviderNotFound", err)
+	}
+	if _, err := s.SublayerSecurity(SublayerID{}); err == nil {
+		t.Error("getting security of zero sublayer ID succeeded")
+	}
+
+	// A recreated object doesn't inherit the old security descriptor.
+	if err := s.DeleteRule(r.ID); err != nil {
+		t.Fatalf("delete rule failed: %v", err)
+	}
+	if _, err := s.RuleSecurity(r.ID); err != FilterNotFound {
+		t.Errorf("getting security of deleted rule: got err %v, want FilterNotFound", err)
+	}
+	plain := *r
+	plain.SecurityDescriptor = ""
+	if err := s.AddRule(&plain); err != nil {
+		t.Fatalf("re-adding rule failed: %v", err)
+	}
+	get("rule", func() (string, error) { return s.RuleSecurity(r.ID) }, memDefaultSecurity)
+}
+
+func TestMemoryEngineRules(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(testLayers), nil)
+	defer s.Close()
+
+	p := &Provider{ID: ProviderID{Data1: 1}}
+	if err := s.AddProvider(p); err != nil {
+		t.Fatal(err)
+	}
+
+	owned := &Rule{
+		ID:     RuleID{Data1: 1},
+		Layer:  LayerALEAuthConnectV4,
+		Weight: 1,
+		Conditions: []*Match{
+			{Field: FieldALEAppID, Op: MatchTypeEqual, Value: "test"},
+		},
+		Action:   ActionPermit,
+		Provider: p.ID,
+		Disabled: true,
+	}
+	other := &Rule{
+		ID:     RuleID{Data1: 2},
+		Layer:  LayerALEAuthConnectV4,
+		Weight: 2,
+		Action: ActionBlock,
+	}
+	for _, r := range []*Rule{owned, other} {
+		if err := s.AddRule(r); err != nil {
+			t.Fatalf("add rule failed: %v", err)
+		}
+	}
+
+	rules, err := s.Rules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("got %d rules, want 2", len(rules))
+	}
+	want := *owned
+	want.Sublayer = guidSublayerUniversal
+	want.KernelID = 1
+	want.Disabled = false
+	if diff := cmp.Diff(rules[0], &want); diff != "" {
+		t.Fatalf("rule is wrong (-got+want):\n%s", diff)
+	}
+
+	rules, err = s.EnumerateRules(FilterEnumTypeOverlapping, LayerALEAuthConnectV4).
+		WithProvider(p.ID).
+		WithActionMask(ActionFlagIgnore).
+		Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || rules[0].ID != owned.ID {
+		t.Fatalf("enumerating by provider returned %v, want only %v", rules, owned.ID)
+	}
+
+	rules, err = s.EnumerateRules(FilterEnumTypeOverlapping, LayerALEAuthConnectV4).
+		WithActionMask(ActionFlagIgnore).
+		WithFlags(FilterEnumFlagsSorted).
+		Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 2 || rules[0].ID != other.ID {
+		t.Fatalf("sorted enumeration returned %v, want %v first", rules, other.ID)
+	}
+}
+
+func TestMemoryEngineConditionValues(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(testLayers), nil)
+	defer s.Close()
+
+	// Values are stored as the filtering engine stores them, and
+	// come back as the types that a real engine returns.
+	r := &Rule{
+		ID:    RuleID{Data1: 1},
+		Layer: LayerALEAuthConnectV4,
+		Conditions: []*Match{
+			{FieldIPProtocol, MatchTypeEqual, IPProtoTCP},
+			{FieldIPProtocol, MatchTypeRange, Range{IPProtoTCP, uint8(17)}},
+			{FieldFlags, MatchTypeFlagsAllSet, ConditionFlagIsLoopback},
+			{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParseAddr("::ffff:10.0.0.1")},
+			{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParsePrefix("10.1.2.3/8")},
+		},
+		Action: ActionBlock,
+	}
+	if err := s.AddRule(r); err != nil {
+		t.Fatalf("add rule failed: %v", err)
+	}
+	rules, err := s.Rules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Match{
+		{FieldIPProtocol, MatchTypeEqual, uint8(6)},
+		{FieldIPProtocol, MatchTypeRange, Range{uint8(6), uint8(17)}},
+		{FieldFlags, MatchTypeFlagsAllSet, uint32(1)},
+		{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParseAddr("10.0.0.1")},
+		{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParsePrefix("10.0.0.0/8")},
+	}
+	addrs := cmp.Comparer(func(a, b netip.Addr) bool { return a == b })
+	prefixes := cmp.Comparer(func(a, b netip.Prefix) bool { return a == b })
+	if diff := cmp.Diff(rules[0].Conditions, want, addrs, prefixes); diff != "" {
+		t.Fatalf("stored conditions are wrong (-got+want):\n%s", diff)
+	}
+
+	// Values that the engine can't store in the field are rejected.
+	for _, m := range []*Match{
+		{FieldIPProtocol, MatchTypeEqual, uint16(6)},
+		{FieldIPRemotePort, MatchTypeEqual, 443},
+		{FieldIPRemoteAddress, MatchTypeEqual, "10.0.0.1"},
+		{FieldIPRemoteAddress, MatchTypeRange, Range{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2")}},
+		{FieldIPRemotePort, MatchTypeRange, Range{uint16(1), uint8(2)}},
+		{FieldIPRemotePort, MatchTypeRange, Range{Range{uint16(1), uint16(2)}, uint16(3)}},
+	} {
+		bad := &Rule{ID: RuleID{Data1: 2}, Layer: LayerALEAuthConnectV4, Conditions: []*Match{m}, Action: ActionBlock}
+		if err := s.AddRule(bad); err == nil {
+			t.Errorf("adding rule with condition %v succeeded", m)
+		}
+	}
+}
+
+func TestMemoryEngineTransactions(t *testing.T) {
+	m := NewMemoryEngine(testLayers)
+	a := newMemorySession(t, m, &Options{TransactionStartTimeout: time.Millisecond})
+	defer a.Close()
+	b := newMemorySession(t, m, &Options{TransactionStartTimeout: time.Millisecond})
+	defer b.Close()
+
+	p := &Provider{ID: ProviderID{Data1: 1}}
+
+	a.BeginTransaction(TransactionReadWrite)
+	if err := a.TransactionStatus().Err; err != nil {
+		t.Fatalf("begin transaction failed: %v", err)
+	}
+	if err := a.AddProvider(p); err != nil {
+		t.Fatalf("add provider failed: %v", err)
+	}
+	if got, _ := b.Providers(); len(got) != 0 {
+		t.Fatalf("uncommitted provider visible to other session: %v", got)
+	}
+	if err := b.AddProvider(&Provider{ID: ProviderID{Data1: 2}}); err != syscall.Errno(Timeout) {
+		t.Fatalf("write during other session's transaction: got err %v, want Timeout", err)
+	}
+	a.AbortTransaction()
+	if got, _ := a.Providers(); len(got) != 0 {
+		t.Fatalf("aborted provider still present: %v", got)
+	}
+
+	a.BeginTransaction(TransactionReadWrite)
+	if err := a.AddProvider(p); err != nil {
+		t.Fatalf("add provider failed: %v", err)
+	}
+	a.CommitTransaction()
+	if err := a.TransactionStatus().Err; err != nil {
+		t.Fatalf("commit transaction failed: %v", err)
+	}
+	if got, _ := b.Providers(); len(got) != 1 {
+		t.Fatalf("committed provider not visible to other session: %v", got)
+	}
+
+	b.BeginTransaction(TransactionReadOnly)
+	if err := b.DeleteProvider(p.ID); err != syscall.Errno(IncompatibleTransaction) {
+		t.Fatalf("write in read-only transaction: got err %v, want IncompatibleTransaction", err)
+	}
+	b.CommitTransaction()
+	if err := b.TransactionStatus().Err; err != nil {
+		t.Fatalf("commit transaction failed: %v", err)
+	}
+}
+
+func TestMemoryEngineDynamic(t *testing.T) {
+	m := NewMemoryEngine(testLayers)
+	static := newMemorySession(t, m, nil)
+	defer static.Close()
+
+	dyn := newMemorySession(t, m, &Options{Dynamic: true})
+	if err := dyn.AddProvider(&Provider{ID: ProviderID{Data1: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := static.AddProvider(&Provider{ID: ProviderID{Data1: 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dyn.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dyn.AddProvider(&Provider{ID: ProviderID{Data1: 3}}); err != syscall.Errno(NilPointer) {
+		t.Fatalf("add provider on closed session: got err %v, want NilPointer", err)
+	}
+
+	got, err := static.Providers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != (ProviderID{Data1: 2}) {
+		t.Fatalf("providers after dynamic session closed: %v, want only the static one", got)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// An idKind is the kind of object that a well-known GUID identifies.
+type idKind int
+
+const (
+	kindLayer idKind = iota
+	kindField
+	kindSublayer
+	kindProvider
+	kindProviderContext
+	kindCallout
+	kindKeyingModule
+)
+
+// idKindPrefixes are the prefixes that guidNames keeps on the names
+// of kinds other than layers and fields. Longer prefixes come first,
+// so that provider contexts aren't mistaken for providers.
+var idKindPrefixes = []struct {
+	prefix string
+	kind   idKind
+}{
+	{"SUBLAYER_", kindSublayer},
+	{"PROVIDER_CONTEXT_", kindProviderContext},
+	{"PROVIDER_", kindProvider},
+	{"CALLOUT_", kindCallout},
+	{"KEYING_MODULE_", kindKeyingModule},
+}
+
+// guidKinds maps each GUID in guidNames to its kind. Layer and field
+// names have no prefix, so layers are told apart from fields by
+// being in the default schema.
+var guidKinds = func() map[GUID]idKind {
+	ret := make(map[GUID]idKind, len(guidNames))
+	for guid, name := range guidNames {
+		ret[guid] = kindField
+		if defaultSchema.Layer(LayerID(guid)) != nil {
+			ret[guid] = kindLayer
+			continue
+		}
+		for _, p := range idKindPrefixes {
+			if strings.HasPrefix(name, p.prefix) {
+				ret[guid] = p.kind
+				break
+			}
+		}
+	}
+	return ret
+}()
+
+// sdkName returns the name of the FWPM_* constant for the GUID whose
+// guidNames entry is name.
+func sdkName(name string, kind idKind) string {
+	switch kind {
+	case kindLayer:
+		return "FWPM_LAYER_" + name
+	case kindField:
+		return "FWPM_CONDITION_" + name
+	}
+	return "FWPM_" + name
+}
+
+// guidsBySDKName maps the FWPM_* constant names of the SDK to their
+// GUIDs.
+var guidsBySDKName = func() map[string]GUID {
+	ret := make(map[string]GUID, len(guidNames))
+	for guid, name := range guidNames {
+		ret[sdkName(name, guidKinds[guid])] = guid
+	}
+	return ret
+}()
+
+// parseID parses s as the short name, the FWPM_* constant name or the
+// GUID string of an object of the given kind. what describes the kind
+// for error messages.
+func parseID(s string, kind idKind, what string) (GUID, error) {
+	guid, ok := guidsByName[s]
+	if !ok {
+		guid, ok = guidsBySDKName[s]
+	}
+	if ok {
+		if guidKinds[guid] != kind {
+			return GUID{}, fmt.Errorf("%q is not a %s", s, what)
+		}
+		return guid, nil
+	}
+	guid, err := parseGUID(s)
+	if err != nil {
+		return GUID{}, fmt.Errorf("unknown %s %q", what, s)
+	}
+	return guid, nil
+}
+
+// ParseLayerID parses s as a layer name such as
+// "ALE_AUTH_CONNECT_V4" or "FWPM_LAYER_ALE_AUTH_CONNECT_V4", or as a
+// GUID string.
+func ParseLayerID(s string) (LayerID, error) {
+	guid, err := parseID(s, kindLayer, "layer")
+	return LayerID(guid), err
+}
+
+// ParseFieldID parses s as a field name such as "IP_REMOTE_PORT" or
+// "FWPM_CONDITION_IP_REMOTE_PORT", or as a GUID string.
+func ParseFieldID(s string) (FieldID, error) {
+	guid, err := parseID(s, kindField, "field")
+	return FieldID(guid), err
+}
+
+// ParseSublayerID parses s as a sublayer name such as
+// "SUBLAYER_UNIVERSAL" or "FWPM_SUBLAYER_UNIVERSAL", or as a GUID
+// string.
+func ParseSublayerID(s string) (SublayerID, error) {
+	guid, err := parseID(s, kindSublayer, "sublayer")
+	return SublayerID(guid), err
+}
+
+// ParseProviderID parses s as a provider name such as
+// "PROVIDER_IKEEXT" or "FWPM_PROVIDER_IKEEXT", or as a GUID string.
+func ParseProviderID(s string) (ProviderID, error) {
+	guid, err := parseID(s, kindProvider, "provider")
+	return ProviderID(guid), err
+}
+
+// ParseProviderContextID parses s as a provider context name such as
+// "PROVIDER_CONTEXT_SECURE_SOCKET_AUTHIP" or
+// "FWPM_PROVIDER_CONTEXT_SECURE_SOCKET_AUTHIP", or as a GUID string.
+func ParseProviderContextID(s string) (ProviderContextID, error) {
+	guid, err := parseID(s, kindProviderContext, "provider context")
+	return ProviderContextID(guid), err
+}
+
+// ParseCalloutID parses s as a callout name such as
+// "CALLOUT_WFP_TRANSPORT_LAYER_V4_SILENT_DROP" or
+// "FWPM_CALLOUT_WFP_TRANSPORT_LAYER_V4_SILENT_DROP", or as a GUID
+// string.
+func ParseCalloutID(s string) (CalloutID, error) {
+	guid, err := parseID(s, kindCallout, "callout")
+	return CalloutID(guid), err
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseIDs(t *testing.T) {
+	custom := GUID{0x12345678, 0x9abc, 0xdef0, [8]byte{1, 2, 3, 4, 5, 6, 7, 8}}
+	customStr := "{12345678-9ABC-DEF0-0102-030405060708}"
+
+	tests := []struct {
+		parse func(string) (GUID, error)
+		in    string
+		want  GUID
+	}{
+		{parseLayer, "ALE_AUTH_CONNECT_V4", GUID(LayerALEAuthConnectV4)},
+		{parseLayer, "FWPM_LAYER_ALE_AUTH_CONNECT_V4", GUID(LayerALEAuthConnectV4)},
+		{parseLayer, LayerALEAuthConnectV4.String(), GUID(LayerALEAuthConnectV4)},
+		{parseLayer, customStr, custom},
+		{parseField, "IP_REMOTE_PORT", GUID(FieldIPRemotePort)},
+		{parseField, "FWPM_CONDITION_IP_REMOTE_PORT", GUID(FieldIPRemotePort)},
+		{parseField, "12345678-9abc-def0-0102-030405060708", custom},
+		{parseSublayer, "SUBLAYER_UNIVERSAL", GUID(guidSublayerUniversal)},
+		{parseSublayer, "FWPM_SUBLAYER_UNIVERSAL", GUID(guidSublayerUniversal)},
+		{parseProvider, "PROVIDER_IKEEXT", guidProviderIKEExt},
+		{parseProvider, "FWPM_PROVIDER_IKEEXT", guidProviderIKEExt},
+		{parseProvider, customStr, custom},
+		{parseCallout, "CALLOUT_WFP_TRANSPORT_LAYER_V4_SILENT_DROP", guidCalloutWFPTransportLayerV4SilentDrop},
+		{parseCallout, "FWPM_CALLOUT_WFP_TRANSPORT_LAYER_V4_SILENT_DROP", guidCalloutWFPTransportLayerV4SilentDrop},
+	}
+	for _, test := range tests {
+		got, err := test.parse(test.in)
+		if err != nil {
+			t.Errorf("parsing %q: %v", test.in, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("parsing %q = %s, want %s", test.in, got, test.want)
+		}
+	}
+}
+
+func TestParseIDErrors(t *testing.T) {
+	tests := []struct {
+		parse func(string) (GUID, error)
+		in    string
+	}{
+		{parseLayer, "IP_REMOTE_PORT"},
+		{parseLayer, "FWPM_CONDITION_ALE_AUTH_CONNECT_V4"},
+		{parseLayer, "NO_SUCH_LAYER"},
+		{parseLayer, ""},
+		{parseField, "ALE_AUTH_CONNECT_V4"},
+		{parseSublayer, "PROVIDER_IKEEXT"},
+		{parseProvider, "PROVIDER_CONTEXT_SECURE_SOCKET_AUTHIP"},
+		{parseProvider, "{12345678-9ABC-DEF0-0102-0304050607}"},
+		{parseCallout, "SUBLAYER_UNIVERSAL"},
+	}
+	for _, test := range tests {
+		if got, err := test.parse(test.in); err == nil {
+			t.Errorf("parsing %q = %s, want error", test.in, got)
+		}
+	}
+}
+
+func TestSDKNames(t *testing.T) {
+	for guid, name := range guidNames {
+		sdk := sdkName(name, guidKinds[guid])
+		if !strings.HasPrefix(sdk, "FWPM_") {
+			t.Errorf("SDK name of %s is %q", name, sdk)
+		}
+		got, err := parseNamedGUID(sdk)
+		if err != nil {
+			t.Errorf("parseNamedGUID(%q): %v", sdk, err)
+			continue
+		}
+		if got != guid {
+			t.Errorf("parseNamedGUID(%q) = %s, want %s", sdk, got, guid)
+		}
+	}
+}
+
+func parseLayer(s string) (GUID, error) {
+	id, err := ParseLayerID(s)
+	return GUID(id), err
+}
+
+func parseField(s string) (GUID, error) {
+	id, err := ParseFieldID(s)
+	return GUID(id), err
+}
+
+func parseSublayer(s string) (GUID, error) {
+	id, err := ParseSublayerID(s)
+	return GUID(id), err
+}
+
+func parseProvider(s string) (GUID, error) {
+	id, err := ParseProviderID(s)
+	return GUID(id), err
+}
+
+func parseCallout(s string) (GUID, error) {
+	id, err := ParseCalloutID(s)
+	return GUID(id), err
+}
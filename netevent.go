@@ -0,0 +1,594 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"net/netip"
+	"time"
+
+	"go4.org/netipx"
+)
+
+// NetEventType is the type of a NetEvent.
+type NetEventType uint32
+
+const (
+	NetEventTypeIKEMainModeFailure     NetEventType = 0
+	NetEventTypeIKEQuickModeFailure    NetEventType = 1
+	NetEventTypeIKEExtendedModeFailure NetEventType = 2
+	NetEventTypeClassifyDrop           NetEventType = 3
+	NetEventTypeIPsecKernelDrop        NetEventType = 4
+	NetEventTypeIPsecDoSPDrop          NetEventType = 5
+	NetEventTypeClassifyAllow          NetEventType = 6
+	NetEventTypeCapabilityDrop         NetEventType = 7
+	NetEventTypeCapabilityAllow        NetEventType = 8
+)
+
+// NetEventFlag says which of a NetEvent's header fields the engine
+// set.
+type NetEventFlag uint32
+
+const (
+	NetEventFlagIPProtocolSet    NetEventFlag = 0x00000001
+	NetEventFlagLocalAddrSet     NetEventFlag = 0x00000002
+	NetEventFlagRemoteAddrSet    NetEventFlag = 0x00000004
+	NetEventFlagLocalPortSet     NetEventFlag = 0x00000008
+	NetEventFlagRemotePortSet    NetEventFlag = 0x00000010
+	NetEventFlagAppIDSet         NetEventFlag = 0x00000020
+	NetEventFlagUserIDSet        NetEventFlag = 0x00000040
+	NetEventFlagScopeIDSet       NetEventFlag = 0x00000080
+	NetEventFlagIPVersionSet     NetEventFlag = 0x00000100
+	NetEventFlagReauthReasonSet  NetEventFlag = 0x00000200
+	NetEventFlagPackageIDSet     NetEventFlag = 0x00000400
+	NetEventFlagEnterpriseIDSet  NetEventFlag = 0x00000800
+	NetEventFlagPolicyFlagsSet   NetEventFlag = 0x00001000
+	NetEventFlagEffectiveNameSet NetEventFlag = 0x00002000
+)
+
+// Direction is the direction of the traffic that caused a NetEvent.
+type Direction uint8
+
+const (
+	DirectionUnknown Direction = iota
+	DirectionInbound
+	DirectionOutbound
+)
+
+func (d Direction) String() string {
+	switch d {
+	case DirectionInbound:
+		return "Inbound"
+	case DirectionOutbound:
+		return "Outbound"
+	}
+	return "Unknown"
+}
+
+// KeyingModule is an IPsec keying module.
+type KeyingModule uint32
+
+const (
+	KeyingModuleIKE    KeyingModule = 0
+	KeyingModuleAuthIP KeyingModule = 1
+	KeyingModuleIKEv2  KeyingModule = 2
+)
+
+// NetworkCapability is an app container network capability.
+type NetworkCapability uint32
+
+const (
+	NetworkCapabilityInternetClient             NetworkCapability = 0
+	NetworkCapabilityInternetClientServer       NetworkCapability = 1
+	NetworkCapabilityPrivateNetworkClientServer NetworkCapability = 2
+)
+
+// A NetEvent is an event recorded by the filtering engine, such as a
+// packet being dropped by a rule.
+//
+// The header fields describe the traffic that caused the event, as
+// far as Flags says they are known. Exactly one of the detail fields
+// is set, according to Type, unless Type is one that this package
+// doesn't decode.
+type NetEvent struct {
+	Type       NetEventType
+	Timestamp  time.Time
+	Flags      NetEventFlag
+	IPProtocol uint8
+	LocalAddr  netip.AddrPort
+	RemoteAddr netip.AddrPort
+	// ScopeID is the IPv6 scope ID of LocalAddr.
+	ScopeID uint32
+	AppID   string
+	// UserID is the SID of the user that generated the traffic,
+	// in string form.
+	UserID string
+	// PackageID is the SID of the app container that generated
+	// the traffic, in string form. It is only recorded by Windows
+	// 8 and later.
+	PackageID string
+
+	// Classify is set for NetEventTypeClassifyDrop and
+	// NetEventTypeClassifyAllow.
+	Classify *ClassifyEvent
+	// Capability is set for NetEventTypeCapabilityDrop and
+	// NetEventTypeCapabilityAllow.
+	Capability *CapabilityEvent
+	// IKEFailure is set for the NetEventTypeIKE*Failure types.
+	IKEFailure *IKEFailureEvent
+	// IPsecDrop is set for NetEventTypeIPsecKernelDrop.
+	IPsecDrop *IPsecDropEvent
+	// DoSPDrop is set for NetEventTypeIPsecDoSPDrop.
+	DoSPDrop *DoSPDropEvent
+}
+
+// A ClassifyEvent is the outcome of classifying traffic at a layer:
+// the rule that permitted or dropped it.
+type ClassifyEvent struct {
+	FilterID        uint64
+	LayerID         uint16
+	ReauthReason    uint32
+	OriginalProfile uint32
+	CurrentProfile  uint32
+	Direction       Direction
+	Loopback        bool
+
+	// The Hyper-V virtual switch fields are only set for drops
+	// on Windows 8 and later.
+	VSwitchID              string
+	VSwitchSourcePort      uint32
+	VSwitchDestinationPort uint32
+}
+
+// A CapabilityEvent is traffic that an app container's network
+// capabilities permitted or dropped.
+type CapabilityEvent struct {
+	Capability NetworkCapability
+	FilterID   uint64
+	Loopback   bool
+}
+
+// An IKEFailureEvent is a failed IKE or AuthIP negotiation. Fields
+// that don't apply to the failed mode are zero: main mode failures
+// have no subnets, and quick mode failures have no authentication
+// details.
+type IKEFailureEvent struct {
+	// ErrorCode is the Windows error code of the failure.
+	ErrorCode uint32
+	// FailurePoint is the IPSEC_FAILURE_POINT at which the
+	// negotiation failed.
+	FailurePoint uint32
+	// Flags is the IKEEXT_*_FAILURE_FLAG_* bits of the failure.
+	Flags        uint32
+	KeyingModule KeyingModule
+	// State is the IKEEXT_MM_SA_STATE, IKEEXT_QM_SA_STATE or
+	// IKEEXT_EM_SA_STATE of the negotiation, depending on the
+	// mode.
+	State uint32
+	// Role is the IKEEXT_SA_ROLE of the local host.
+	Role uint32
+	// AuthMethod is the IKEEXT_AUTHENTICATION_METHOD_TYPE that
+	// failed.
+	AuthMethod uint32
+	// EndCertHash is the SHA-1 hash of the end certificate.
+	EndCertHash [20]byte
+	// MainModeID is the ID of the main mode SA.
+	MainModeID uint64
+	// FilterID is the ID of the main mode or quick mode filter.
+	FilterID        uint64
+	LocalPrincipal  string
+	RemotePrincipal string
+	// LocalGroupSIDs and RemoteGroupSIDs are the group SIDs of
+	// the principals, in string form.
+	LocalGroupSIDs  []string
+	RemoteGroupSIDs []string
+	// TrafficType is the IKEEXT_TRAFFIC_TYPE or
+	// IPSEC_TRAFFIC_TYPE of the SA.
+	TrafficType  uint32
+	LocalSubnet  netipx.IPRange
+	RemoteSubnet netipx.IPRange
+}
+
+// An IPsecDropEvent is a packet dropped by the IPsec kernel driver.
+type IPsecDropEvent struct {
+	// FailureStatus is the NTSTATUS code of the failure.
+	FailureStatus uint32
+	Direction     Direction
+	SPI           uint32
+	FilterID      uint64
+	LayerID       uint16
+}
+
+// A DoSPDropEvent is a packet dropped by IPsec denial of service
+// protection.
+type DoSPDropEvent struct {
+	PublicHostAddr   netip.Addr
+	InternalHostAddr netip.Addr
+	// FailureStatus is the NTSTATUS code of the failure.
+	FailureStatus uint32
+	Direction     Direction
+}
+
+// DropEvent returns e as a DropEvent, or nil if e is not a classify
+// drop.
+func (e *NetEvent) DropEvent() *DropEvent {
+	if e.Type != NetEventTypeClassifyDrop || e.Classify == nil {
+		return nil
+	}
+	return &DropEvent{
+		Timestamp:  e.Timestamp,
+		IPProtocol: e.IPProtocol,
+		LocalAddr:  e.LocalAddr,
+		RemoteAddr: e.RemoteAddr,
+		AppID:      e.AppID,
+		LayerID:    e.Classify.LayerID,
+		FilterID:   e.Classify.FilterID,
+	}
+}
+
+// netEventFromDrop returns the classify drop NetEvent for e.
+func netEventFromDrop(e *DropEvent) *NetEvent {
+	return &NetEvent{
+		Type:       NetEventTypeClassifyDrop,
+		Timestamp:  e.Timestamp,
+		IPProtocol: e.IPProtocol,
+		LocalAddr:  e.LocalAddr,
+		RemoteAddr: e.RemoteAddr,
+		AppID:      e.AppID,
+		Classify: &ClassifyEvent{
+			FilterID: e.FilterID,
+			LayerID:  e.LayerID,
+		},
+	}
+}
+
+// cloneNetEvent returns a copy of e that shares no memory with it.
+func cloneNetEvent(e *NetEvent) *NetEvent {
+	ret := *e
+	if e.Classify != nil {
+		c := *e.Classify
+		ret.Classify = &c
+	}
+	if e.Capability != nil {
+		c := *e.Capability
+		ret.Capability = &c
+	}
+	if e.IKEFailure != nil {
+		f := *e.IKEFailure
+		f.LocalGroupSIDs = append([]string(nil), f.LocalGroupSIDs...)
+		f.RemoteGroupSIDs = append([]string(nil), f.RemoteGroupSIDs...)
+		ret.IKEFailure = &f
+	}
+	if e.IPsecDrop != nil {
+		d := *e.IPsecDrop
+		ret.IPsecDrop = &d
+	}
+	if e.DoSPDrop != nil {
+		d := *e.DoSPDrop
+		ret.DoSPDrop = &d
+	}
+	return &ret
+}
+
+// NetEvents returns the events recorded by the filtering engine.
+func (s *Session) NetEvents() ([]*NetEvent, error) {
+	return s.engine.NetEvents(nil)
+}
+
+// dropEvents returns the classify drops in events as DropEvents.
+func dropEvents(events []*NetEvent) []*DropEvent {
+	var ret []*DropEvent
+	for _, e := range events {
+		if d := e.DropEvent(); d != nil {
+			ret = append(ret, d)
+		}
+	}
+	return ret
+}
+
+// The layout versions of FWPM_NET_EVENT that decodeNetEvent
+// understands. Version 1 is returned by FwpmNetEventEnum1, and
+// version 2 by FwpmNetEventEnum2 and later, on Windows 8 and later.
+const (
+	netEventVersion1 = 1
+	netEventVersion2 = 2
+)
+
+// decodeNetEvents decodes the array of num pointers to FWPM_NET_EVENT
+// structs of the given layout version at addr.
+func decodeNetEvents(mem memReader, addr uint64, num uint32, version int) ([]*NetEvent, error) {
+	arr := &cStruct{mem: mem, addr: addr}
+	var ret []*NetEvent
+	for i := uint32(0); i < num; i++ {
+		p := arr.ptr()
+		if arr.err != nil {
+			return nil, arr.err
+		}
+		e, err := decodeNetEvent(mem, p, version)
+		if err != nil {
+			return nil, fmt.Errorf("decoding net event %d: %w", i, err)
+		}
+		ret = append(ret, e)
+	}
+	return ret, nil
+}
+
+// decodeNetEvent decodes the FWPM_NET_EVENT struct of the given
+// layout version at addr.
+func decodeNetEvent(mem memReader, addr uint64, version int) (*NetEvent, error) {
+	c := &cStruct{mem: mem, addr: addr}
+	e := &NetEvent{}
+
+	// FWPM_NET_EVENT_HEADER1 or FWPM_NET_EVENT_HEADER2.
+	e.Timestamp = fileTime(c.u32(), c.u32())
+	e.Flags = NetEventFlag(c.u32())
+	ipVersion := fwpIPVersion(c.u32())
+	e.IPProtocol = c.u8()
+	localAddr := c.bytes(16, 4)
+	remoteAddr := c.bytes(16, 4)
+	localPort := c.u16()
+	remotePort := c.u16()
+	e.ScopeID = c.u32()
+	e.AppID = c.blobString()
+	e.UserID = c.sid()
+	switch version {
+	case netEventVersion1:
+		// An aborted attempt at including Ethernet frame
+		// information: a UINT32 and a 48-byte struct with
+		// 64-bit alignment.
+		c.u32()
+		c.field(48, 8)
+		c.align(8)
+	case netEventVersion2:
+		c.u32() // FWP_AF addressFamily, implied by the addresses.
+		e.PackageID = c.sid()
+		c.align(c.mem.ptrSize())
+	default:
+		return nil, fmt.Errorf("unknown net event version %d", version)
+	}
+
+	var local, remote netip.Addr
+	if e.Flags&NetEventFlagLocalAddrSet != 0 {
+		local = eventAddr(ipVersion, localAddr)
+	}
+	if e.Flags&NetEventFlagRemoteAddrSet != 0 {
+		remote = eventAddr(ipVersion, remoteAddr)
+	}
+	e.LocalAddr = netip.AddrPortFrom(local, localPort)
+	e.RemoteAddr = netip.AddrPortFrom(remote, remotePort)
+
+	e.Type = NetEventType(c.u32())
+	p := c.ptr()
+	if c.err != nil {
+		return nil, c.err
+	}
+	if p == 0 {
+		return e, nil
+	}
+
+	d := &cStruct{mem: mem, addr: p}
+	switch e.Type {
+	case NetEventTypeIKEMainModeFailure:
+		e.IKEFailure = decodeIKEFailure(d, false)
+	case NetEventTypeIKEQuickModeFailure:
+		// FWPM_NET_EVENT_IKEEXT_QM_FAILURE0.
+		f := &IKEFailureEvent{
+			ErrorCode:    d.u32(),
+			FailurePoint: d.u32(),
+			KeyingModule: KeyingModule(d.u32()),
+			State:        d.u32(),
+			Role:         d.u32(),
+			TrafficType:  d.u32(),
+		}
+		f.LocalSubnet = d.subnet()
+		f.RemoteSubnet = d.subnet()
+		f.FilterID = d.u64()
+		e.IKEFailure = f
+	case NetEventTypeIKEExtendedModeFailure:
+		e.IKEFailure = decodeIKEFailure(d, true)
+	case NetEventTypeClassifyDrop, NetEventTypeClassifyAllow:
+		// FWPM_NET_EVENT_CLASSIFY_DROP1 and
+		// FWPM_NET_EVENT_CLASSIFY_ALLOW0 share their fields with
+		// the start of FWPM_NET_EVENT_CLASSIFY_DROP2.
+		cl := &ClassifyEvent{
+			FilterID:        d.u64(),
+			LayerID:         d.u16(),
+			ReauthReason:    d.u32(),
+			OriginalProfile: d.u32(),
+			CurrentProfile:  d.u32(),
+			Direction:       msFwpDirection(d.u32()),
+			Loopback:        d.u32() != 0,
+		}
+		if e.Type == NetEventTypeClassifyDrop && version >= netEventVersion2 {
+			cl.VSwitchID = d.blobString()
+			cl.VSwitchSourcePort = d.u32()
+			cl.VSwitchDestinationPort = d.u32()
+		}
+		e.Classify = cl
+	case NetEventTypeIPsecKernelDrop:
+		// FWPM_NET_EVENT_IPSEC_KERNEL_DROP0.
+		e.IPsecDrop = &IPsecDropEvent{
+			FailureStatus: d.u32(),
+			Direction:     fwpDirection(d.u32()),
+			SPI:           d.u32(),
+			FilterID:      d.u64(),
+			LayerID:       d.u16(),
+		}
+	case NetEventTypeIPsecDoSPDrop:
+		// FWPM_NET_EVENT_IPSEC_DOSP_DROP0.
+		ipVersion := fwpIPVersion(d.u32())
+		public := d.bytes(16, 4)
+		internal := d.bytes(16, 4)
+		e.DoSPDrop = &DoSPDropEvent{
+			PublicHostAddr:   eventAddr(ipVersion, public),
+			InternalHostAddr: eventAddr(ipVersion, internal),
+			FailureStatus:    d.u32(),
+			Direction:        fwpDirection(d.u32()),
+		}
+	case NetEventTypeCapabilityDrop, NetEventTypeCapabilityAllow:
+		// FWPM_NET_EVENT_CAPABILITY_DROP0 and
+		// FWPM_NET_EVENT_CAPABILITY_ALLOW0.
+		e.Capability = &CapabilityEvent{
+			Capability: NetworkCapability(d.u32()),
+			FilterID:   d.u64(),
+			Loopback:   d.u32() != 0,
+		}
+	}
+	if d.err != nil {
+		return nil, fmt.Errorf("decoding %s details: %w", e.Type, d.err)
+	}
+
+	return e, nil
+}
+
+// decodeIKEFailure decodes FWPM_NET_EVENT_IKEEXT_MM_FAILURE1, or
+// FWPM_NET_EVENT_IKEEXT_EM_FAILURE1 if em is true. The two differ
+// only in that extended mode is always AuthIP, so has no keying
+// module field.
+func decodeIKEFailure(c *cStruct, em bool) *IKEFailureEvent {
+	f := &IKEFailureEvent{
+		ErrorCode:    c.u32(),
+		FailurePoint: c.u32(),
+		Flags:        c.u32(),
+		KeyingModule: KeyingModuleAuthIP,
+	}
+	if !em {
+		f.KeyingModule = KeyingModule(c.u32())
+	}
+	f.State = c.u32()
+	f.Role = c.u32()
+	f.AuthMethod = c.u32()
+	copy(f.EndCertHash[:], c.field(20, 1))
+	f.MainModeID = c.u64()
+	f.FilterID = c.u64()
+	f.LocalPrincipal = c.str()
+	f.RemotePrincipal = c.str()
+	f.LocalGroupSIDs = c.strs(c.u32())
+	f.RemoteGroupSIDs = c.strs(c.u32())
+	f.TrafficType = c.u32()
+	return f
+}
+
+// fileTime converts the halves of a FILETIME to a time.Time.
+func fileTime(lo, hi uint32) time.Time {
+	// 100-nanosecond intervals since January 1, 1601.
+	ft := int64(hi)<<32 | int64(lo)
+	// Change the starting time to the Unix epoch of January 1, 1970.
+	ft -= 116444736000000000
+	return time.Unix(0, ft*100)
+}
+
+// eventAddr decodes the address union of a net event, whose
+// FWP_IP_VERSION is ipVersion.
+func eventAddr(ipVersion fwpIPVersion, b []byte) netip.Addr {
+	switch ipVersion {
+	case fwpIPVersion4:
+		return ipv4From32(binary.LittleEndian.Uint32(b))
+	case fwpIPVersion6:
+		var a [16]byte
+		copy(a[:], b)
+		return netip.AddrFrom16(a).Unmap()
+	}
+	return netip.Addr{}
+}
+
+// msFwpDirection converts the MS_FWP_DIRECTION of a classify event to
+// a Direction.
+func msFwpDirection(d uint32) Direction {
+	switch d {
+	case 0x3900: // MS_FWP_DIRECTION_IN
+		return DirectionInbound
+	case 0x3901: // MS_FWP_DIRECTION_OUT
+		return DirectionOutbound
+	}
+	return fwpDirection(d)
+}
+
+// fwpDirection converts an FWP_DIRECTION to a Direction.
+func fwpDirection(d uint32) Direction {
+	switch d {
+	case 0: // FWP_DIRECTION_OUTBOUND
+		return DirectionOutbound
+	case 1: // FWP_DIRECTION_INBOUND
+		return DirectionInbound
+	}
+	return DirectionUnknown
+}
+
+// subnet decodes the next FWP_CONDITION_VALUE0 field, which holds an
+// address, an address and mask, or a range of addresses, as an
+// IPRange.
+func (c *cStruct) subnet() netipx.IPRange {
+	typ, v := c.value()
+	if c.err != nil {
+		return netipx.IPRange{}
+	}
+	switch typ {
+	case dataTypeEmpty:
+		return netipx.IPRange{}
+	case dataTypeV4AddrMask:
+		// FWP_V4_ADDR_AND_MASK.
+		s := &cStruct{mem: c.mem, addr: v}
+		addr, mask := s.u32(), s.u32()
+		c.err = s.err
+		return netipx.RangeOfPrefix(netip.PrefixFrom(ipv4From32(addr), bits.OnesCount32(mask)).Masked())
+	case dataTypeV6AddrMask:
+		// FWP_V6_ADDR_AND_MASK.
+		s := &cStruct{mem: c.mem, addr: v}
+		addr := eventAddr(fwpIPVersion6, s.field(16, 1))
+		bits := int(s.u8())
+		c.err = s.err
+		return netipx.RangeOfPrefix(netip.PrefixFrom(addr, bits).Masked())
+	case dataTypeRange:
+		// FWP_RANGE0.
+		s := &cStruct{mem: c.mem, addr: v}
+		from := s.ipAddr(s.value())
+		to := s.ipAddr(s.value())
+		c.err = s.err
+		return netipx.IPRangeFrom(from, to)
+	}
+	a := c.ipAddr(typ, v)
+	return netipx.IPRangeFrom(a, a)
+}
+
+// value decodes the next FWP_VALUE0 or FWP_CONDITION_VALUE0 field. It
+// returns the value's type, and the contents of its union: a scalar
+// of up to 32 bits, or a pointer to anything larger.
+func (c *cStruct) value() (dataType, uint64) {
+	c.align(c.mem.ptrSize())
+	typ := dataType(c.u32())
+	// The union's low bytes hold scalars, since memory is little
+	// endian.
+	v := c.ptr()
+	if c.mem.ptrSize() == 8 && typ < dataTypeUint64 {
+		v &= 0xffffffff
+	}
+	return typ, v
+}
+
+// ipAddr returns the IP address in a value decoded by value.
+func (c *cStruct) ipAddr(typ dataType, v uint64) netip.Addr {
+	switch typ {
+	case dataTypeUint32:
+		return ipv4From32(uint32(v))
+	case dataTypeByteArray16:
+		if v == 0 {
+			c.err = errNilPointer
+			return netip.Addr{}
+		}
+		b, err := c.mem.read(v, 16)
+		if err != nil {
+			c.err = err
+			return netip.Addr{}
+		}
+		return eventAddr(fwpIPVersion6, b)
+	}
+	if c.err == nil {
+		c.err = fmt.Errorf("%d is not an address data type", typ)
+	}
+	return netip.Addr{}
+}
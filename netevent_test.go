@@ -0,0 +1,617 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/google/go-cmp/cmp"
+	"go4.org/netipx"
+)
+
+// fakeMemory is a memReader over byte fixtures, each of which is
+// mapped at its own address.
+type fakeMemory struct {
+	ptr  int
+	segs map[uint64][]byte
+	next uint64
+}
+
+func newFakeMemory(ptrSize int) *fakeMemory {
+	return &fakeMemory{
+		ptr:  ptrSize,
+		segs: map[uint64][]byte{},
+		next: 0x10000,
+	}
+}
+
+func (m *fakeMemory) read(addr uint64, n int) ([]byte, error) {
+	if addr == 0 {
+		return nil, errNilPointer
+	}
+	for base, b := range m.segs {
+		if addr >= base && addr+uint64(n) <= base+uint64(len(b)) {
+			return b[addr-base:][:n], nil
+		}
+	}
+	return nil, fmt.Errorf("read of %d bytes at %#x is out of bounds", n, addr)
+}
+
+func (m *fakeMemory) ptrSize() int { return m.ptr }
+
+// add maps b at a fresh address, and returns the address.
+func (m *fakeMemory) add(b []byte) uint64 {
+	addr := m.next
+	m.segs[addr] = b
+	m.next += 0x1000
+	return addr
+}
+
+// wstr maps s as a NUL-terminated UTF-16 string, and returns its
+// address.
+func (m *fakeMemory) wstr(s string) uint64 {
+	b := m.wstrBytes(s)
+	return m.add(b)
+}
+
+func (m *fakeMemory) wstrBytes(s string) []byte {
+	u := append(utf16.Encode([]rune(s)), 0)
+	b := make([]byte, 2*len(u))
+	for i, r := range u {
+		binary.LittleEndian.PutUint16(b[2*i:], r)
+	}
+	return b
+}
+
+// ptrs maps an array of the pointers ps, and returns its address.
+func (m *fakeMemory) ptrs(ps ...uint64) uint64 {
+	b := make([]byte, m.ptr*len(ps))
+	for i, p := range ps {
+		m.putPtr(b, m.ptr*i, p)
+	}
+	return m.add(b)
+}
+
+func (m *fakeMemory) putPtr(b []byte, off int, p uint64) {
+	if m.ptr == 4 {
+		put32(b, off, uint32(p))
+	} else {
+		put64(b, off, p)
+	}
+}
+
+func put16(b []byte, off int, v uint16) { binary.LittleEndian.PutUint16(b[off:], v) }
+func put32(b []byte, off int, v uint32) { binary.LittleEndian.PutUint32(b[off:], v) }
+func put64(b []byte, off int, v uint64) { binary.LittleEndian.PutUint64(b[off:], v) }
+
+var (
+	testEventTime = time.Date(2026, 1, 2, 3, 4, 5, 123456700, time.UTC)
+	testAppID     = `\device\harddiskvolume3\windows\system32\svchost.exe`
+	// S-1-5-18 and S-1-15-2-1, in binary form.
+	testUserSID    = []byte{1, 1, 0, 0, 0, 0, 0, 5, 18, 0, 0, 0}
+	testPackageSID = []byte{1, 2, 0, 0, 0, 0, 0, 15, 2, 0, 0, 0, 1, 0, 0, 0}
+)
+
+// testHeader returns the header fields that eventFixture writes, as a
+// NetEvent of type typ.
+func testHeader(typ NetEventType, v6, packageID bool) *NetEvent {
+	e := &NetEvent{
+		Type:       typ,
+		Timestamp:  testEventTime,
+		Flags:      0x1ff,
+		IPProtocol: 6,
+		LocalAddr:  netip.MustParseAddrPort("10.0.0.1:49152"),
+		RemoteAddr: netip.MustParseAddrPort("1.2.3.4:443"),
+		AppID:      testAppID,
+		UserID:     "S-1-5-18",
+	}
+	if v6 {
+		e.LocalAddr = netip.MustParseAddrPort("[fe80::1]:49152")
+		e.RemoteAddr = netip.MustParseAddrPort("[2001:db8::2]:443")
+		e.ScopeID = 7
+	}
+	if packageID {
+		e.Flags |= NetEventFlagPackageIDSet
+		e.PackageID = "S-1-15-2-1"
+	}
+	return e
+}
+
+// eventFixture maps an FWPM_NET_EVENT1 or FWPM_NET_EVENT2 of type typ
+// whose details are at detail, and whose header holds the fields of
+// testHeader. The field offsets are those of the SDK headers for the
+// pointer size of m.
+func eventFixture(m *fakeMemory, version int, typ NetEventType, detail uint64, v6 bool) uint64 {
+	var (
+		b                 []byte
+		appIDSize, appID  int
+		userID, typeField int
+		detailField       int
+	)
+	switch {
+	case m.ptr == 8 && version == netEventVersion2:
+		b = make([]byte, 120)
+		appIDSize, appID, userID = 64, 72, 80
+		typeField, detailField = 104, 112
+		put32(b, 88, 0) // addressFamily
+		m.putPtr(b, 96, m.add(testPackageSID))
+	case m.ptr == 8 && version == netEventVersion1:
+		// The reserved fields from 88 to 144 stay zero.
+		b = make([]byte, 160)
+		appIDSize, appID, userID = 64, 72, 80
+		typeField, detailField = 144, 152
+	case m.ptr == 4 && version == netEventVersion2:
+		b = make([]byte, 88)
+		appIDSize, appID, userID = 60, 64, 68
+		typeField, detailField = 80, 84
+		put32(b, 72, 0) // addressFamily
+		m.putPtr(b, 76, m.add(testPackageSID))
+	default:
+		panic("no fixture layout")
+	}
+
+	ft := uint64(testEventTime.UnixNano()/100) + 116444736000000000
+	put32(b, 0, uint32(ft))
+	put32(b, 4, uint32(ft>>32))
+	flags := uint32(0x1ff)
+	if version == netEventVersion2 {
+		flags |= uint32(NetEventFlagPackageIDSet)
+	}
+	put32(b, 8, flags)
+	b[16] = 6
+	if v6 {
+		put32(b, 12, 1)
+		copy(b[20:], netip.MustParseAddr("fe80::1").AsSlice())
+		copy(b[36:], netip.MustParseAddr("2001:db8::2").AsSlice())
+		put32(b, 56, 7)
+	} else {
+		put32(b, 12, 0)
+		put32(b, 20, 0x0a000001)
+		put32(b, 36, 0x01020304)
+	}
+	put16(b, 52, 49152)
+	put16(b, 54, 443)
+	appIDBytes := m.wstrBytes(testAppID)
+	put32(b, appIDSize, uint32(len(appIDBytes)))
+	m.putPtr(b, appID, m.add(appIDBytes))
+	m.putPtr(b, userID, m.add(testUserSID))
+	put32(b, typeField, uint32(typ))
+	m.putPtr(b, detailField, detail)
+	return m.add(b)
+}
+
+func TestDecodeNetEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		ptrSize int
+		version int
+		v6      bool
+		typ     NetEventType
+		// detail maps the event's details, and returns their
+		// address and the expected decoding.
+		detail func(m *fakeMemory, want *NetEvent) uint64
+	}{
+		{
+			name:    "classify drop",
+			ptrSize: 8,
+			version: netEventVersion2,
+			typ:     NetEventTypeClassifyDrop,
+			detail: func(m *fakeMemory, want *NetEvent) uint64 {
+				// FWPM_NET_EVENT_CLASSIFY_DROP2.
+				b := make([]byte, 56)
+				put64(b, 0, 0x1234)
+				put16(b, 8, 48)
+				put32(b, 12, 2)
+				put32(b, 16, 1)
+				put32(b, 20, 4)
+				put32(b, 24, 0x3901)
+				put32(b, 28, 1)
+				vswitch := m.wstrBytes("{switch}")
+				put32(b, 32, uint32(len(vswitch)))
+				m.putPtr(b, 40, m.add(vswitch))
+				put32(b, 48, 5)
+				put32(b, 52, 6)
+				want.Classify = &ClassifyEvent{
+					FilterID:               0x1234,
+					LayerID:                48,
+					ReauthReason:           2,
+					OriginalProfile:        1,
+					CurrentProfile:         4,
+					Direction:              DirectionOutbound,
+					Loopback:               true,
+					VSwitchID:              "{switch}",
+					VSwitchSourcePort:      5,
+					VSwitchDestinationPort: 6,
+				}
+				return m.add(b)
+			},
+		},
+		{
+			name:    "classify drop, FWPM_NET_EVENT1",
+			ptrSize: 8,
+			version: netEventVersion1,
+			typ:     NetEventTypeClassifyDrop,
+			detail: func(m *fakeMemory, want *NetEvent) uint64 {
+				// FWPM_NET_EVENT_CLASSIFY_DROP1.
+				b := make([]byte, 32)
+				put64(b, 0, 0x1234)
+				put16(b, 8, 48)
+				put32(b, 24, 0x3900)
+				want.Classify = &ClassifyEvent{
+					FilterID:  0x1234,
+					LayerID:   48,
+					Direction: DirectionInbound,
+				}
+				return m.add(b)
+			},
+		},
+		{
+			name:    "classify drop, 32-bit",
+			ptrSize: 4,
+			version: netEventVersion2,
+			typ:     NetEventTypeClassifyDrop,
+			detail: func(m *fakeMemory, want *NetEvent) uint64 {
+				// FWPM_NET_EVENT_CLASSIFY_DROP2, with 4-byte
+				// pointers.
+				b := make([]byte, 48)
+				put64(b, 0, 0x1234)
+				put16(b, 8, 48)
+				put32(b, 24, 0x3900)
+				vswitch := m.wstrBytes("{switch}")
+				put32(b, 32, uint32(len(vswitch)))
+				m.putPtr(b, 36, m.add(vswitch))
+				put32(b, 40, 5)
+				put32(b, 44, 6)
+				want.Classify = &ClassifyEvent{
+					FilterID:               0x1234,
+					LayerID:                48,
+					Direction:              DirectionInbound,
+					VSwitchID:              "{switch}",
+					VSwitchSourcePort:      5,
+					VSwitchDestinationPort: 6,
+				}
+				return m.add(b)
+			},
+		},
+		{
+			name:    "classify allow",
+			ptrSize: 8,
+			version: netEventVersion2,
+			v6:      true,
+			typ:     NetEventTypeClassifyAllow,
+			detail: func(m *fakeMemory, want *NetEvent) uint64 {
+				// FWPM_NET_EVENT_CLASSIFY_ALLOW0.
+				b := make([]byte, 32)
+				put64(b, 0, 99)
+				put16(b, 8, 44)
+				put32(b, 24, 0x3900)
+				want.Classify = &ClassifyEvent{
+					FilterID:  99,
+					LayerID:   44,
+					Direction: DirectionInbound,
+				}
+				return m.add(b)
+			},
+		},
+		{
+			name:    "capability drop",
+			ptrSize: 8,
+			version: netEventVersion2,
+			typ:     NetEventTypeCapabilityDrop,
+			detail: func(m *fakeMemory, want *NetEvent) uint64 {
+				// FWPM_NET_EVENT_CAPABILITY_DROP0.
+				b := make([]byte, 24)
+				put32(b, 0, 2)
+				put64(b, 8, 77)
+				put32(b, 16, 1)
+				want.Capability = &CapabilityEvent{
+					Capability: NetworkCapabilityPrivateNetworkClientServer,
+					FilterID:   77,
+					Loopback:   true,
+				}
+				return m.add(b)
+			},
+		},
+		{
+			name:    "capability allow",
+			ptrSize: 8,
+			version: netEventVersion2,
+			typ:     NetEventTypeCapabilityAllow,
+			detail: func(m *fakeMemory, want *NetEvent) uint64 {
+				// FWPM_NET_EVENT_CAPABILITY_ALLOW0.
+				b := make([]byte, 24)
+				put32(b, 0, 0)
+				put64(b, 8, 78)
+				want.Capability = &CapabilityEvent{
+					Capability: NetworkCapabilityInternetClient,
+					FilterID:   78,
+				}
+				return m.add(b)
+			},
+		},
+		{
+			name:    "IPsec kernel drop",
+			ptrSize: 8,
+			version: netEventVersion2,
+			typ:     NetEventTypeIPsecKernelDrop,
+			detail: func(m *fakeMemory, want *NetEvent) uint64 {
+				// FWPM_NET_EVENT_IPSEC_KERNEL_DROP0.
+				b := make([]byte, 32)
+				put32(b, 0, 0xc0360001)
+				put32(b, 4, 1)
+				put32(b, 8, 0xabcd)
+				put64(b, 16, 12)
+				put16(b, 24, 20)
+				want.IPsecDrop = &IPsecDropEvent{
+					FailureStatus: 0xc0360001,
+					Direction:     DirectionInbound,
+					SPI:           0xabcd,
+					FilterID:      12,
+					LayerID:       20,
+				}
+				return m.add(b)
+			},
+		},
+		{
+			name:    "IPsec DoSP drop",
+			ptrSize: 8,
+			version: netEventVersion2,
+			typ:     NetEventTypeIPsecDoSPDrop,
+			detail: func(m *fakeMemory, want *NetEvent) uint64 {
+				// FWPM_NET_EVENT_IPSEC_DOSP_DROP0.
+				b := make([]byte, 44)
+				put32(b, 0, 1)
+				copy(b[4:], netip.MustParseAddr("2001:db8::1").AsSlice())
+				copy(b[20:], netip.MustParseAddr("fd00::1").AsSlice())
+				put32(b, 36, 0xc0360005)
+				put32(b, 40, 0)
+				want.DoSPDrop = &DoSPDropEvent{
+					PublicHostAddr:   netip.MustParseAddr("2001:db8::1"),
+					InternalHostAddr: netip.MustParseAddr("fd00::1"),
+					FailureStatus:    0xc0360005,
+					Direction:        DirectionOutbound,
+				}
+				return m.add(b)
+			},
+		},
+		{
+			name:    "IKE main mode failure",
+			ptrSize: 8,
+			version: netEventVersion2,
+			typ:     NetEventTypeIKEMainModeFailure,
+			detail: func(m *fakeMemory, want *NetEvent) uint64 {
+				// FWPM_NET_EVENT_IKEEXT_MM_FAILURE1.
+				b := make([]byte, 120)
+				put32(b, 0, 13801)
+				put32(b, 4, 2)
+				put32(b, 8, 1)
+				put32(b, 12, 2)
+				put32(b, 16, 3)
+				put32(b, 20, 1)
+				put32(b, 24, 9)
+				for i := 0; i < 20; i++ {
+					b[28+i] = byte(i)
+				}
+				put64(b, 48, 555)
+				put64(b, 56, 66)
+				m.putPtr(b, 64, m.wstr("local@example.com"))
+				m.putPtr(b, 72, m.wstr("remote@example.com"))
+				put32(b, 80, 2)
+				m.putPtr(b, 88, m.ptrs(m.wstr("S-1-5-32-544"), m.wstr("S-1-5-11")))
+				put32(b, 96, 0)
+				put32(b, 112, 1)
+				want.IKEFailure = &IKEFailureEvent{
+					ErrorCode:       13801,
+					FailurePoint:    2,
+					Flags:           1,
+					KeyingModule:    KeyingModuleIKEv2,
+					State:           3,
+					Role:            1,
+					AuthMethod:      9,
+					EndCertHash:     [20]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
+					MainModeID:      555,
+					FilterID:        66,
+					LocalPrincipal:  "local@example.com",
+					RemotePrincipal: "remote@example.com",
+					LocalGroupSIDs:  []string{"S-1-5-32-544", "S-1-5-11"},
+					TrafficType:     1,
+				}
+				return m.add(b)
+			},
+		},
+		{
+			name:    "IKE quick mode failure",
+			ptrSize: 8,
+			version: netEventVersion2,
+			typ:     NetEventTypeIKEQuickModeFailure,
+			detail: func(m *fakeMemory, want *NetEvent) uint64 {
+				// FWPM_NET_EVENT_IKEEXT_QM_FAILURE0.
+				b := make([]byte, 64)
+				put32(b, 0, 13805)
+				put32(b, 4, 3)
+				put32(b, 8, 0)
+				put32(b, 12, 2)
+				put32(b, 16, 0)
+				put32(b, 20, 1)
+				// localSubNet is a FWP_V4_ADDR_AND_MASK.
+				mask := make([]byte, 8)
+				put32(mask, 0, 0x0a010203)
+				put32(mask, 4, 0xffff0000)
+				put32(b, 24, uint32(dataTypeV4AddrMask))
+				m.putPtr(b, 32, m.add(mask))
+				// remoteSubNet is a FWP_RANGE0 of addresses.
+				rng := make([]byte, 32)
+				put32(rng, 0, uint32(dataTypeUint32))
+				put32(rng, 8, 0xc0a80001)
+				put32(rng, 16, uint32(dataTypeUint32))
+				put32(rng, 24, 0xc0a800ff)
+				put32(b, 40, uint32(dataTypeRange))
+				m.putPtr(b, 48, m.add(rng))
+				put64(b, 56, 88)
+				want.IKEFailure = &IKEFailureEvent{
+					ErrorCode:    13805,
+					FailurePoint: 3,
+					KeyingModule: KeyingModuleIKE,
+					State:        2,
+					TrafficType:  1,
+					LocalSubnet:  netipx.MustParseIPRange("10.1.0.0-10.1.255.255"),
+					RemoteSubnet: netipx.MustParseIPRange("192.168.0.1-192.168.0.255"),
+					FilterID:     88,
+				}
+				return m.add(b)
+			},
+		},
+		{
+			name:    "IKE extended mode failure",
+			ptrSize: 8,
+			version: netEventVersion2,
+			typ:     NetEventTypeIKEExtendedModeFailure,
+			detail: func(m *fakeMemory, want *NetEvent) uint64 {
+				// FWPM_NET_EVENT_IKEEXT_EM_FAILURE1.
+				b := make([]byte, 120)
+				put32(b, 0, 13806)
+				put32(b, 4, 4)
+				put32(b, 8, 0)
+				put32(b, 12, 1)
+				put32(b, 16, 0)
+				put32(b, 20, 11)
+				put64(b, 48, 556)
+				put64(b, 56, 67)
+				m.putPtr(b, 72, m.wstr("remote"))
+				put32(b, 96, 1)
+				m.putPtr(b, 104, m.ptrs(m.wstr("S-1-1-0")))
+				put32(b, 112, 2)
+				want.IKEFailure = &IKEFailureEvent{
+					ErrorCode:       13806,
+					FailurePoint:    4,
+					KeyingModule:    KeyingModuleAuthIP,
+					State:           1,
+					AuthMethod:      11,
+					MainModeID:      556,
+					FilterID:        67,
+					RemotePrincipal: "remote",
+					RemoteGroupSIDs: []string{"S-1-1-0"},
+					TrafficType:     2,
+				}
+				return m.add(b)
+			},
+		},
+		{
+			name:    "unknown type",
+			ptrSize: 8,
+			version: netEventVersion2,
+			typ:     NetEventType(10),
+			detail: func(m *fakeMemory, want *NetEvent) uint64 {
+				return m.add(make([]byte, 64))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newFakeMemory(test.ptrSize)
+			want := testHeader(test.typ, test.v6, test.version == netEventVersion2)
+			detail := test.detail(m, want)
+			event := eventFixture(m, test.version, test.typ, detail, test.v6)
+
+			got, err := decodeNetEvents(m, m.ptrs(event), 1, test.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got, []*NetEvent{want}, netipComparers...); diff != "" {
+				t.Fatalf("decoded event is wrong (-got+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeNetEventErrors(t *testing.T) {
+	m := newFakeMemory(8)
+	// The details pointer points to unmapped memory.
+	event := eventFixture(m, netEventVersion2, NetEventTypeClassifyDrop, 0xdead0000, false)
+	if _, err := decodeNetEvent(m, event, netEventVersion2); err == nil {
+		t.Error("decoding event with dangling details succeeded")
+	}
+
+	// The details of a quick mode failure have a subnet of a
+	// non-address type.
+	b := make([]byte, 64)
+	put32(b, 24, uint32(dataTypeUint64))
+	m.putPtr(b, 32, m.add(make([]byte, 8)))
+	event = eventFixture(m, netEventVersion2, NetEventTypeIKEQuickModeFailure, m.add(b), false)
+	if _, err := decodeNetEvent(m, event, netEventVersion2); err == nil {
+		t.Error("decoding subnet of uint64 type succeeded")
+	}
+
+	// The event itself is truncated.
+	if _, err := decodeNetEvent(m, m.add(make([]byte, 100)), netEventVersion2); err == nil {
+		t.Error("decoding truncated event succeeded")
+	}
+
+	// No detail pointer is fine, and only yields the header.
+	event = eventFixture(m, netEventVersion2, NetEventTypeClassifyDrop, 0, false)
+	got, err := decodeNetEvent(m, event, netEventVersion2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, testHeader(NetEventTypeClassifyDrop, false, true), netipComparers...); diff != "" {
+		t.Fatalf("decoded event is wrong (-got+want):\n%s", diff)
+	}
+}
+
+func TestNetEventsMemoryEngine(t *testing.T) {
+	m := NewMemoryEngine(nil)
+	s := newMemorySession(t, m, nil)
+
+	drop := &DropEvent{
+		Timestamp:  testEventTime,
+		IPProtocol: 17,
+		LocalAddr:  netip.MustParseAddrPort("10.0.0.1:5353"),
+		RemoteAddr: netip.MustParseAddrPort("10.0.0.2:53"),
+		AppID:      testAppID,
+		LayerID:    48,
+		FilterID:   1234,
+	}
+	allow := testHeader(NetEventTypeClassifyAllow, false, false)
+	allow.Classify = &ClassifyEvent{FilterID: 99, Direction: DirectionOutbound}
+	m.AddNetEvent(allow)
+	m.AddDropEvent(drop)
+
+	events, err := s.NetEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d net events, want 2", len(events))
+	}
+	if diff := cmp.Diff(events[0], allow, netipComparers...); diff != "" {
+		t.Errorf("allow event is wrong (-got+want):\n%s", diff)
+	}
+	if events[1].Type != NetEventTypeClassifyDrop {
+		t.Errorf("drop event has type %s", events[1].Type)
+	}
+	// Returned events are copies.
+	events[0].Classify.FilterID = 1
+
+	drops, err := s.DropEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(drops, []*DropEvent{drop}, netipComparers...); diff != "" {
+		t.Errorf("DropEvents is wrong (-got+want):\n%s", diff)
+	}
+	events, err = s.NetEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events[0].Classify.FilterID != 99 {
+		t.Errorf("modifying a returned event changed the engine's copy")
+	}
+}
@@ -0,0 +1,1066 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/bits"
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"go4.org/netipx"
+)
+
+// This file reads and writes the XML reports of `netsh wfp show
+// state` and `netsh wfp show filters`. The structs below mirror the XML
+// elements, which in turn mirror the FWPM_* structs of the WFP API.
+
+// NetshState is the filtering engine state described by a netsh XML
+// report.
+type NetshState struct {
+	Providers []*Provider
+	Sublayers []*Sublayer
+	// Layers is empty for reports from `netsh wfp show filters`,
+	// which don't describe layers.
+	Layers []*Layer
+	Rules  []*Rule
+}
+
+// Schema returns a Schema for the layers in st. Layers that st
+// doesn't describe are taken from DefaultSchema.
+func (st *NetshState) Schema() *Schema {
+	layers := append([]*Layer(nil), st.Layers...)
+	for _, l := range defaultSchema.layers {
+		if !st.hasLayer(l.ID) {
+			layers = append(layers, l)
+		}
+	}
+	return NewSchema(layers)
+}
+
+func (st *NetshState) hasLayer(id LayerID) bool {
+	for _, l := range st.Layers {
+		if l.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+type netshReport struct {
+	XMLName   xml.Name
+	Providers *netshProviders  `xml:"providers"`
+	Sublayers *netshSublayers  `xml:"subLayers"`
+	Layers    *netshLayerItems `xml:"layers"`
+	Filters   *netshFilters    `xml:"filters"`
+}
+
+// The netsh*s types are lists, which netsh prints with their length
+// in a numItems attribute.
+type (
+	netshProviders struct {
+		NumItems int              `xml:"numItems,attr"`
+		Items    []*netshProvider `xml:"item"`
+	}
+	netshSublayers struct {
+		NumItems int              `xml:"numItems,attr"`
+		Items    []*netshSublayer `xml:"item"`
+	}
+	netshLayerItems struct {
+		NumItems int               `xml:"numItems,attr"`
+		Items    []*netshLayerItem `xml:"item"`
+	}
+	netshFilters struct {
+		NumItems int            `xml:"numItems,attr"`
+		Items    []*netshFilter `xml:"item"`
+	}
+	netshFields struct {
+		NumItems int           `xml:"numItems,attr"`
+		Items    []*netshField `xml:"item"`
+	}
+	netshConditions struct {
+		NumItems int               `xml:"numItems,attr"`
+		Items    []*netshCondition `xml:"item"`
+	}
+	netshFlags struct {
+		NumItems int      `xml:"numItems,attr"`
+		Items    []string `xml:"item"`
+	}
+)
+
+type netshDisplayData struct {
+	Name        string `xml:"name"`
+	Description string `xml:"description"`
+}
+
+type netshByteBlob struct {
+	Data     string `xml:"data"`
+	AsString string `xml:"asString"`
+}
+
+type netshProvider struct {
+	Key          string           `xml:"providerKey"`
+	DisplayData  netshDisplayData `xml:"displayData"`
+	Flags        netshFlags       `xml:"flags"`
+	ProviderData netshByteBlob    `xml:"providerData"`
+	ServiceName  string           `xml:"serviceName"`
+}
+
+type netshSublayer struct {
+	Key          string           `xml:"subLayerKey"`
+	DisplayData  netshDisplayData `xml:"displayData"`
+	Flags        netshFlags       `xml:"flags"`
+	ProviderKey  string           `xml:"providerKey"`
+	ProviderData netshByteBlob    `xml:"providerData"`
+	Weight       uint16           `xml:"weight"`
+}
+
+type netshLayerItem struct {
+	Layer   netshLayer    `xml:"layer"`
+	Filters *netshFilters `xml:"filters"`
+}
+
+type netshLayer struct {
+	Key                string           `xml:"layerKey"`
+	DisplayData        netshDisplayData `xml:"displayData"`
+	Flags              netshFlags       `xml:"flags"`
+	NumFields          int              `xml:"numFields"`
+	Fields             netshFields      `xml:"field"`
+	DefaultSublayerKey string           `xml:"defaultSubLayerKey"`
+	LayerID            uint16           `xml:"layerId"`
+}
+
+type netshField struct {
+	Key      string `xml:"fieldKey"`
+	Type     string `xml:"type"`
+	DataType string `xml:"dataType"`
+}
+
+type netshFilter struct {
+	Key                string           `xml:"filterKey"`
+	DisplayData        netshDisplayData `xml:"displayData"`
+	Flags              netshFlags       `xml:"flags"`
+	ProviderKey        string           `xml:"providerKey"`
+	ProviderData       netshByteBlob    `xml:"providerData"`
+	LayerKey           string           `xml:"layerKey"`
+	SublayerKey        string           `xml:"subLayerKey"`
+	Weight             netshValue       `xml:"weight"`
+	Conditions         netshConditions  `xml:"filterCondition"`
+	Action             netshAction      `xml:"action"`
+	RawContext         uint64           `xml:"rawContext,omitempty"`
+	ProviderContextKey string           `xml:"providerContextKey,omitempty"`
+	FilterID           uint64           `xml:"filterId"`
+	EffectiveWeight    netshValue       `xml:"effectiveWeight"`
+}
+
+type netshAction struct {
+	Type       string `xml:"type"`
+	FilterType string `xml:"filterType,omitempty"`
+	CalloutKey string `xml:"calloutKey,omitempty"`
+}
+
+type netshCondition struct {
+	FieldKey  string     `xml:"fieldKey"`
+	MatchType string     `xml:"matchType"`
+	Value     netshValue `xml:"conditionValue"`
+}
+
+// netshValue is an FWP_VALUE0 or FWP_CONDITION_VALUE0. Type says
+// which of the other fields is set. Numbers are kept as strings,
+// because netsh prints some of them as IP addresses.
+type netshValue struct {
+	Type        string           `xml:"type"`
+	Uint8       string           `xml:"uint8,omitempty"`
+	Uint16      string           `xml:"uint16,omitempty"`
+	Uint32      string           `xml:"uint32,omitempty"`
+	Uint64      string           `xml:"uint64,omitempty"`
+	ByteArray16 string           `xml:"byteArray16,omitempty"`
+	ByteBlob    *netshByteBlob   `xml:"byteBlob"`
+	SID         string           `xml:"sid,omitempty"`
+	SD          string           `xml:"sd,omitempty"`
+	ByteArray6  string           `xml:"byteArray6,omitempty"`
+	V4AddrMask  *netshV4AddrMask `xml:"v4AddrMask"`
+	V6AddrMask  *netshV6AddrMask `xml:"v6AddrMask"`
+	Range       *netshRange      `xml:"rangeValue"`
+}
+
+type netshV4AddrMask struct {
+	Addr string `xml:"addr"`
+	Mask string `xml:"mask"`
+}
+
+type netshV6AddrMask struct {
+	Addr         string `xml:"addr"`
+	PrefixLength int    `xml:"prefixLength"`
+}
+
+type netshRange struct {
+	Low  netshValue `xml:"valueLow"`
+	High netshValue `xml:"valueHigh"`
+}
+
+// netshActions maps the action names used by netsh to Actions.
+var netshActions = map[string]Action{
+	"FWP_ACTION_BLOCK":               ActionBlock,
+	"FWP_ACTION_PERMIT":              ActionPermit,
+	"FWP_ACTION_CALLOUT_TERMINATING": ActionCalloutTerminating,
+	"FWP_ACTION_CALLOUT_INSPECTION":  ActionCalloutInspection,
+	"FWP_ACTION_CALLOUT_UNKNOWN":     ActionCalloutUnknown,
+}
+
+// netshMatchTypes maps the match type names used by netsh to
+// MatchTypes.
+var netshMatchTypes = map[string]MatchType{
+	"FWP_MATCH_EQUAL":                  MatchTypeEqual,
+	"FWP_MATCH_GREATER":                MatchTypeGreater,
+	"FWP_MATCH_LESS":                   MatchTypeLess,
+	"FWP_MATCH_GREATER_OR_EQUAL":       MatchTypeGreaterOrEqual,
+	"FWP_MATCH_LESS_OR_EQUAL":          MatchTypeLessOrEqual,
+	"FWP_MATCH_RANGE":                  MatchTypeRange,
+	"FWP_MATCH_FLAGS_ALL_SET":          MatchTypeFlagsAllSet,
+	"FWP_MATCH_FLAGS_ANY_SET":          MatchTypeFlagsAnySet,
+	"FWP_MATCH_FLAGS_NONE_SET":         MatchTypeFlagsNoneSet,
+	"FWP_MATCH_EQUAL_CASE_INSENSITIVE": MatchTypeEqualCaseInsensitive,
+	"FWP_MATCH_NOT_EQUAL":              MatchTypeNotEqual,
+	"FWP_MATCH_PREFIX":                 MatchTypePrefix,
+	"FWP_MATCH_NOT_PREFIX":             MatchTypeNotPrefix,
+}
+
+// netshFieldTypes maps the data type names used by netsh for layer
+// fields to Go types, like fieldTypeMap does for the WFP API.
+var netshFieldTypes = map[string]reflect.Type{
+	"FWP_UINT8":                         typeUint8,
+	"FWP_UINT16":                        typeUint16,
+	"FWP_UINT32":                        typeUint32,
+	"FWP_UINT64":                        typeUint64,
+	"FWP_BYTE_ARRAY16_TYPE":             typeArray16,
+	"FWP_BYTE_BLOB_TYPE":                typeBytes,
+	"FWP_SID":                           typeSID,
+	"FWP_SECURITY_DESCRIPTOR_TYPE":      typeSecurityDescriptor,
+	"FWP_TOKEN_INFORMATION_TYPE":        typeTokenInformation,
+	"FWP_TOKEN_ACCESS_INFORMATION_TYPE": typeSecurityDescriptor,
+	"FWP_BYTE_ARRAY6_TYPE":              typeMAC,
+	"FWP_BITMAP_INDEX_TYPE":             typeBitmapIndex,
+	"FWP_V4_ADDR_MASK":                  typePrefix,
+	"FWP_V6_ADDR_MASK":                  typePrefix,
+	"FWP_RANGE_TYPE":                    typeRange,
+}
+
+// ParseNetshXML parses the XML report written by `netsh wfp show
+// state` or `netsh wfp show filters`. Rule conditions have the same
+// Go types that Session.Rules returns, except that IP_PROTOCOL values
+// are IPProto and FLAGS values are ConditionFlag, like ParseMatch
+// returns. The types of fields are taken from the layers in the
+// report, or from DefaultSchema for reports without layers.
+func ParseNetshXML(r io.Reader) (*NetshState, error) {
+	var report netshReport
+	if err := xml.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("decoding netsh XML: %w", err)
+	}
+
+	ret := &NetshState{}
+	if report.Providers != nil {
+		for _, p := range report.Providers.Items {
+			provider, err := p.toProvider()
+			if err != nil {
+				return nil, fmt.Errorf("provider %s: %w", p.Key, err)
+			}
+			ret.Providers = append(ret.Providers, provider)
+		}
+	}
+	if report.Sublayers != nil {
+		for _, sl := range report.Sublayers.Items {
+			sublayer, err := sl.toSublayer()
+			if err != nil {
+				return nil, fmt.Errorf("sublayer %s: %w", sl.Key, err)
+			}
+			ret.Sublayers = append(ret.Sublayers, sublayer)
+		}
+	}
+	var filters []*netshFilter
+	if report.Layers != nil {
+		for _, item := range report.Layers.Items {
+			layer, err := item.Layer.toLayer()
+			if err != nil {
+				return nil, fmt.Errorf("layer %s: %w", item.Layer.Key, err)
+			}
+			ret.Layers = append(ret.Layers, layer)
+			if item.Filters != nil {
+				filters = append(filters, item.Filters.Items...)
+			}
+		}
+	}
+	if report.Filters != nil {
+		filters = append(filters, report.Filters.Items...)
+	}
+
+	schema := ret.Schema()
+	for _, f := range filters {
+		rule, err := f.toRule(schema)
+		if err != nil {
+			return nil, fmt.Errorf("filter %s: %w", f.Key, err)
+		}
+		ret.Rules = append(ret.Rules, rule)
+	}
+
+	return ret, nil
+}
+
+func (p *netshProvider) toProvider() (*Provider, error) {
+	id, err := parseNetshGUID(p.Key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := p.ProviderData.bytes()
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		ID:          ProviderID(id),
+		Name:        p.DisplayData.Name,
+		Description: p.DisplayData.Description,
+		Persistent:  hasNetshFlag(p.Flags, "FWPM_PROVIDER_FLAG_PERSISTENT"),
+		Disabled:    hasNetshFlag(p.Flags, "FWPM_PROVIDER_FLAG_DISABLED"),
+		Data:        data,
+		ServiceName: p.ServiceName,
+	}, nil
+}
+
+func (sl *netshSublayer) toSublayer() (*Sublayer, error) {
+	id, err := parseNetshGUID(sl.Key)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := parseNetshGUID(sl.ProviderKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := sl.ProviderData.bytes()
+	if err != nil {
+		return nil, err
+	}
+	return &Sublayer{
+		ID:           SublayerID(id),
+		Name:         sl.DisplayData.Name,
+		Description:  sl.DisplayData.Description,
+		Persistent:   hasNetshFlag(sl.Flags, "FWPM_SUBLAYER_FLAG_PERSISTENT"),
+		Provider:     ProviderID(provider),
+		ProviderData: data,
+		Weight:       sl.Weight,
+	}, nil
+}
+
+func (l *netshLayer) toLayer() (*Layer, error) {
+	id, err := parseNetshGUID(l.Key)
+	if err != nil {
+		return nil, err
+	}
+	sublayer, err := parseNetshGUID(l.DefaultSublayerKey)
+	if err != nil {
+		return nil, err
+	}
+	ret := &Layer{
+		ID:              LayerID(id),
+		KernelID:        l.LayerID,
+		Name:            l.DisplayData.Name,
+		Description:     l.DisplayData.Description,
+		DefaultSublayer: SublayerID(sublayer),
+	}
+	for _, f := range l.Fields.Items {
+		field, err := parseNetshGUID(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		typ, err := f.goType(FieldID(field))
+		if err != nil {
+			return nil, fmt.Errorf("finding type of field %s: %w", f.Key, err)
+		}
+		ret.Fields = append(ret.Fields, &Field{FieldID(field), typ})
+	}
+	return ret, nil
+}
+
+// goType returns the Go type for values of the field whose ID is id,
+// following the same rules as fieldType.
+func (f *netshField) goType(id FieldID) (reflect.Type, error) {
+	switch f.Type {
+	case "FWPM_FIELD_IP_ADDRESS":
+		if f.DataType != "FWP_UINT32" && f.DataType != "FWP_BYTE_ARRAY16_TYPE" {
+			return nil, fmt.Errorf("field has IP address type, but underlying datatype is %s (want Uint32 or ByteArray16)", f.DataType)
+		}
+		return typeIP, nil
+	case "FWPM_FIELD_FLAGS":
+		if f.DataType != "FWP_UINT32" {
+			return nil, fmt.Errorf("field has flag type, but underlying datatype is %s (want Uint32)", f.DataType)
+		}
+		return typeUint32, nil
+	}
+	if f.DataType == "FWP_BYTE_BLOB_TYPE" && id == FieldALEAppID {
+		return typeString, nil
+	}
+	if t, ok := netshFieldTypes[f.DataType]; ok {
+		return t, nil
+	}
+	return nil, fmt.Errorf("unknown data type %s", f.DataType)
+}
+
+func (f *netshFilter) toRule(schema *Schema) (*Rule, error) {
+	id, err := parseNetshGUID(f.Key)
+	if err != nil {
+		return nil, err
+	}
+	layer, err := parseNetshGUID(f.LayerKey)
+	if err != nil {
+		return nil, err
+	}
+	sublayer, err := parseNetshGUID(f.SublayerKey)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := parseNetshGUID(f.ProviderKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := f.ProviderData.bytes()
+	if err != nil {
+		return nil, err
+	}
+	action, ok := netshActions[f.Action.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown action %q", f.Action.Type)
+	}
+	var context GUID
+	if hasNetshFlag(f.Flags, "FWPM_FILTER_FLAG_HAS_PROVIDER_CONTEXT") {
+		if context, err = parseNetshGUID(f.ProviderContextKey); err != nil {
+			return nil, err
+		}
+	}
+
+	ret := &Rule{
+		ID:           RuleID(id),
+		KernelID:     f.FilterID,
+		Name:         f.DisplayData.Name,
+		Description:  f.DisplayData.Description,
+		Layer:        LayerID(layer),
+		Sublayer:     SublayerID(sublayer),
+		Action:       action,
+		Provider:     ProviderID(provider),
+		ProviderData: data,
+		Persistent:   hasNetshFlag(f.Flags, "FWPM_FILTER_FLAG_PERSISTENT"),
+		BootTime:     hasNetshFlag(f.Flags, "FWPM_FILTER_FLAG_BOOTTIME"),
+		Disabled:     hasNetshFlag(f.Flags, "FWPM_FILTER_FLAG_DISABLED"),
+		HardAction:   hasNetshFlag(f.Flags, "FWPM_FILTER_FLAG_CLEAR_ACTION_RIGHT"),
+	}
+	if context != (GUID{}) {
+		ret.ProviderContext = ProviderContextID(context)
+	} else {
+		ret.RawContext = f.RawContext
+	}
+	if f.EffectiveWeight.Type == "FWP_UINT64" {
+		if ret.Weight, err = strconv.ParseUint(f.EffectiveWeight.Uint64, 10, 64); err != nil {
+			return nil, fmt.Errorf("parsing weight: %w", err)
+		}
+	}
+	if action == ActionCalloutTerminating || action == ActionCalloutInspection || action == ActionCalloutUnknown {
+		callout, err := parseNetshGUID(f.Action.CalloutKey)
+		if err != nil {
+			return nil, err
+		}
+		ret.Callout = CalloutID(callout)
+	}
+	if action == ActionCalloutTerminating || action == ActionCalloutUnknown {
+		ret.PermitIfMissing = hasNetshFlag(f.Flags, "FWPM_FILTER_FLAG_PERMIT_IF_CALLOUT_UNREGISTERED")
+	}
+
+	if schema.Layer(ret.Layer) == nil {
+		return nil, fmt.Errorf("unknown layer %s", ret.Layer)
+	}
+	for _, c := range f.Conditions.Items {
+		field, err := parseNetshGUID(c.FieldKey)
+		if err != nil {
+			return nil, err
+		}
+		ftype, ok := schema.FieldType(ret.Layer, FieldID(field))
+		if !ok {
+			return nil, fmt.Errorf("unknown field %s", c.FieldKey)
+		}
+		op, ok := netshMatchTypes[c.MatchType]
+		if !ok {
+			return nil, fmt.Errorf("unknown match type %q", c.MatchType)
+		}
+		v, err := c.Value.toValue(ftype)
+		if err != nil {
+			return nil, fmt.Errorf("getting value for match [%s %s]: %w", c.FieldKey, c.MatchType, err)
+		}
+		ret.Conditions = append(ret.Conditions, &Match{
+			Field: FieldID(field),
+			Op:    op,
+			Value: netshFieldValue(FieldID(field), v),
+		})
+	}
+
+	return ret, nil
+}
+
+// netshFieldValue returns v as the type specific to field, if any:
+// IPProto for FieldIPProtocol and ConditionFlag for FieldFlags.
+func netshFieldValue(field FieldID, v interface{}) interface{} {
+	switch u := v.(type) {
+	case uint8:
+		if field == FieldIPProtocol {
+			return IPProto(u)
+		}
+	case uint32:
+		if field == FieldFlags {
+			return ConditionFlag(u)
+		}
+	case Range:
+		return Range{netshFieldValue(field, u.From), netshFieldValue(field, u.To)}
+	}
+	return v
+}
+
+// toValue converts v to the corresponding Go value, following the
+// same rules as fromValue0.
+func (v *netshValue) toValue(ftype reflect.Type) (interface{}, error) {
+	switch v.Type {
+	case "FWP_UINT8":
+		u, err := strconv.ParseUint(v.Uint8, 0, 8)
+		return uint8(u), err
+	case "FWP_UINT16":
+		u, err := strconv.ParseUint(v.Uint16, 0, 16)
+		return uint16(u), err
+	case "FWP_UINT32":
+		if ftype == typeIP {
+			if ip, err := netip.ParseAddr(v.Uint32); err == nil && ip.Is4() {
+				return ip, nil
+			}
+		}
+		u, err := strconv.ParseUint(v.Uint32, 0, 32)
+		if err != nil {
+			return nil, err
+		}
+		if ftype == typeIP {
+			var b [4]byte
+			binary.BigEndian.PutUint32(b[:], uint32(u))
+			return netip.AddrFrom4(b), nil
+		}
+		return uint32(u), nil
+	case "FWP_UINT64":
+		return strconv.ParseUint(v.Uint64, 0, 64)
+	case "FWP_BYTE_ARRAY16_TYPE":
+		if ftype == typeIP {
+			if ip, err := netip.ParseAddr(v.ByteArray16); err == nil {
+				return ip.Unmap(), nil
+			}
+		}
+		bs, err := parseNetshHex(v.ByteArray16)
+		if err != nil {
+			return nil, err
+		}
+		if len(bs) != 16 {
+			return nil, fmt.Errorf("byte array %q is not 16 bytes long", v.ByteArray16)
+		}
+		var ret [16]byte
+		copy(ret[:], bs)
+		if ftype == typeIP {
+			return netip.AddrFrom16(ret).Unmap(), nil
+		}
+		return ret, nil
+	case "FWP_BYTE_BLOB_TYPE":
+		if v.ByteBlob == nil {
+			return nil, fmt.Errorf("%s value has no byteBlob", v.Type)
+		}
+		bs, err := v.ByteBlob.bytes()
+		if err != nil {
+			return nil, err
+		}
+		if ftype == typeString {
+			return netshUTF16String(bs)
+		}
+		return bs, nil
+	case "FWP_SID":
+		return sidFromString(v.SID)
+	case "FWP_SECURITY_DESCRIPTOR_TYPE":
+		return sdFromString(v.SD)
+	case "FWP_BYTE_ARRAY6_TYPE":
+		if mac, err := net.ParseMAC(v.ByteArray6); err == nil && len(mac) == 6 {
+			return mac, nil
+		}
+		bs, err := parseNetshHex(v.ByteArray6)
+		if err != nil {
+			return nil, err
+		}
+		if len(bs) != 6 {
+			return nil, fmt.Errorf("byte array %q is not 6 bytes long", v.ByteArray6)
+		}
+		return net.HardwareAddr(bs), nil
+	case "FWP_V4_ADDR_MASK":
+		if v.V4AddrMask == nil {
+			return nil, fmt.Errorf("%s value has no v4AddrMask", v.Type)
+		}
+		ip, err := netip.ParseAddr(v.V4AddrMask.Addr)
+		if err != nil {
+			return nil, err
+		}
+		mask, err := netip.ParseAddr(v.V4AddrMask.Mask)
+		if err != nil || !mask.Is4() {
+			return nil, fmt.Errorf("invalid mask %q", v.V4AddrMask.Mask)
+		}
+		m4 := mask.As4()
+		return netip.PrefixFrom(ip, 32-bits.TrailingZeros32(binary.BigEndian.Uint32(m4[:]))), nil
+	case "FWP_V6_ADDR_MASK":
+		if v.V6AddrMask == nil {
+			return nil, fmt.Errorf("%s value has no v6AddrMask", v.Type)
+		}
+		ip, err := netip.ParseAddr(v.V6AddrMask.Addr)
+		if err != nil {
+			return nil, err
+		}
+		return netip.PrefixFrom(ip.Unmap(), v.V6AddrMask.PrefixLength), nil
+	case "FWP_RANGE_TYPE":
+		if v.Range == nil {
+			return nil, fmt.Errorf("%s value has no rangeValue", v.Type)
+		}
+		from, err := v.Range.Low.toValue(ftype)
+		if err != nil {
+			return nil, err
+		}
+		to, err := v.Range.High.toValue(ftype)
+		if err != nil {
+			return nil, err
+		}
+		if reflect.TypeOf(from) != reflect.TypeOf(to) {
+			return nil, fmt.Errorf("range.From and range.To types don't match: %s / %s", reflect.TypeOf(from), reflect.TypeOf(to))
+		}
+		if reflect.TypeOf(from) == typeIP {
+			return netipx.IPRangeFrom(from.(netip.Addr), to.(netip.Addr)), nil
+		}
+		return Range{from, to}, nil
+	}
+
+	return nil, fmt.Errorf("don't know how to map netsh type %q into Go", v.Type)
+}
+
+// bytes returns the contents of b, or nil if b is empty.
+func (b *netshByteBlob) bytes() ([]byte, error) {
+	bs, err := parseNetshHex(b.Data)
+	if err != nil || len(bs) == 0 {
+		return nil, err
+	}
+	return bs, nil
+}
+
+// parseNetshGUID parses a GUID as printed by netsh: either the name
+// of a well-known GUID from the Windows SDK, such as
+// FWPM_LAYER_ALE_AUTH_CONNECT_V4, or a GUID string. An empty string
+// is the zero GUID.
+func parseNetshGUID(s string) (GUID, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		return GUID{}, nil
+	case strings.HasPrefix(s, "FWPM_LAYER_"):
+		s = strings.TrimPrefix(s, "FWPM_LAYER_")
+	case strings.HasPrefix(s, "FWPM_CONDITION_"):
+		s = strings.TrimPrefix(s, "FWPM_CONDITION_")
+	case strings.HasPrefix(s, "FWPM_"):
+		s = strings.TrimPrefix(s, "FWPM_")
+	default:
+		return parseGUID(s)
+	}
+	if guid, ok := guidsByName[s]; ok {
+		return guid, nil
+	}
+	return GUID{}, fmt.Errorf("unknown GUID name %q", s)
+}
+
+// parseNetshHex decodes a hex string as printed by netsh, ignoring
+// whitespace and separators.
+func parseNetshHex(s string) ([]byte, error) {
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\r', '\n', '-', ':':
+			return -1
+		}
+		return r
+	}, s)
+	return hex.DecodeString(s)
+}
+
+// netshUTF16String decodes bs as a null-terminated, little-endian
+// UTF-16 string, like fromByteBlobToString.
+func netshUTF16String(bs []byte) (string, error) {
+	if len(bs)%2 != 0 {
+		return "", fmt.Errorf("byte blob should be string, but has odd number of bytes")
+	}
+	u := make([]uint16, 0, len(bs)/2)
+	for i := 0; i < len(bs); i += 2 {
+		c := binary.LittleEndian.Uint16(bs[i:])
+		if c == 0 {
+			break
+		}
+		u = append(u, c)
+	}
+	return string(utf16.Decode(u)), nil
+}
+
+func hasNetshFlag(flags netshFlags, flag string) bool {
+	for _, f := range flags.Items {
+		if strings.TrimSpace(f) == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteNetshXML writes st to w as XML, in the format of `netsh wfp
+// show state`. Rules in a layer of st.Layers are listed under that
+// layer, other rules are listed after the layers. Values are encoded
+// the way netsh prints them, e.g. security descriptors as SDDL.
+//
+// ParseNetshXML of the output returns st, provided that its condition
+// values have the types ParseNetshXML returns, e.g. IPProto rather
+// than uint8 for IP_PROTOCOL.
+func WriteNetshXML(w io.Writer, st *NetshState) error {
+	report := netshReport{
+		XMLName:   xml.Name{Local: "wfpstate"},
+		Providers: &netshProviders{},
+		Sublayers: &netshSublayers{},
+	}
+	for _, p := range st.Providers {
+		report.Providers.Items = append(report.Providers.Items, toNetshProvider(p))
+	}
+	report.Providers.NumItems = len(report.Providers.Items)
+	for _, sl := range st.Sublayers {
+		report.Sublayers.Items = append(report.Sublayers.Items, toNetshSublayer(sl))
+	}
+	report.Sublayers.NumItems = len(report.Sublayers.Items)
+
+	filters := map[LayerID]*netshFilters{}
+	if len(st.Layers) > 0 {
+		report.Layers = &netshLayerItems{}
+		for _, l := range st.Layers {
+			item := &netshLayerItem{
+				Layer:   toNetshLayer(l),
+				Filters: &netshFilters{},
+			}
+			filters[l.ID] = item.Filters
+			report.Layers.Items = append(report.Layers.Items, item)
+		}
+		report.Layers.NumItems = len(report.Layers.Items)
+	}
+	schema := st.Schema()
+	for _, r := range st.Rules {
+		f, err := toNetshFilter(r, schema)
+		if err != nil {
+			return fmt.Errorf("rule %s: %w", r.ID, err)
+		}
+		list := filters[r.Layer]
+		if list == nil {
+			if report.Filters == nil {
+				report.Filters = &netshFilters{}
+			}
+			list = report.Filters
+		}
+		list.Items = append(list.Items, f)
+		list.NumItems++
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func toNetshProvider(p *Provider) *netshProvider {
+	ret := &netshProvider{
+		Key:          netshGUID(GUID(p.ID), "FWPM_"),
+		DisplayData:  netshDisplayData{p.Name, p.Description},
+		ProviderData: toNetshByteBlob(p.Data),
+		ServiceName:  p.ServiceName,
+	}
+	ret.Flags.add(p.Persistent, "FWPM_PROVIDER_FLAG_PERSISTENT")
+	ret.Flags.add(p.Disabled, "FWPM_PROVIDER_FLAG_DISABLED")
+	return ret
+}
+
+func toNetshSublayer(sl *Sublayer) *netshSublayer {
+	ret := &netshSublayer{
+		Key:          netshGUID(GUID(sl.ID), "FWPM_"),
+		DisplayData:  netshDisplayData{sl.Name, sl.Description},
+		ProviderKey:  netshGUID(GUID(sl.Provider), "FWPM_"),
+		ProviderData: toNetshByteBlob(sl.ProviderData),
+		Weight:       sl.Weight,
+	}
+	ret.Flags.add(sl.Persistent, "FWPM_SUBLAYER_FLAG_PERSISTENT")
+	return ret
+}
+
+func toNetshLayer(l *Layer) netshLayer {
+	ret := netshLayer{
+		Key:                netshGUID(GUID(l.ID), "FWPM_LAYER_"),
+		DisplayData:        netshDisplayData{l.Name, l.Description},
+		NumFields:          len(l.Fields),
+		DefaultSublayerKey: netshGUID(GUID(l.DefaultSublayer), "FWPM_"),
+		LayerID:            l.KernelID,
+	}
+	for _, f := range l.Fields {
+		ret.Fields.Items = append(ret.Fields.Items, toNetshField(f, l.ID))
+	}
+	ret.Fields.NumItems = len(ret.Fields.Items)
+	return ret
+}
+
+// toNetshField returns the netsh form of f, a field of layer. It is
+// the inverse of netshField.goType.
+func toNetshField(f *Field, layer LayerID) *netshField {
+	ret := &netshField{
+		Key:  netshGUID(GUID(f.ID), "FWPM_CONDITION_"),
+		Type: "FWPM_FIELD_RAW_DATA",
+	}
+	switch f.Type {
+	case typeIP:
+		ret.Type = "FWPM_FIELD_IP_ADDRESS"
+		ret.DataType = "FWP_BYTE_ARRAY16_TYPE"
+		if layerFamily(layer) == 4 {
+			ret.DataType = "FWP_UINT32"
+		}
+		return ret
+	case typeString:
+		ret.DataType = "FWP_BYTE_BLOB_TYPE"
+		return ret
+	}
+	ret.DataType = netshDataTypes[f.Type]
+	return ret
+}
+
+// netshDataTypes maps Go types of layer fields to the data type
+// names used by netsh. It is the inverse of netshFieldTypes, picking
+// one name for types that have several.
+var netshDataTypes = map[reflect.Type]string{
+	typeUint8:              "FWP_UINT8",
+	typeUint16:             "FWP_UINT16",
+	typeUint32:             "FWP_UINT32",
+	typeUint64:             "FWP_UINT64",
+	typeArray16:            "FWP_BYTE_ARRAY16_TYPE",
+	typeBytes:              "FWP_BYTE_BLOB_TYPE",
+	typeSID:                "FWP_SID",
+	typeSecurityDescriptor: "FWP_SECURITY_DESCRIPTOR_TYPE",
+	typeTokenInformation:   "FWP_TOKEN_INFORMATION_TYPE",
+	typeMAC:                "FWP_BYTE_ARRAY6_TYPE",
+	typePrefix:             "FWP_V4_ADDR_MASK",
+	typeRange:              "FWP_RANGE_TYPE",
+}
+
+func toNetshFilter(r *Rule, schema *Schema) (*netshFilter, error) {
+	ret := &netshFilter{
+		Key:          netshGUID(GUID(r.ID), "FWPM_"),
+		DisplayData:  netshDisplayData{r.Name, r.Description},
+		ProviderKey:  netshGUID(GUID(r.Provider), "FWPM_"),
+		ProviderData: toNetshByteBlob(r.ProviderData),
+		LayerKey:     netshGUID(GUID(r.Layer), "FWPM_LAYER_"),
+		SublayerKey:  netshGUID(GUID(r.Sublayer), "FWPM_"),
+		Weight: netshValue{
+			Type:   "FWP_UINT64",
+			Uint64: strconv.FormatUint(r.Weight, 10),
+		},
+		FilterID: r.KernelID,
+		EffectiveWeight: netshValue{
+			Type:   "FWP_UINT64",
+			Uint64: strconv.FormatUint(r.Weight, 10),
+		},
+	}
+	ret.Flags.add(r.Persistent, "FWPM_FILTER_FLAG_PERSISTENT")
+	ret.Flags.add(r.BootTime, "FWPM_FILTER_FLAG_BOOTTIME")
+	ret.Flags.add(r.HardAction, "FWPM_FILTER_FLAG_CLEAR_ACTION_RIGHT")
+	ret.Flags.add(r.PermitIfMissing, "FWPM_FILTER_FLAG_PERMIT_IF_CALLOUT_UNREGISTERED")
+	ret.Flags.add(r.Disabled, "FWPM_FILTER_FLAG_DISABLED")
+	if !r.ProviderContext.IsZero() {
+		ret.Flags.add(true, "FWPM_FILTER_FLAG_HAS_PROVIDER_CONTEXT")
+		ret.ProviderContextKey = netshGUID(GUID(r.ProviderContext), "FWPM_")
+	} else {
+		ret.RawContext = r.RawContext
+	}
+
+	for name, action := range netshActions {
+		if action == r.Action {
+			ret.Action.Type = name
+		}
+	}
+	if ret.Action.Type == "" {
+		return nil, fmt.Errorf("unknown action %s", r.Action)
+	}
+	if r.Action&Action(ActionFlagCallout) != 0 {
+		ret.Action.CalloutKey = netshGUID(GUID(r.Callout), "FWPM_")
+	}
+
+	for _, m := range r.Conditions {
+		c := &netshCondition{
+			FieldKey: netshGUID(GUID(m.Field), "FWPM_CONDITION_"),
+		}
+		for name, op := range netshMatchTypes {
+			if op == m.Op {
+				c.MatchType = name
+			}
+		}
+		if c.MatchType == "" {
+			return nil, fmt.Errorf("unknown match type %s", m.Op)
+		}
+		ftype, _ := schema.FieldType(r.Layer, m.Field)
+		v, err := toNetshValue(m.Value, ftype)
+		if err != nil {
+			return nil, fmt.Errorf("encoding value for match [%s %s]: %w", m.Field, m.Op, err)
+		}
+		c.Value = *v
+		ret.Conditions.Items = append(ret.Conditions.Items, c)
+	}
+	ret.Conditions.NumItems = len(ret.Conditions.Items)
+
+	return ret, nil
+}
+
+// toNetshValue returns the netsh form of v, a value of a field of
+// type ftype. It is the inverse of netshValue.toValue.
+func toNetshValue(v interface{}, ftype reflect.Type) (*netshValue, error) {
+	switch v := v.(type) {
+	case uint8:
+		return &netshValue{Type: "FWP_UINT8", Uint8: strconv.FormatUint(uint64(v), 10)}, nil
+	case IPProto:
+		return &netshValue{Type: "FWP_UINT8", Uint8: strconv.FormatUint(uint64(v), 10)}, nil
+	case uint16:
+		return &netshValue{Type: "FWP_UINT16", Uint16: strconv.FormatUint(uint64(v), 10)}, nil
+	case uint32:
+		return &netshValue{Type: "FWP_UINT32", Uint32: strconv.FormatUint(uint64(v), 10)}, nil
+	case ConditionFlag:
+		return &netshValue{Type: "FWP_UINT32", Uint32: strconv.FormatUint(uint64(v), 10)}, nil
+	case uint64:
+		return &netshValue{Type: "FWP_UINT64", Uint64: strconv.FormatUint(v, 10)}, nil
+	case netip.Addr:
+		if v.Is4() {
+			return &netshValue{Type: "FWP_UINT32", Uint32: v.String()}, nil
+		}
+		return &netshValue{Type: "FWP_BYTE_ARRAY16_TYPE", ByteArray16: v.String()}, nil
+	case netip.Prefix:
+		if v.Addr().Is4() {
+			mask := netip.AddrFrom4([4]byte{})
+			if v.Bits() > 0 {
+				var b [4]byte
+				binary.BigEndian.PutUint32(b[:], ^uint32(0)<<(32-v.Bits()))
+				mask = netip.AddrFrom4(b)
+			}
+			return &netshValue{
+				Type:       "FWP_V4_ADDR_MASK",
+				V4AddrMask: &netshV4AddrMask{v.Addr().String(), mask.String()},
+			}, nil
+		}
+		return &netshValue{
+			Type:       "FWP_V6_ADDR_MASK",
+			V6AddrMask: &netshV6AddrMask{v.Addr().String(), v.Bits()},
+		}, nil
+	case netipx.IPRange:
+		if !v.IsValid() {
+			return nil, fmt.Errorf("invalid IPRange %v", v)
+		}
+		return toNetshRange(v.From(), v.To(), ftype)
+	case Range:
+		return toNetshRange(v.From, v.To, ftype)
+	case string:
+		u := utf16.Encode([]rune(v + "\x00"))
+		bs := make([]byte, 2*len(u))
+		for i, c := range u {
+			binary.LittleEndian.PutUint16(bs[2*i:], c)
+		}
+		return &netshValue{
+			Type:     "FWP_BYTE_BLOB_TYPE",
+			ByteBlob: &netshByteBlob{hex.EncodeToString(bs), v},
+		}, nil
+	case []byte:
+		bb := toNetshByteBlob(v)
+		return &netshValue{Type: "FWP_BYTE_BLOB_TYPE", ByteBlob: &bb}, nil
+	case [16]byte:
+		return &netshValue{Type: "FWP_BYTE_ARRAY16_TYPE", ByteArray16: hex.EncodeToString(v[:])}, nil
+	case net.HardwareAddr:
+		if len(v) != 6 {
+			return nil, fmt.Errorf("MAC address %v is not 6 bytes long", v)
+		}
+		return &netshValue{Type: "FWP_BYTE_ARRAY6_TYPE", ByteArray6: strings.ReplaceAll(v.String(), ":", "-")}, nil
+	}
+	if sid, ok := sidString(v); ok {
+		return &netshValue{Type: "FWP_SID", SID: sid}, nil
+	}
+	if sd, ok := sdString(v); ok {
+		return &netshValue{Type: "FWP_SECURITY_DESCRIPTOR_TYPE", SD: sd}, nil
+	}
+	return nil, fmt.Errorf("cannot map Go type %T to field type %s", v, ftype)
+}
+
+func toNetshRange(from, to interface{}, ftype reflect.Type) (*netshValue, error) {
+	if _, ok := from.(Range); ok {
+		return nil, fmt.Errorf("can't have a Range of Ranges")
+	}
+	if _, ok := to.(Range); ok {
+		return nil, fmt.Errorf("can't have a Range of Ranges")
+	}
+	low, err := toNetshValue(from, ftype)
+	if err != nil {
+		return nil, err
+	}
+	high, err := toNetshValue(to, ftype)
+	if err != nil {
+		return nil, err
+	}
+	return &netshValue{
+		Type:  "FWP_RANGE_TYPE",
+		Range: &netshRange{*low, *high},
+	}, nil
+}
+
+func toNetshByteBlob(bs []byte) netshByteBlob {
+	if len(bs) == 0 {
+		return netshByteBlob{}
+	}
+	asString := strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e {
+			return '.'
+		}
+		return r
+	}, string(bs))
+	return netshByteBlob{hex.EncodeToString(bs), asString}
+}
+
+// netshGUID returns g as netsh prints it: the name of a well-known
+// GUID with the SDK prefix that guidNames omits, a GUID string, or
+// an empty string for the zero GUID.
+func netshGUID(g GUID, prefix string) string {
+	if g == (GUID{}) {
+		return ""
+	}
+	if name, ok := guidNames[g]; ok {
+		return prefix + name
+	}
+	return strings.ToLower(g.String())
+}
+
+func (f *netshFlags) add(set bool, flag string) {
+	if set {
+		f.Items = append(f.Items, flag)
+		f.NumItems++
+	}
+}
@@ -0,0 +1,502 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"bytes"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go4.org/netipx"
+)
+
+// netshStateXML is an abridged `netsh wfp show state` report.
+const netshStateXML = `<?xml version="1.0"?>
+<wfpstate>
+<timeStamp>2026-01-02T03:04:05.000Z</timeStamp>
+<providers numItems="1">
+	<item>
+		<providerKey>{aaaaaaaa-0000-0000-0000-000000000001}</providerKey>
+		<displayData>
+			<name>Test provider</name>
+			<description>Provides tests</description>
+		</displayData>
+		<flags numItems="1">
+			<item>FWPM_PROVIDER_FLAG_PERSISTENT</item>
+		</flags>
+		<providerData>
+			<data>0102</data>
+			<asString>..</asString>
+		</providerData>
+		<serviceName>testsvc</serviceName>
+	</item>
+</providers>
+<subLayers numItems="2">
+	<item>
+		<subLayerKey>FWPM_SUBLAYER_UNIVERSAL</subLayerKey>
+		<displayData>
+			<name>WFP Built-in Universal Sublayer</name>
+		</displayData>
+		<flags/>
+		<providerKey/>
+		<providerData/>
+		<weight>32766</weight>
+	</item>
+	<item>
+		<subLayerKey>{aaaaaaaa-0000-0000-0000-000000000002}</subLayerKey>
+		<displayData>
+			<name>Test sublayer</name>
+		</displayData>
+		<flags/>
+		<providerKey>{aaaaaaaa-0000-0000-0000-000000000001}</providerKey>
+		<providerData/>
+		<weight>100</weight>
+	</item>
+</subLayers>
+<layers numItems="1">
+	<item>
+		<layer>
+			<layerKey>FWPM_LAYER_ALE_AUTH_CONNECT_V4</layerKey>
+			<displayData>
+				<name>ALE Connect v4 Layer</name>
+			</displayData>
+			<flags numItems="1">
+				<item>FWPM_LAYER_FLAG_KERNEL</item>
+			</flags>
+			<numFields>5</numFields>
+			<field numItems="5">
+				<item>
+					<fieldKey>FWPM_CONDITION_IP_REMOTE_ADDRESS</fieldKey>
+					<type>FWPM_FIELD_IP_ADDRESS</type>
+					<dataType>FWP_UINT32</dataType>
+				</item>
+				<item>
+					<fieldKey>FWPM_CONDITION_IP_REMOTE_PORT</fieldKey>
+					<type>FWPM_FIELD_RAW_DATA</type>
+					<dataType>FWP_UINT16</dataType>
+				</item>
+				<item>
+					<fieldKey>FWPM_CONDITION_IP_PROTOCOL</fieldKey>
+					<type>FWPM_FIELD_RAW_DATA</type>
+					<dataType>FWP_UINT8</dataType>
+				</item>
+				<item>
+					<fieldKey>FWPM_CONDITION_ALE_APP_ID</fieldKey>
+					<type>FWPM_FIELD_RAW_DATA</type>
+					<dataType>FWP_BYTE_BLOB_TYPE</dataType>
+				</item>
+				<item>
+					<fieldKey>FWPM_CONDITION_ALE_USER_ID</fieldKey>
+					<type>FWPM_FIELD_RAW_DATA</type>
+					<dataType>FWP_TOKEN_ACCESS_INFORMATION_TYPE</dataType>
+				</item>
+			</field>
+			<defaultSubLayerKey>FWPM_SUBLAYER_UNIVERSAL</defaultSubLayerKey>
+			<layerId>48</layerId>
+		</layer>
+		<callouts numItems="0"/>
+		<filters numItems="2">
+			<item>
+				<filterKey>{aaaaaaaa-0000-0000-0000-000000000003}</filterKey>
+				<displayData>
+					<name>Block app</name>
+					<description>Blocks the test app</description>
+				</displayData>
+				<flags numItems="2">
+					<item>FWPM_FILTER_FLAG_PERSISTENT</item>
+					<item>FWPM_FILTER_FLAG_CLEAR_ACTION_RIGHT</item>
+				</flags>
+				<providerKey>{aaaaaaaa-0000-0000-0000-000000000001}</providerKey>
+				<providerData/>
+				<layerKey>FWPM_LAYER_ALE_AUTH_CONNECT_V4</layerKey>
+				<subLayerKey>{aaaaaaaa-0000-0000-0000-000000000002}</subLayerKey>
+				<weight>
+					<type>FWP_UINT8</type>
+					<uint8>10</uint8>
+				</weight>
+				<filterCondition numItems="5">
+					<item>
+						<fieldKey>FWPM_CONDITION_ALE_APP_ID</fieldKey>
+						<matchType>FWP_MATCH_EQUAL</matchType>
+						<conditionValue>
+							<type>FWP_BYTE_BLOB_TYPE</type>
+							<byteBlob>
+								<data>5c006400650076006900630065005c0074006500730074002e006500780065000000</data>
+								<asString>\device\test.exe</asString>
+							</byteBlob>
+						</conditionValue>
+					</item>
+					<item>
+						<fieldKey>FWPM_CONDITION_IP_REMOTE_ADDRESS</fieldKey>
+						<matchType>FWP_MATCH_EQUAL</matchType>
+						<conditionValue>
+							<type>FWP_V4_ADDR_MASK</type>
+							<v4AddrMask>
+								<addr>10.0.0.0</addr>
+								<mask>255.0.0.0</mask>
+							</v4AddrMask>
+						</conditionValue>
+					</item>
+					<item>
+						<fieldKey>FWPM_CONDITION_IP_REMOTE_ADDRESS</fieldKey>
+						<matchType>FWP_MATCH_NOT_EQUAL</matchType>
+						<conditionValue>
+							<type>FWP_UINT32</type>
+							<uint32>10.1.2.3</uint32>
+						</conditionValue>
+					</item>
+					<item>
+						<fieldKey>FWPM_CONDITION_IP_REMOTE_ADDRESS</fieldKey>
+						<matchType>FWP_MATCH_RANGE</matchType>
+						<conditionValue>
+							<type>FWP_RANGE_TYPE</type>
+							<rangeValue>
+								<valueLow>
+									<type>FWP_UINT32</type>
+									<uint32>10.0.0.1</uint32>
+								</valueLow>
+								<valueHigh>
+									<type>FWP_UINT32</type>
+									<uint32>10.0.0.9</uint32>
+								</valueHigh>
+							</rangeValue>
+						</conditionValue>
+					</item>
+					<item>
+						<fieldKey>FWPM_CONDITION_IP_REMOTE_PORT</fieldKey>
+						<matchType>FWP_MATCH_RANGE</matchType>
+						<conditionValue>
+							<type>FWP_RANGE_TYPE</type>
+							<rangeValue>
+								<valueLow>
+									<type>FWP_UINT16</type>
+									<uint16>1000</uint16>
+								</valueLow>
+								<valueHigh>
+									<type>FWP_UINT16</type>
+									<uint16>2000</uint16>
+								</valueHigh>
+							</rangeValue>
+						</conditionValue>
+					</item>
+				</filterCondition>
+				<action>
+					<type>FWP_ACTION_BLOCK</type>
+					<filterType>{00000000-0000-0000-0000-000000000000}</filterType>
+				</action>
+				<rawContext>0</rawContext>
+				<reserved/>
+				<filterId>70000</filterId>
+				<effectiveWeight>
+					<type>FWP_UINT64</type>
+					<uint64>720575940379279360</uint64>
+				</effectiveWeight>
+			</item>
+			<item>
+				<filterKey>{aaaaaaaa-0000-0000-0000-000000000004}</filterKey>
+				<displayData>
+					<name>Callout</name>
+				</displayData>
+				<flags numItems="1">
+					<item>FWPM_FILTER_FLAG_PERMIT_IF_CALLOUT_UNREGISTERED</item>
+				</flags>
+				<providerKey/>
+				<providerData/>
+				<layerKey>FWPM_LAYER_ALE_AUTH_CONNECT_V4</layerKey>
+				<subLayerKey>FWPM_SUBLAYER_UNIVERSAL</subLayerKey>
+				<weight>
+					<type>FWP_EMPTY</type>
+				</weight>
+				<filterCondition numItems="2">
+					<item>
+						<fieldKey>FWPM_CONDITION_IP_PROTOCOL</fieldKey>
+						<matchType>FWP_MATCH_EQUAL</matchType>
+						<conditionValue>
+							<type>FWP_UINT8</type>
+							<uint8>6</uint8>
+						</conditionValue>
+					</item>
+					<item>
+						<fieldKey>FWPM_CONDITION_ALE_USER_ID</fieldKey>
+						<matchType>FWP_MATCH_EQUAL</matchType>
+						<conditionValue>
+							<type>FWP_SECURITY_DESCRIPTOR_TYPE</type>
+							<sd>O:LSD:(A;;CC;;;S-1-5-18)</sd>
+						</conditionValue>
+					</item>
+				</filterCondition>
+				<action>
+					<type>FWP_ACTION_CALLOUT_TERMINATING</type>
+					<calloutKey>{aaaaaaaa-0000-0000-0000-000000000005}</calloutKey>
+				</action>
+				<rawContext>0</rawContext>
+				<reserved/>
+				<filterId>70001</filterId>
+				<effectiveWeight>
+					<type>FWP_UINT64</type>
+					<uint64>42</uint64>
+				</effectiveWeight>
+			</item>
+		</filters>
+	</item>
+</layers>
+</wfpstate>
+`
+
+func testGUID(n uint32) GUID {
+	return GUID{Data1: 0xaaaaaaaa, Data4: [8]byte{7: byte(n)}}
+}
+
+func TestParseNetshXML(t *testing.T) {
+	st, err := ParseNetshXML(strings.NewReader(netshStateXML))
+	if err != nil {
+		t.Fatalf("parsing netsh XML: %v", err)
+	}
+
+	wantProviders := []*Provider{
+		{
+			ID:          ProviderID(testGUID(1)),
+			Name:        "Test provider",
+			Description: "Provides tests",
+			Persistent:  true,
+			Data:        []byte{1, 2},
+			ServiceName: "testsvc",
+		},
+	}
+	if diff := cmp.Diff(st.Providers, wantProviders); diff != "" {
+		t.Fatalf("providers are wrong (-got+want):\n%s", diff)
+	}
+
+	wantSublayers := []*Sublayer{
+		{
+			ID:     guidSublayerUniversal,
+			Name:   "WFP Built-in Universal Sublayer",
+			Weight: 32766,
+		},
+		{
+			ID:       SublayerID(testGUID(2)),
+			Name:     "Test sublayer",
+			Provider: ProviderID(testGUID(1)),
+			Weight:   100,
+		},
+	}
+	if diff := cmp.Diff(st.Sublayers, wantSublayers); diff != "" {
+		t.Fatalf("sublayers are wrong (-got+want):\n%s", diff)
+	}
+
+	wantLayers := []*Layer{
+		{
+			ID:              LayerALEAuthConnectV4,
+			KernelID:        48,
+			Name:            "ALE Connect v4 Layer",
+			DefaultSublayer: guidSublayerUniversal,
+			Fields: []*Field{
+				{FieldIPRemoteAddress, typeIP},
+				{FieldIPRemotePort, typeUint16},
+				{FieldIPProtocol, typeUint8},
+				{FieldALEAppID, typeString},
+				{FieldALEUserID, typeSecurityDescriptor},
+			},
+		},
+	}
+	if diff := cmp.Diff(st.Layers, wantLayers, cmp.Comparer(func(a, b *Field) bool { return *a == *b })); diff != "" {
+		t.Fatalf("layers are wrong (-got+want):\n%s", diff)
+	}
+
+	if len(st.Rules) != 2 {
+		t.Fatalf("got %d rules, want 2", len(st.Rules))
+	}
+	wantRule := &Rule{
+		ID:          RuleID(testGUID(3)),
+		KernelID:    70000,
+		Name:        "Block app",
+		Description: "Blocks the test app",
+		Layer:       LayerALEAuthConnectV4,
+		Sublayer:    SublayerID(testGUID(2)),
+		Weight:      720575940379279360,
+		Conditions: []*Match{
+			{FieldALEAppID, MatchTypeEqual, `\device\test.exe`},
+			{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParsePrefix("10.0.0.0/8")},
+			{FieldIPRemoteAddress, MatchTypeNotEqual, netip.MustParseAddr("10.1.2.3")},
+			{FieldIPRemoteAddress, MatchTypeRange, netipx.MustParseIPRange("10.0.0.1-10.0.0.9")},
+			{FieldIPRemotePort, MatchTypeRange, Range{uint16(1000), uint16(2000)}},
+		},
+		Action:     ActionBlock,
+		HardAction: true,
+		Persistent: true,
+		Provider:   ProviderID(testGUID(1)),
+	}
+	if diff := cmp.Diff(st.Rules[0], wantRule, netipComparers...); diff != "" {
+		t.Fatalf("rule is wrong (-got+want):\n%s", diff)
+	}
+
+	r := st.Rules[1]
+	if r.Action != ActionCalloutTerminating || r.Callout != CalloutID(testGUID(5)) || !r.PermitIfMissing || r.Weight != 42 {
+		t.Fatalf("callout rule is wrong: %+v", r)
+	}
+	if v := r.Conditions[0].Value; v != IPProtoTCP {
+		t.Fatalf("protocol condition has value %v (%T), want IPProtoTCP", v, v)
+	}
+	if sd, ok := sdString(r.Conditions[1].Value); !ok || sd != "O:LSD:(A;;CC;;;SY)" && sd != "O:LSD:(A;;CC;;;S-1-5-18)" {
+		t.Fatalf("user condition has value %v, want SDDL O:LSD:(A;;CC;;;SY)", r.Conditions[1].Value)
+	}
+}
+
+func TestParseNetshXMLErrors(t *testing.T) {
+	tests := []struct {
+		name, xml string
+	}{
+		{"bad xml", "<wfpstate>"},
+		{"unknown GUID name", `<wfpstate><providers><item><providerKey>FWPM_PROVIDER_NOPE</providerKey></item></providers></wfpstate>`},
+		{"unknown layer", `<wfpdiag><filters><item><filterKey>{aaaaaaaa-0000-0000-0000-000000000003}</filterKey><layerKey>{aaaaaaaa-0000-0000-0000-000000000009}</layerKey><action><type>FWP_ACTION_BLOCK</type></action></item></filters></wfpdiag>`},
+		{"unknown action", `<wfpdiag><filters><item><filterKey>{aaaaaaaa-0000-0000-0000-000000000003}</filterKey><layerKey>FWPM_LAYER_ALE_AUTH_CONNECT_V4</layerKey><action><type>FWP_ACTION_NOPE</type></action></item></filters></wfpdiag>`},
+	}
+	for _, test := range tests {
+		if _, err := ParseNetshXML(strings.NewReader(test.xml)); err == nil {
+			t.Errorf("%s: parsing succeeded", test.name)
+		}
+	}
+}
+
+func TestNetshXMLRoundTrip(t *testing.T) {
+	mac, err := net.ParseMAC("01:23:45:67:89:ab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sid, err := sidFromString("S-1-5-18")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sd, err := sdFromString("O:SYG:SYD:(A;;CC;;;WD)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ParseNetshXML(strings.NewReader(netshStateXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	built := &NetshState{
+		Providers: []*Provider{{ID: ProviderID(testGUID(1)), Name: "provider", Disabled: true}},
+		Sublayers: []*Sublayer{{ID: SublayerID(testGUID(2)), Persistent: true, ProviderData: []byte("data\x00")}},
+		Layers: []*Layer{
+			{
+				ID:              LayerALEAuthConnectV6,
+				KernelID:        50,
+				Name:            "ALE Connect v6 Layer",
+				DefaultSublayer: guidSublayerUniversal,
+				Fields: []*Field{
+					{FieldIPRemoteAddress, typeIP},
+					{FieldMACLocalAddress, typeMAC},
+					{FieldDCOMAppID, typeArray16},
+					{FieldALEOriginalAppID, typeBytes},
+					{FieldALEPackageID, typeSID},
+					{FieldALEUserID, typeSecurityDescriptor},
+					{FieldIPLocalInterface, typeUint64},
+					{FieldIPProtocol, typeUint8},
+					{FieldFlags, typeUint32},
+				},
+			},
+		},
+		Rules: []*Rule{
+			{
+				ID:       RuleID(testGUID(3)),
+				KernelID: 1,
+				Layer:    LayerALEAuthConnectV6,
+				Sublayer: SublayerID(testGUID(2)),
+				Weight:   7,
+				Conditions: []*Match{
+					{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParseAddr("2001:db8::1")},
+					{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParsePrefix("2001:db8::/32")},
+					{FieldIPRemoteAddress, MatchTypeRange, netipx.MustParseIPRange("2001:db8::1-2001:db8::9")},
+					{FieldMACLocalAddress, MatchTypeEqual, mac},
+					{FieldDCOMAppID, MatchTypeEqual, [16]byte{1, 2, 3}},
+					{FieldALEOriginalAppID, MatchTypeEqual, []byte{0, 1, 2}},
+					{FieldALEPackageID, MatchTypeEqual, sid},
+					{FieldALEUserID, MatchTypeEqual, sd},
+					{FieldIPLocalInterface, MatchTypeRange, Range{uint64(1), uint64(5)}},
+					{FieldIPProtocol, MatchTypeEqual, IPProtoTCP},
+					{FieldIPProtocol, MatchTypeRange, Range{IPProtoTCP, IPProtoUDP}},
+					{FieldFlags, MatchTypeFlagsAllSet, ConditionFlagIsFragmantGroup},
+				},
+				Action:          ActionCalloutUnknown,
+				Callout:         CalloutID(testGUID(5)),
+				PermitIfMissing: true,
+				RawContext:      42,
+				BootTime:        true,
+			},
+			{
+				ID:    RuleID(testGUID(4)),
+				Layer: LayerALEAuthConnectV4,
+				Conditions: []*Match{
+					{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParsePrefix("0.0.0.0/0")},
+					{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParseAddr("192.0.2.1")},
+				},
+				Action:          ActionPermit,
+				ProviderContext: ProviderContextID(testGUID(6)),
+				Disabled:        true,
+			},
+		},
+	}
+
+	for _, st := range []*NetshState{parsed, built} {
+		var buf bytes.Buffer
+		if err := WriteNetshXML(&buf, st); err != nil {
+			t.Fatalf("writing netsh XML: %v", err)
+		}
+		got, err := ParseNetshXML(&buf)
+		if err != nil {
+			t.Fatalf("parsing written netsh XML: %v\n%s", err, buf.String())
+		}
+		opts := append(netipComparers,
+			cmp.Comparer(func(a, b *Field) bool { return *a == *b }),
+			// Security descriptors are compared by their SDDL form,
+			// since on Windows they are opaque pointers.
+			cmp.FilterValues(func(a, b interface{}) bool {
+				_, okA := sdString(a)
+				_, okB := sdString(b)
+				return okA && okB
+			}, cmp.Comparer(func(a, b interface{}) bool {
+				sa, _ := sdString(a)
+				sb, _ := sdString(b)
+				return sa == sb
+			})))
+		if diff := cmp.Diff(got, st, opts...); diff != "" {
+			t.Fatalf("round trip is wrong (-got+want):\n%s", diff)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteNetshXML(&buf, parsed); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<layerKey>FWPM_LAYER_ALE_AUTH_CONNECT_V4</layerKey>",
+		"<subLayerKey>FWPM_SUBLAYER_UNIVERSAL</subLayerKey>",
+		`<filterCondition numItems="5">`,
+		"<type>FWP_V4_ADDR_MASK</type>",
+		"<mask>255.0.0.0</mask>",
+		"<asString>\\device\\test.exe</asString>",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("netsh XML doesn't contain %q:\n%s", want, buf.String())
+		}
+	}
+	buf.Reset()
+	if err := WriteNetshXML(&buf, built); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<uint32>192.0.2.1</uint32>",
+		"<sd>O:SYG:SYD:(A;;CC;;;WD)</sd>",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("netsh XML doesn't contain %q:\n%s", want, buf.String())
+		}
+	}
+}
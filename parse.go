@@ -2,6 +2,9 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build windows
+// +build windows
+
 package wf
 
 import (
@@ -11,7 +14,6 @@ import (
 	"net"
 	"net/netip"
 	"reflect"
-	"time"
 	"unsafe"
 
 	"go4.org/netipx"
@@ -24,24 +26,6 @@ import (
 // structs (which require unsafe pointers to traverse) into safe Go
 // types.
 
-var (
-	typeUint8                  = reflect.TypeOf(uint8(0))
-	typeUint16                 = reflect.TypeOf(uint16(0))
-	typeUint32                 = reflect.TypeOf(uint32(0))
-	typeUint64                 = reflect.TypeOf(uint64(0))
-	typeArray16                = reflect.TypeOf([16]byte{})
-	typeBytes                  = reflect.TypeOf([]byte(nil))
-	typeSID                    = reflect.TypeOf(&windows.SID{})
-	typeSecurityDescriptor     = reflect.TypeOf(windows.SECURITY_DESCRIPTOR{})
-	typeTokenInformation       = reflect.TypeOf(TokenInformation{})
-	typeMAC                    = reflect.TypeOf(net.HardwareAddr{})
-	typeBitmapIndex            = reflect.TypeOf(uint8(0))
-	typeIP                     = reflect.TypeOf(netip.Addr{})
-	typePrefix                 = reflect.TypeOf(netip.Prefix{})
-	typeRange                  = reflect.TypeOf(Range{})
-	typeString                 = reflect.TypeOf("")
-)
-
 // fieldTypeMap maps a layer field's dataType to a Go value of that
 // type.
 // NOTE: According to documentation, all fields which report TokenAccessInformation
@@ -178,6 +162,75 @@ func fromSublayer0(array **fwpmSublayer0, num uint32) []*Sublayer {
 	return ret
 }
 
+// fromCallout0 converts a C array of fwpmCallout0 to a safe-to-use
+// Callout slice.
+func fromCallout0(array **fwpmCallout0, num uint32) []*Callout {
+	var ret []*Callout
+
+	var callouts []*fwpmCallout0
+	sh := (*reflect.SliceHeader)(unsafe.Pointer(&callouts))
+	sh.Cap = int(num)
+	sh.Len = int(num)
+	sh.Data = uintptr(unsafe.Pointer(array))
+
+	for _, callout := range callouts {
+		c := &Callout{
+			ID:                  callout.CalloutKey,
+			KernelID:            callout.CalloutID,
+			Name:                windows.UTF16PtrToString(callout.DisplayData.Name),
+			Description:         windows.UTF16PtrToString(callout.DisplayData.Description),
+			Layer:               callout.ApplicableLayer,
+			Persistent:          (callout.Flags & fwpmCalloutFlagsPersistent) != 0,
+			UsesProviderContext: (callout.Flags & fwpmCalloutFlagsUsesProviderContext) != 0,
+			Registered:          (callout.Flags & fwpmCalloutFlagsRegistered) != 0,
+			ProviderData:        fromByteBlob(&callout.ProviderData),
+		}
+		if callout.ProviderKey != nil {
+			// Make a copy of the GUID, to ensure we're not aliasing C
+			// memory.
+			c.Provider = ProviderID(*callout.ProviderKey)
+		}
+		ret = append(ret, c)
+	}
+
+	return ret
+}
+
+// fromProviderContext0 converts a C array of fwpmProviderContext0 to
+// a safe-to-use ProviderContext slice.
+func fromProviderContext0(array **fwpmProviderContext0, num uint32) []*ProviderContext {
+	var ret []*ProviderContext
+
+	var contexts []*fwpmProviderContext0
+	sh := (*reflect.SliceHeader)(unsafe.Pointer(&contexts))
+	sh.Cap = int(num)
+	sh.Len = int(num)
+	sh.Data = uintptr(unsafe.Pointer(array))
+
+	for _, context := range contexts {
+		pc := &ProviderContext{
+			ID:           context.ProviderContextKey,
+			KernelID:     context.ProviderContextID,
+			Name:         windows.UTF16PtrToString(context.DisplayData.Name),
+			Description:  windows.UTF16PtrToString(context.DisplayData.Description),
+			Persistent:   (context.Flags & fwpmProviderContextFlagsPersistent) != 0,
+			ProviderData: fromByteBlob(&context.ProviderData),
+			Type:         context.Type,
+		}
+		if context.ProviderKey != nil {
+			// Make a copy of the GUID, to ensure we're not aliasing C
+			// memory.
+			pc.Provider = ProviderID(*context.ProviderKey)
+		}
+		if context.Type == ProviderContextGeneral && context.DataBuffer != nil {
+			pc.Data = fromByteBlob(context.DataBuffer)
+		}
+		ret = append(ret, pc)
+	}
+
+	return ret
+}
+
 // toProviders converts a C array of fwpmProvider0 to a safe-to-use Provider
 // slice.
 func fromProvider0(array **fwpmProvider0, num uint32) []*Provider {
@@ -205,51 +258,20 @@ func fromProvider0(array **fwpmProvider0, num uint32) []*Provider {
 	return ret
 }
 
-// fromNetEvent1 converts a C array of fwpmNetEvent1 to a safe-to-use
-// DropEvent slice.
-func fromNetEvent1(array **fwpmNetEvent1, num uint32) ([]*DropEvent, error) {
-	var ret []*DropEvent
-
-	var events []*fwpmNetEvent1
-	sh := (*reflect.SliceHeader)(unsafe.Pointer(&events))
-	sh.Cap = int(num)
-	sh.Len = int(num)
-	sh.Data = uintptr(unsafe.Pointer(array))
-
-	for _, event := range events {
-		if event.Type != fwpmNetEventClassifyDrop {
-			continue
-		}
+// cMemory is a memReader for the memory of C structs returned by the
+// filtering engine.
+type cMemory struct{}
 
-		e := &DropEvent{
-			Timestamp:  time.Unix(0, event.Header.Timestamp.Nanoseconds()),
-			IPProtocol: event.Header.IPProtocol,
-			LocalAddr:  netip.AddrPortFrom(netip.Addr{}, event.Header.LocalPort),
-			RemoteAddr: netip.AddrPortFrom(netip.Addr{}, event.Header.RemotePort),
-			LayerID:    event.Drop.LayerID,
-			FilterID:   event.Drop.FilterID,
-		}
-		switch event.Header.IPVersion {
-		case fwpIPVersion4:
-			localIP := ipv4From32(*(*uint32)(unsafe.Pointer(&event.Header.LocalAddr[0])))
-			e.LocalAddr = netip.AddrPortFrom(localIP, e.LocalAddr.Port())
-			remoteIP := ipv4From32(*(*uint32)(unsafe.Pointer(&event.Header.RemoteAddr[0])))
-			e.RemoteAddr = netip.AddrPortFrom(remoteIP, e.RemoteAddr.Port())
-		case fwpIPVersion6:
-			localIP := netip.AddrFrom16(event.Header.LocalAddr).Unmap()
-			e.LocalAddr = netip.AddrPortFrom(localIP, e.LocalAddr.Port())
-			remoteIP := netip.AddrFrom16(event.Header.RemoteAddr).Unmap()
-			e.RemoteAddr = netip.AddrPortFrom(remoteIP, e.RemoteAddr.Port())
-		}
-		appID, err := fromByteBlobToString(&event.Header.AppID)
-		if err != nil {
-			return nil, err
-		}
-		e.AppID = appID
-		ret = append(ret, e)
+func (cMemory) read(addr uint64, n int) ([]byte, error) {
+	if addr == 0 {
+		return nil, errNilPointer
 	}
+	p := uintptr(addr)
+	return unsafe.Slice(*(**byte)(unsafe.Pointer(&p)), n), nil
+}
 
-	return ret, nil
+func (cMemory) ptrSize() int {
+	return int(unsafe.Sizeof(uintptr(0)))
 }
 
 func fromFilter0(array **fwpmFilter0, num uint32, layerTypes layerTypes) ([]*Rule, error) {
@@ -288,6 +310,11 @@ func fromFilter0(array **fwpmFilter0, num uint32, layerTypes layerTypes) ([]*Rul
 			r.PermitIfMissing = (rule.Flags & fwpmFilterFlagsPermitIfCalloutUnregistered) != 0
 		}
 		r.HardAction = (rule.Flags & fwpmFilterFlagsClearActionRight) != 0
+		if (rule.Flags & fwpmFilterFlagsHasProviderContext) != 0 {
+			r.ProviderContext = *rule.providerContextKey()
+		} else {
+			r.RawContext = *rule.rawContext()
+		}
 
 		ft := layerTypes[r.Layer]
 		if ft == nil {
@@ -440,10 +467,6 @@ func parseRange0(v *uintptr, ftype reflect.Type) (interface{}, error) {
 	return Range{from, to}, nil
 }
 
-func ipv4From32(v uint32) netip.Addr {
-	return netip.AddrFrom4([4]byte{uint8(v >> 24), uint8(v >> 16), uint8(v >> 8), uint8(v)})
-}
-
 func fromBytes(bb uintptr, length int) []byte {
 	var bs []byte
 	sh := (*reflect.SliceHeader)(unsafe.Pointer(&bs))
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+)
+
+// PurgeProvider deletes the provider whose ID is id, along with
+// every sublayer, callout, provider context and rule it owns, and
+// returns the deletions as a Plan. Rules in the provider's sublayers,
+// and rules that invoke the provider's callouts or reference its
+// provider contexts, are deleted whoever owns them, since those
+// objects can't be deleted otherwise. All deletions are made inside
+// one transaction, so either all or none of them are applied.
+//
+// If dryRun is true, PurgeProvider returns the plan without changing
+// anything.
+func (s *Session) PurgeProvider(id ProviderID, dryRun bool) (*Plan, error) {
+	if id.IsZero() {
+		return nil, errors.New("GUID cannot be zero")
+	}
+
+	if dryRun {
+		return s.purgePlan(id)
+	}
+	var ret *Plan
+	err := s.Transact(TransactionReadWrite, func(tx *Session) error {
+		plan, err := tx.purgePlan(id)
+		if err != nil {
+			return err
+		}
+		if err := tx.apply(plan); err != nil {
+			return err
+		}
+		ret = plan
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// purgePlan returns the plan that deletes the provider whose ID is
+// id, and everything that depends on it.
+func (s *Session) purgePlan(id ProviderID) (*Plan, error) {
+	providers, err := s.Providers()
+	if err != nil {
+		return nil, fmt.Errorf("listing providers: %w", err)
+	}
+	var provider *Provider
+	for _, p := range providers {
+		if p.ID == id {
+			provider = p
+			break
+		}
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("provider %s: %w", id, syscall.Errno(ProviderNotFound))
+	}
+
+	sublayers, err := s.Sublayers(id)
+	if err != nil {
+		return nil, fmt.Errorf("listing sublayers: %w", err)
+	}
+	owned := map[SublayerID]*Sublayer{}
+	for _, sl := range sublayers {
+		owned[sl.ID] = sl
+	}
+	callouts, err := s.Callouts()
+	if err != nil {
+		return nil, fmt.Errorf("listing callouts: %w", err)
+	}
+	ownedCallouts := map[CalloutID]*Callout{}
+	for _, c := range callouts {
+		if c.Provider == id {
+			ownedCallouts[c.ID] = c
+		}
+	}
+	contexts, err := s.ProviderContexts()
+	if err != nil {
+		return nil, fmt.Errorf("listing provider contexts: %w", err)
+	}
+	ownedContexts := map[ProviderContextID]*ProviderContext{}
+	for _, pc := range contexts {
+		if pc.Provider == id {
+			ownedContexts[pc.ID] = pc
+		}
+	}
+	rules, err := s.Rules()
+	if err != nil {
+		return nil, fmt.Errorf("listing rules: %w", err)
+	}
+	purged := map[RuleID]*Rule{}
+	for _, r := range rules {
+		invokesOwned := r.Action&Action(ActionFlagCallout) != 0 && ownedCallouts[r.Callout] != nil
+		referencesOwned := ownedContexts[r.ProviderContext] != nil
+		if r.Provider == id || owned[r.Sublayer] != nil || invokesOwned || referencesOwned {
+			purged[r.ID] = r
+		}
+	}
+
+	ret := &Plan{Provider: id}
+	ret.add(ChangeDelete, provider, nil)
+	for _, sl := range sortedSublayers(owned) {
+		ret.add(ChangeDelete, sl, nil)
+	}
+	for _, c := range sortedCallouts(ownedCallouts) {
+		ret.add(ChangeDelete, c, nil)
+	}
+	for _, pc := range sortedProviderContexts(ownedContexts) {
+		ret.add(ChangeDelete, pc, nil)
+	}
+	for _, r := range sortedRules(purged) {
+		ret.add(ChangeDelete, r, nil)
+	}
+	return ret, nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPurgeProvider(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(testLayers), nil)
+	defer s.Close()
+
+	provider := &Provider{ID: ProviderID{Data1: 1}, Name: "provider"}
+	other := &Provider{ID: ProviderID{Data1: 2}, Name: "other"}
+	sublayer := &Sublayer{ID: SublayerID{Data1: 1}, Name: "sublayer", Provider: provider.ID}
+	callout := &Callout{ID: CalloutID{Data1: 1}, Name: "callout", Layer: LayerALEAuthConnectV4, Provider: provider.ID}
+	context := &ProviderContext{ID: ProviderContextID{Data1: 1}, Name: "context", Provider: provider.ID, Type: ProviderContextGeneral}
+	objs := []interface{}{
+		provider,
+		other,
+		sublayer,
+		callout,
+		context,
+		// Owned by the provider, in a universal sublayer.
+		&Rule{ID: RuleID{Data1: 1}, Name: "owned", Layer: LayerALEAuthConnectV4, Provider: provider.ID, Action: ActionBlock},
+		// Owned by someone else, in the provider's sublayer.
+		&Rule{ID: RuleID{Data1: 2}, Name: "guest", Layer: LayerALEAuthConnectV4, Sublayer: sublayer.ID, Provider: other.ID, Action: ActionBlock},
+		// Unrelated.
+		&Rule{ID: RuleID{Data1: 3}, Name: "unrelated", Layer: LayerALEAuthConnectV4, Provider: other.ID, Action: ActionBlock},
+		// Owned by someone else, invoking the provider's callout.
+		&Rule{ID: RuleID{Data1: 4}, Name: "invoker", Layer: LayerALEAuthConnectV4, Provider: other.ID, Action: ActionCalloutTerminating, Callout: callout.ID},
+		// Owned by someone else, referencing the provider's context.
+		&Rule{ID: RuleID{Data1: 5}, Name: "context user", Layer: LayerALEAuthConnectV4, Provider: other.ID, Action: ActionBlock, ProviderContext: context.ID},
+	}
+	for _, o := range objs {
+		var err error
+		switch o := o.(type) {
+		case *Provider:
+			err = s.AddProvider(o)
+		case *Sublayer:
+			err = s.AddSublayer(o)
+		case *Callout:
+			err = s.AddCallout(o)
+		case *ProviderContext:
+			err = s.AddProviderContext(o)
+		case *Rule:
+			err = s.AddRule(o)
+		}
+		if err != nil {
+			t.Fatalf("adding %v: %v", o, err)
+		}
+	}
+
+	if err := s.DeleteProvider(provider.ID); err != syscall.Errno(InUse) {
+		t.Fatalf("deleting provider in use: got err %v, want InUse", err)
+	}
+
+	want := []string{
+		`delete provider {00000001-0000-0000-0000-000000000000} "provider"`,
+		`delete sublayer {00000001-0000-0000-0000-000000000000} "sublayer"`,
+		`delete callout {00000001-0000-0000-0000-000000000000} "callout"`,
+		`delete provider context {00000001-0000-0000-0000-000000000000} "context"`,
+		`delete rule {00000001-0000-0000-0000-000000000000} "owned"`,
+		`delete rule {00000002-0000-0000-0000-000000000000} "guest"`,
+		`delete rule {00000004-0000-0000-0000-000000000000} "invoker"`,
+		`delete rule {00000005-0000-0000-0000-000000000000} "context user"`,
+	}
+	plan, err := s.PurgeProvider(provider.ID, true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if diff := cmp.Diff(planSummary(plan), want); diff != "" {
+		t.Fatalf("dry run plan is wrong (-got+want):\n%s", diff)
+	}
+	if got, _ := s.Providers(); len(got) != 2 {
+		t.Fatalf("dry run deleted providers: %v", got)
+	}
+
+	plan, err = s.PurgeProvider(provider.ID, false)
+	if err != nil {
+		t.Fatalf("purge failed: %v", err)
+	}
+	if diff := cmp.Diff(planSummary(plan), want); diff != "" {
+		t.Fatalf("purge plan is wrong (-got+want):\n%s", diff)
+	}
+
+	providers, err := s.Providers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(providers) != 1 || providers[0].ID != other.ID {
+		t.Fatalf("providers after purge are wrong: %v", providers)
+	}
+	rules, err := s.Rules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || rules[0].ID != (RuleID{Data1: 3}) {
+		t.Fatalf("rules after purge are wrong: %v", rules)
+	}
+	if callouts, err := s.Callouts(); err != nil || len(callouts) != 0 {
+		t.Fatalf("callouts after purge: %v, %v, want none", callouts, err)
+	}
+	if contexts, err := s.ProviderContexts(); err != nil || len(contexts) != 0 {
+		t.Fatalf("provider contexts after purge: %v, %v, want none", contexts, err)
+	}
+
+	if _, err := s.PurgeProvider(provider.ID, false); !errors.Is(err, syscall.Errno(ProviderNotFound)) {
+		t.Fatalf("purging missing provider: got err %v, want ProviderNotFound", err)
+	}
+}
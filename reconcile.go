@@ -0,0 +1,672 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sort"
+
+	"go4.org/netipx"
+)
+
+// DesiredState is the complete set of objects that one provider
+// should own.
+//
+// Security descriptors are applied to the objects Reconcile creates,
+// but aren't compared, since the engine doesn't return them when
+// enumerating. Use the SetDACL methods to update existing objects.
+type DesiredState struct {
+	// Provider is the provider that owns all the objects.
+	Provider *Provider
+	// Sublayers are the sublayers the provider should own. A zero
+	// Sublayer.Provider is taken to mean Provider.ID.
+	Sublayers []*Sublayer
+	// Callouts are the callouts the provider should own. A zero
+	// Callout.Provider is taken to mean Provider.ID.
+	Callouts []*Callout
+	// ProviderContexts are the provider contexts the provider should
+	// own. A zero ProviderContext.Provider is taken to mean
+	// Provider.ID.
+	ProviderContexts []*ProviderContext
+	// Rules are the rules the provider should own. A zero
+	// Rule.Provider is taken to mean Provider.ID. A zero
+	// Rule.Sublayer matches whichever sublayer the engine put the
+	// rule in, which is the layer's default sublayer.
+	Rules []*Rule
+}
+
+// ChangeType is the kind of change a Plan makes to an object.
+type ChangeType int
+
+const (
+	ChangeCreate  ChangeType = iota + 1 // the object is created
+	ChangeDelete                        // the object is deleted
+	ChangeReplace                       // the object is deleted, then recreated
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeCreate:
+		return "create"
+	case ChangeDelete:
+		return "delete"
+	case ChangeReplace:
+		return "replace"
+	}
+	return fmt.Sprintf("ChangeType(%d)", int(t))
+}
+
+// A Change is one step of a Plan.
+type Change struct {
+	Type ChangeType
+	// Object is the *Provider, *Sublayer, *Callout,
+	// *ProviderContext or *Rule being changed. For
+	// ChangeDelete it is the current object, otherwise it is the
+	// desired object.
+	Object interface{}
+	// Current is the object being replaced, for ChangeReplace.
+	Current interface{}
+}
+
+func (c *Change) String() string {
+	switch o := c.Object.(type) {
+	case *Provider:
+		return fmt.Sprintf("%s provider %s %q", c.Type, o.ID, o.Name)
+	case *Sublayer:
+		return fmt.Sprintf("%s sublayer %s %q", c.Type, o.ID, o.Name)
+	case *Callout:
+		return fmt.Sprintf("%s callout %s %q", c.Type, o.ID, o.Name)
+	case *ProviderContext:
+		return fmt.Sprintf("%s provider context %s %q", c.Type, o.ID, o.Name)
+	case *Rule:
+		return fmt.Sprintf("%s rule %s %q", c.Type, o.ID, o.Name)
+	}
+	return fmt.Sprintf("%s %v", c.Type, c.Object)
+}
+
+// A Plan is the set of changes that bring the objects owned by a
+// provider to a DesiredState.
+type Plan struct {
+	// Provider is the provider whose objects are changed.
+	Provider ProviderID
+	// Changes are the changes to make: first to the provider, then
+	// to sublayers, then to callouts, then to provider contexts,
+	// then to rules. Apply reorders them as needed to satisfy
+	// dependencies between objects.
+	Changes []*Change
+}
+
+// Empty reports whether the plan makes no changes.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Reconcile brings the objects owned by desired.Provider to the
+// desired state, and returns the changes it made. Objects that the
+// provider owns but that are not in desired are deleted. All
+// changes are made inside one transaction, so either all or none of
+// them are applied.
+//
+// The plan is computed inside the transaction, so it reflects the
+// state it is applied to, even if the transaction is retried.
+//
+// If dryRun is true, Reconcile returns the plan without changing
+// anything.
+func (s *Session) Reconcile(desired *DesiredState, dryRun bool) (*Plan, error) {
+	if dryRun {
+		return s.Plan(desired)
+	}
+	var ret *Plan
+	err := s.Transact(TransactionReadWrite, func(tx *Session) error {
+		plan, err := tx.Plan(desired)
+		if err != nil {
+			return err
+		}
+		if err := tx.apply(plan); err != nil {
+			return err
+		}
+		ret = plan
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Plan computes the changes that bring the objects owned by
+// desired.Provider to the desired state, without applying them.
+func (s *Session) Plan(desired *DesiredState) (*Plan, error) {
+	want, err := desired.normalize()
+	if err != nil {
+		return nil, err
+	}
+	id := want.Provider.ID
+
+	providers, err := s.Providers()
+	if err != nil {
+		return nil, fmt.Errorf("listing providers: %w", err)
+	}
+	var curProvider *Provider
+	for _, p := range providers {
+		if p.ID == id {
+			curProvider = p
+			break
+		}
+	}
+	var (
+		curSublayers []*Sublayer
+		curCallouts  []*Callout
+		curContexts  []*ProviderContext
+		curRules     []*Rule
+	)
+	if curProvider != nil {
+		if curSublayers, err = s.Sublayers(id); err != nil {
+			return nil, fmt.Errorf("listing sublayers: %w", err)
+		}
+		callouts, err := s.Callouts()
+		if err != nil {
+			return nil, fmt.Errorf("listing callouts: %w", err)
+		}
+		for _, c := range callouts {
+			if c.Provider == id {
+				curCallouts = append(curCallouts, c)
+			}
+		}
+		contexts, err := s.ProviderContexts()
+		if err != nil {
+			return nil, fmt.Errorf("listing provider contexts: %w", err)
+		}
+		for _, pc := range contexts {
+			if pc.Provider == id {
+				curContexts = append(curContexts, pc)
+			}
+		}
+		rules, err := s.Rules()
+		if err != nil {
+			return nil, fmt.Errorf("listing rules: %w", err)
+		}
+		for _, r := range rules {
+			if r.Provider == id {
+				curRules = append(curRules, r)
+			}
+		}
+	}
+
+	ret := &Plan{Provider: id}
+	// replaceAll is set when the provider itself is replaced, which
+	// requires deleting and recreating everything it owns.
+	replaceAll := false
+	switch {
+	case curProvider == nil:
+		ret.add(ChangeCreate, want.Provider, nil)
+	case !providersEqual(curProvider, want.Provider):
+		ret.add(ChangeReplace, want.Provider, curProvider)
+		replaceAll = true
+	}
+
+	// removedSublayers are the sublayers that are deleted at some
+	// point, which requires deleting the rules in them.
+	removedSublayers := map[SublayerID]bool{}
+	curSublayerByID := map[SublayerID]*Sublayer{}
+	for _, sl := range curSublayers {
+		curSublayerByID[sl.ID] = sl
+	}
+	for _, sl := range want.Sublayers {
+		cur := curSublayerByID[sl.ID]
+		delete(curSublayerByID, sl.ID)
+		switch {
+		case cur == nil:
+			ret.add(ChangeCreate, sl, nil)
+		case replaceAll || !sublayersEqual(cur, sl):
+			ret.add(ChangeReplace, sl, cur)
+			removedSublayers[sl.ID] = true
+		}
+	}
+	for _, sl := range sortedSublayers(curSublayerByID) {
+		ret.add(ChangeDelete, sl, nil)
+		removedSublayers[sl.ID] = true
+	}
+
+	// removedCallouts are the callouts that are deleted at some
+	// point, which requires deleting the rules that invoke them.
+	removedCallouts := map[CalloutID]bool{}
+	curCalloutByID := map[CalloutID]*Callout{}
+	for _, c := range curCallouts {
+		curCalloutByID[c.ID] = c
+	}
+	for _, c := range want.Callouts {
+		cur := curCalloutByID[c.ID]
+		delete(curCalloutByID, c.ID)
+		switch {
+		case cur == nil:
+			ret.add(ChangeCreate, c, nil)
+		case replaceAll || !calloutsEqual(cur, c):
+			ret.add(ChangeReplace, c, cur)
+			removedCallouts[c.ID] = true
+		}
+	}
+	for _, c := range sortedCallouts(curCalloutByID) {
+		ret.add(ChangeDelete, c, nil)
+		removedCallouts[c.ID] = true
+	}
+
+	// removedContexts are the provider contexts that are deleted at
+	// some point, which requires deleting the rules that reference
+	// them.
+	removedContexts := map[ProviderContextID]bool{}
+	curContextByID := map[ProviderContextID]*ProviderContext{}
+	for _, pc := range curContexts {
+		curContextByID[pc.ID] = pc
+	}
+	for _, pc := range want.ProviderContexts {
+		cur := curContextByID[pc.ID]
+		delete(curContextByID, pc.ID)
+		switch {
+		case cur == nil:
+			ret.add(ChangeCreate, pc, nil)
+		case replaceAll || !providerContextsEqual(cur, pc):
+			ret.add(ChangeReplace, pc, cur)
+			removedContexts[pc.ID] = true
+		}
+	}
+	for _, pc := range sortedProviderContexts(curContextByID) {
+		ret.add(ChangeDelete, pc, nil)
+		removedContexts[pc.ID] = true
+	}
+
+	curRuleByID := map[RuleID]*Rule{}
+	for _, r := range curRules {
+		curRuleByID[r.ID] = r
+	}
+	for _, r := range want.Rules {
+		cur := curRuleByID[r.ID]
+		delete(curRuleByID, r.ID)
+		invokesRemoved := cur != nil && cur.Action&Action(ActionFlagCallout) != 0 && removedCallouts[cur.Callout]
+		switch {
+		case cur == nil:
+			ret.add(ChangeCreate, r, nil)
+		case replaceAll || removedSublayers[cur.Sublayer] || invokesRemoved || removedContexts[cur.ProviderContext] || !rulesEqual(cur, r):
+			ret.add(ChangeReplace, r, cur)
+		}
+	}
+	for _, r := range sortedRules(curRuleByID) {
+		ret.add(ChangeDelete, r, nil)
+	}
+
+	return ret, nil
+}
+
+func (p *Plan) add(typ ChangeType, obj, cur interface{}) {
+	p.Changes = append(p.Changes, &Change{
+		Type:    typ,
+		Object:  obj,
+		Current: cur,
+	})
+}
+
+// Apply makes the changes in p inside one transaction. If any change
+// fails, the transaction is aborted and no changes are made.
+func (s *Session) Apply(p *Plan) error {
+	return s.Transact(TransactionReadWrite, func(tx *Session) error {
+		return tx.apply(p)
+	})
+}
+
+// apply makes the changes in p, deleting objects before the objects
+// they depend on, and creating them after.
+func (s *Session) apply(p *Plan) error {
+	removed := func(c *Change) interface{} {
+		switch c.Type {
+		case ChangeDelete:
+			return c.Object
+		case ChangeReplace:
+			return c.Current
+		}
+		return nil
+	}
+	added := func(c *Change) interface{} {
+		if c.Type == ChangeDelete {
+			return nil
+		}
+		return c.Object
+	}
+
+	for _, c := range p.Changes {
+		if r, ok := removed(c).(*Rule); ok {
+			if err := s.DeleteRule(r.ID); err != nil {
+				return fmt.Errorf("deleting rule %s: %w", r.ID, err)
+			}
+		}
+	}
+	for _, c := range p.Changes {
+		if pc, ok := removed(c).(*ProviderContext); ok {
+			if err := s.DeleteProviderContext(pc.ID); err != nil {
+				return fmt.Errorf("deleting provider context %s: %w", pc.ID, err)
+			}
+		}
+	}
+	for _, c := range p.Changes {
+		if co, ok := removed(c).(*Callout); ok {
+			if err := s.DeleteCallout(co.ID); err != nil {
+				return fmt.Errorf("deleting callout %s: %w", co.ID, err)
+			}
+		}
+	}
+	for _, c := range p.Changes {
+		if sl, ok := removed(c).(*Sublayer); ok {
+			if err := s.DeleteSublayer(sl.ID); err != nil {
+				return fmt.Errorf("deleting sublayer %s: %w", sl.ID, err)
+			}
+		}
+	}
+	for _, c := range p.Changes {
+		if pr, ok := removed(c).(*Provider); ok {
+			if err := s.DeleteProvider(pr.ID); err != nil {
+				return fmt.Errorf("deleting provider %s: %w", pr.ID, err)
+			}
+		}
+	}
+	for _, c := range p.Changes {
+		if pr, ok := added(c).(*Provider); ok {
+			if err := s.AddProvider(pr); err != nil {
+				return fmt.Errorf("adding provider %s: %w", pr.ID, err)
+			}
+		}
+	}
+	for _, c := range p.Changes {
+		if sl, ok := added(c).(*Sublayer); ok {
+			if err := s.AddSublayer(sl); err != nil {
+				return fmt.Errorf("adding sublayer %s: %w", sl.ID, err)
+			}
+		}
+	}
+	for _, c := range p.Changes {
+		if co, ok := added(c).(*Callout); ok {
+			if err := s.AddCallout(co); err != nil {
+				return fmt.Errorf("adding callout %s: %w", co.ID, err)
+			}
+		}
+	}
+	for _, c := range p.Changes {
+		if pc, ok := added(c).(*ProviderContext); ok {
+			if err := s.AddProviderContext(pc); err != nil {
+				return fmt.Errorf("adding provider context %s: %w", pc.ID, err)
+			}
+		}
+	}
+	for _, c := range p.Changes {
+		if r, ok := added(c).(*Rule); ok {
+			if err := s.AddRule(r); err != nil {
+				return fmt.Errorf("adding rule %s: %w", r.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// normalize checks d for consistency, and returns a copy of it in
+// which all objects are explicitly owned by d.Provider, in a stable
+// order.
+func (d *DesiredState) normalize() (*DesiredState, error) {
+	if d.Provider == nil || d.Provider.ID.IsZero() {
+		return nil, errors.New("desired state has no provider")
+	}
+	id := d.Provider.ID
+	ret := &DesiredState{Provider: d.Provider}
+
+	sublayers := map[SublayerID]*Sublayer{}
+	for _, sl := range d.Sublayers {
+		if sl.ID.IsZero() {
+			return nil, errors.New("Sublayer.ID cannot be zero")
+		}
+		if _, ok := sublayers[sl.ID]; ok {
+			return nil, fmt.Errorf("duplicate sublayer %s", sl.ID)
+		}
+		n := *sl
+		if n.Provider.IsZero() {
+			n.Provider = id
+		} else if n.Provider != id {
+			return nil, fmt.Errorf("sublayer %s is owned by provider %s, not %s", sl.ID, sl.Provider, id)
+		}
+		sublayers[n.ID] = &n
+	}
+	ret.Sublayers = sortedSublayers(sublayers)
+
+	callouts := map[CalloutID]*Callout{}
+	for _, c := range d.Callouts {
+		if c.ID.IsZero() {
+			return nil, errors.New("Callout.ID cannot be zero")
+		}
+		if _, ok := callouts[c.ID]; ok {
+			return nil, fmt.Errorf("duplicate callout %s", c.ID)
+		}
+		n := *c
+		if n.Provider.IsZero() {
+			n.Provider = id
+		} else if n.Provider != id {
+			return nil, fmt.Errorf("callout %s is owned by provider %s, not %s", c.ID, c.Provider, id)
+		}
+		callouts[n.ID] = &n
+	}
+	ret.Callouts = sortedCallouts(callouts)
+
+	contexts := map[ProviderContextID]*ProviderContext{}
+	for _, pc := range d.ProviderContexts {
+		if pc.ID.IsZero() {
+			return nil, errors.New("ProviderContext.ID cannot be zero")
+		}
+		if _, ok := contexts[pc.ID]; ok {
+			return nil, fmt.Errorf("duplicate provider context %s", pc.ID)
+		}
+		n := *pc
+		if n.Provider.IsZero() {
+			n.Provider = id
+		} else if n.Provider != id {
+			return nil, fmt.Errorf("provider context %s is owned by provider %s, not %s", pc.ID, pc.Provider, id)
+		}
+		contexts[n.ID] = &n
+	}
+	ret.ProviderContexts = sortedProviderContexts(contexts)
+
+	rules := map[RuleID]*Rule{}
+	for _, r := range d.Rules {
+		if r.ID.IsZero() {
+			return nil, errors.New("Rule.ID cannot be zero")
+		}
+		if _, ok := rules[r.ID]; ok {
+			return nil, fmt.Errorf("duplicate rule %s", r.ID)
+		}
+		n := *r
+		if n.Provider.IsZero() {
+			n.Provider = id
+		} else if n.Provider != id {
+			return nil, fmt.Errorf("rule %s is owned by provider %s, not %s", r.ID, r.Provider, id)
+		}
+		rules[n.ID] = &n
+	}
+	ret.Rules = sortedRules(rules)
+
+	return ret, nil
+}
+
+func sortedSublayers(m map[SublayerID]*Sublayer) []*Sublayer {
+	var ret []*Sublayer
+	for _, sl := range m {
+		ret = append(ret, sl)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return GUID(ret[i].ID).String() < GUID(ret[j].ID).String()
+	})
+	return ret
+}
+
+func sortedCallouts(m map[CalloutID]*Callout) []*Callout {
+	var ret []*Callout
+	for _, c := range m {
+		ret = append(ret, c)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return GUID(ret[i].ID).String() < GUID(ret[j].ID).String()
+	})
+	return ret
+}
+
+func sortedProviderContexts(m map[ProviderContextID]*ProviderContext) []*ProviderContext {
+	var ret []*ProviderContext
+	for _, pc := range m {
+		ret = append(ret, pc)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return GUID(ret[i].ID).String() < GUID(ret[j].ID).String()
+	})
+	return ret
+}
+
+func sortedRules(m map[RuleID]*Rule) []*Rule {
+	var ret []*Rule
+	for _, r := range m {
+		ret = append(ret, r)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return GUID(ret[i].ID).String() < GUID(ret[j].ID).String()
+	})
+	return ret
+}
+
+// providersEqual reports whether the current provider cur has the
+// properties of the desired provider want.
+func providersEqual(cur, want *Provider) bool {
+	return cur.ID == want.ID &&
+		cur.Name == want.Name &&
+		cur.Description == want.Description &&
+		cur.Persistent == want.Persistent &&
+		bytes.Equal(cur.Data, want.Data) &&
+		cur.ServiceName == want.ServiceName
+}
+
+// sublayersEqual reports whether the current sublayer cur has the
+// properties of the desired sublayer want.
+func sublayersEqual(cur, want *Sublayer) bool {
+	return cur.ID == want.ID &&
+		cur.Name == want.Name &&
+		cur.Description == want.Description &&
+		cur.Persistent == want.Persistent &&
+		cur.Provider == want.Provider &&
+		bytes.Equal(cur.ProviderData, want.ProviderData) &&
+		cur.Weight == want.Weight
+}
+
+// calloutsEqual reports whether the current callout cur has the
+// properties of the desired callout want. Properties that the engine
+// sets, such as KernelID and Registered, are ignored, as is
+// SecurityDescriptor.
+func calloutsEqual(cur, want *Callout) bool {
+	return cur.ID == want.ID &&
+		cur.Name == want.Name &&
+		cur.Description == want.Description &&
+		cur.Layer == want.Layer &&
+		cur.Persistent == want.Persistent &&
+		cur.UsesProviderContext == want.UsesProviderContext &&
+		cur.Provider == want.Provider &&
+		bytes.Equal(cur.ProviderData, want.ProviderData)
+}
+
+// providerContextsEqual reports whether the current provider context
+// cur has the properties of the desired provider context want.
+// KernelID, which the engine sets, is ignored, as is
+// SecurityDescriptor.
+func providerContextsEqual(cur, want *ProviderContext) bool {
+	return cur.ID == want.ID &&
+		cur.Name == want.Name &&
+		cur.Description == want.Description &&
+		cur.Persistent == want.Persistent &&
+		cur.Provider == want.Provider &&
+		bytes.Equal(cur.ProviderData, want.ProviderData) &&
+		cur.Type == want.Type &&
+		bytes.Equal(cur.Data, want.Data)
+}
+
+// rulesEqual reports whether the current rule cur has the properties
+// of the desired rule want. Properties that the engine sets, such
+// as KernelID and Disabled, are ignored, as is SecurityDescriptor.
+func rulesEqual(cur, want *Rule) bool {
+	return cur.ID == want.ID &&
+		cur.Name == want.Name &&
+		cur.Description == want.Description &&
+		cur.Layer == want.Layer &&
+		(want.Sublayer.IsZero() || cur.Sublayer == want.Sublayer) &&
+		cur.Weight == want.Weight &&
+		conditionsEqual(cur.Conditions, want.Conditions) &&
+		cur.Action == want.Action &&
+		cur.Callout == want.Callout &&
+		cur.PermitIfMissing == want.PermitIfMissing &&
+		cur.HardAction == want.HardAction &&
+		cur.ProviderContext == want.ProviderContext &&
+		cur.RawContext == want.RawContext &&
+		cur.Persistent == want.Persistent &&
+		cur.BootTime == want.BootTime &&
+		cur.Provider == want.Provider &&
+		bytes.Equal(cur.ProviderData, want.ProviderData)
+}
+
+// conditionsEqual reports whether a and b contain the same matches,
+// in any order. Matches are compared by the JSON encoding of their
+// engineValue, which captures the type and value of Match.Value on
+// all platforms.
+func conditionsEqual(a, b []*Match) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	encode := func(ms []*Match) ([]string, bool) {
+		var ret []string
+		for _, m := range ms {
+			bs, err := json.Marshal(&Match{m.Field, m.Op, engineValue(m.Value)})
+			if err != nil {
+				return nil, false
+			}
+			ret = append(ret, string(bs))
+		}
+		sort.Strings(ret)
+		return ret, true
+	}
+	as, ok := encode(a)
+	if !ok {
+		return false
+	}
+	bs, ok := encode(b)
+	if !ok {
+		return false
+	}
+	for i := range as {
+		if as[i] != bs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// engineValue returns v as the filtering engine returns it from
+// Session.Rules, so that a desired value such as IPProtoTCP compares
+// equal to the uint8 the engine reads back.
+func engineValue(v interface{}) interface{} {
+	switch u := v.(type) {
+	case IPProto:
+		return uint8(u)
+	case ConditionFlag:
+		return uint32(u)
+	case Range:
+		return Range{engineValue(u.From), engineValue(u.To)}
+	case netip.Addr, netip.Prefix, netipx.IPRange:
+		if ret, err := memValue(v, typeIP); err == nil {
+			return ret
+		}
+	}
+	return v
+}
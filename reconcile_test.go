@@ -0,0 +1,408 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// planSummary returns the changes in p as strings, for comparison in
+// tests.
+func planSummary(p *Plan) []string {
+	var ret []string
+	for _, c := range p.Changes {
+		ret = append(ret, c.String())
+	}
+	return ret
+}
+
+func TestReconcile(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(testLayers), nil)
+	defer s.Close()
+
+	// A rule owned by someone else, which the reconciler must not
+	// touch.
+	foreign := &Rule{ID: RuleID{Data1: 99}, Layer: LayerALEAuthConnectV4, Action: ActionBlock}
+	if err := s.AddRule(foreign); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &Provider{ID: ProviderID{Data1: 1}, Name: "provider"}
+	sublayer := &Sublayer{ID: SublayerID{Data1: 1}, Name: "sublayer", Weight: 10}
+	rule := func(id uint32, port uint16) *Rule {
+		return &Rule{
+			ID:       RuleID{Data1: id},
+			Name:     "rule",
+			Layer:    LayerALEAuthConnectV4,
+			Sublayer: sublayer.ID,
+			Conditions: []*Match{
+				{FieldIPRemotePort, MatchTypeEqual, port},
+				{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParsePrefix("10.0.0.0/8")},
+			},
+			Action: ActionPermit,
+		}
+	}
+	desired := &DesiredState{
+		Provider:  provider,
+		Sublayers: []*Sublayer{sublayer},
+		Rules:     []*Rule{rule(2, 443), rule(1, 80)},
+	}
+
+	plan, err := s.Reconcile(desired, true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if len(plan.Changes) != 4 {
+		t.Fatalf("dry run plan is wrong: %q", planSummary(plan))
+	}
+	if got, _ := s.Providers(); len(got) != 0 {
+		t.Fatalf("dry run created providers: %v", got)
+	}
+
+	if _, err := s.Reconcile(desired, false); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	plan, err = s.Plan(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("plan after reconcile is not empty: %q", planSummary(plan))
+	}
+
+	// Change one rule, drop the other, add a third.
+	desired.Rules = []*Rule{rule(1, 8080), rule(3, 22)}
+	plan, err = s.Reconcile(desired, false)
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	want := []string{
+		`replace rule {00000001-0000-0000-0000-000000000000} "rule"`,
+		`create rule {00000003-0000-0000-0000-000000000000} "rule"`,
+		`delete rule {00000002-0000-0000-0000-000000000000} "rule"`,
+	}
+	if diff := cmp.Diff(planSummary(plan), want); diff != "" {
+		t.Fatalf("plan is wrong (-got+want):\n%s", diff)
+	}
+
+	// Changing the sublayer replaces it, and all the rules in it.
+	changed := *sublayer
+	changed.Weight = 20
+	desired.Sublayers = []*Sublayer{&changed}
+	plan, err = s.Reconcile(desired, false)
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if len(plan.Changes) != 3 {
+		t.Fatalf("plan is wrong: %q", planSummary(plan))
+	}
+	sls, err := s.Sublayers(provider.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sls) != 1 || sls[0].Weight != 20 {
+		t.Fatalf("sublayer was not replaced: %v", sls)
+	}
+
+	rules, err := s.Rules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[RuleID]bool{}
+	for _, r := range rules {
+		got[r.ID] = true
+	}
+	if len(got) != 3 || !got[foreign.ID] || !got[RuleID{Data1: 1}] || !got[RuleID{Data1: 3}] {
+		t.Fatalf("rules after reconcile are wrong: %v", rules)
+	}
+}
+
+func TestReconcileConditionTypes(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(testLayers), nil)
+	defer s.Close()
+
+	// The engine returns IPProto and ConditionFlag values as plain
+	// integers, which must not count as drift.
+	desired := &DesiredState{
+		Provider: &Provider{ID: ProviderID{Data1: 1}},
+		Rules: []*Rule{
+			{
+				ID:    RuleID{Data1: 1},
+				Layer: LayerALEAuthConnectV4,
+				Conditions: []*Match{
+					{FieldIPProtocol, MatchTypeEqual, IPProtoTCP},
+					{FieldIPProtocol, MatchTypeRange, Range{IPProtoTCP, IPProtoUDP}},
+					{FieldFlags, MatchTypeFlagsNoneSet, ConditionFlagIsLoopback},
+				},
+				Action: ActionBlock,
+			},
+		},
+	}
+	if _, err := s.Reconcile(desired, false); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	plan, err := s.Plan(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("plan after reconcile is not empty: %q", planSummary(plan))
+	}
+
+	// A different protocol is still a change.
+	desired.Rules[0].Conditions[0].Value = IPProtoUDP
+	plan, err = s.Plan(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Changes) != 1 || plan.Changes[0].Type != ChangeReplace {
+		t.Fatalf("plan is wrong: %q", planSummary(plan))
+	}
+}
+
+func TestReconcileCallouts(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(testLayers), nil)
+	defer s.Close()
+
+	provider := &Provider{ID: ProviderID{Data1: 1}, Name: "provider"}
+	callout := &Callout{ID: CalloutID{Data1: 1}, Name: "callout", Layer: LayerALEAuthConnectV4}
+	stale := &Callout{ID: CalloutID{Data1: 2}, Name: "stale", Layer: LayerALEAuthConnectV4}
+	rule := &Rule{ID: RuleID{Data1: 1}, Name: "rule", Layer: LayerALEAuthConnectV4, Action: ActionCalloutTerminating, Callout: callout.ID}
+	desired := &DesiredState{
+		Provider: provider,
+		Callouts: []*Callout{callout, stale},
+		Rules:    []*Rule{rule},
+	}
+	if _, err := s.Reconcile(desired, false); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	plan, err := s.Plan(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("plan after reconcile is not empty: %q", planSummary(plan))
+	}
+
+	// Changing a callout replaces it, and the rules that invoke it.
+	// Dropping a callout deletes it.
+	changed := *callout
+	changed.Description = "changed"
+	desired.Callouts = []*Callout{&changed}
+	plan, err = s.Reconcile(desired, false)
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	want := []string{
+		`replace callout {00000001-0000-0000-0000-000000000000} "callout"`,
+		`delete callout {00000002-0000-0000-0000-000000000000} "stale"`,
+		`replace rule {00000001-0000-0000-0000-000000000000} "rule"`,
+	}
+	if diff := cmp.Diff(planSummary(plan), want); diff != "" {
+		t.Fatalf("plan is wrong (-got+want):\n%s", diff)
+	}
+
+	// Changing the provider replaces it, and everything it owns,
+	// which must include its callouts for the delete to succeed.
+	changedProvider := *provider
+	changedProvider.Name = "changed"
+	desired.Provider = &changedProvider
+	plan, err = s.Reconcile(desired, false)
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	want = []string{
+		`replace provider {00000001-0000-0000-0000-000000000000} "changed"`,
+		`replace callout {00000001-0000-0000-0000-000000000000} "callout"`,
+		`replace rule {00000001-0000-0000-0000-000000000000} "rule"`,
+	}
+	if diff := cmp.Diff(planSummary(plan), want); diff != "" {
+		t.Fatalf("plan is wrong (-got+want):\n%s", diff)
+	}
+	callouts, err := s.Callouts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(callouts) != 1 || callouts[0].Description != "changed" || callouts[0].Provider != provider.ID {
+		t.Fatalf("callouts after reconcile are wrong: %v", callouts)
+	}
+
+	// Removing the callouts from the desired state deletes them,
+	// along with the rules that invoke them.
+	desired.Callouts = nil
+	desired.Rules = nil
+	plan, err = s.Reconcile(desired, false)
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if len(plan.Changes) != 2 {
+		t.Fatalf("plan is wrong: %q", planSummary(plan))
+	}
+	if got, _ := s.Callouts(); len(got) != 0 {
+		t.Fatalf("reconcile left callouts behind: %v", got)
+	}
+}
+
+func TestReconcileProviderContexts(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(testLayers), nil)
+	defer s.Close()
+
+	provider := &Provider{ID: ProviderID{Data1: 1}, Name: "provider"}
+	context := &ProviderContext{ID: ProviderContextID{Data1: 1}, Name: "context", Type: ProviderContextGeneral, Data: []byte("config")}
+	stale := &ProviderContext{ID: ProviderContextID{Data1: 2}, Name: "stale", Type: ProviderContextGeneral}
+	rule := &Rule{ID: RuleID{Data1: 1}, Name: "rule", Layer: LayerALEAuthConnectV4, Action: ActionBlock, ProviderContext: context.ID}
+	desired := &DesiredState{
+		Provider:         provider,
+		ProviderContexts: []*ProviderContext{context, stale},
+		Rules:            []*Rule{rule},
+	}
+	if _, err := s.Reconcile(desired, false); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	plan, err := s.Plan(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("plan after reconcile is not empty: %q", planSummary(plan))
+	}
+
+	// Changing a provider context replaces it, and the rules that
+	// reference it. Dropping a provider context deletes it.
+	changed := *context
+	changed.Data = []byte("new config")
+	desired.ProviderContexts = []*ProviderContext{&changed}
+	plan, err = s.Reconcile(desired, false)
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	want := []string{
+		`replace provider context {00000001-0000-0000-0000-000000000000} "context"`,
+		`delete provider context {00000002-0000-0000-0000-000000000000} "stale"`,
+		`replace rule {00000001-0000-0000-0000-000000000000} "rule"`,
+	}
+	if diff := cmp.Diff(planSummary(plan), want); diff != "" {
+		t.Fatalf("plan is wrong (-got+want):\n%s", diff)
+	}
+
+	// Changing the provider replaces it, and everything it owns,
+	// which must include its provider contexts for the delete to
+	// succeed.
+	changedProvider := *provider
+	changedProvider.Name = "changed"
+	desired.Provider = &changedProvider
+	plan, err = s.Reconcile(desired, false)
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	want = []string{
+		`replace provider {00000001-0000-0000-0000-000000000000} "changed"`,
+		`replace provider context {00000001-0000-0000-0000-000000000000} "context"`,
+		`replace rule {00000001-0000-0000-0000-000000000000} "rule"`,
+	}
+	if diff := cmp.Diff(planSummary(plan), want); diff != "" {
+		t.Fatalf("plan is wrong (-got+want):\n%s", diff)
+	}
+	contexts, err := s.ProviderContexts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contexts) != 1 || string(contexts[0].Data) != "new config" || contexts[0].Provider != provider.ID {
+		t.Fatalf("provider contexts after reconcile are wrong: %v", contexts)
+	}
+
+	// Removing the provider contexts from the desired state deletes
+	// them, along with the rules that reference them.
+	desired.ProviderContexts = nil
+	desired.Rules = nil
+	plan, err = s.Reconcile(desired, false)
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if len(plan.Changes) != 2 {
+		t.Fatalf("plan is wrong: %q", planSummary(plan))
+	}
+	if got, _ := s.ProviderContexts(); len(got) != 0 {
+		t.Fatalf("reconcile left provider contexts behind: %v", got)
+	}
+}
+
+func TestReconcileAtomic(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(testLayers), nil)
+	defer s.Close()
+
+	desired := &DesiredState{
+		Provider: &Provider{ID: ProviderID{Data1: 1}},
+		Rules: []*Rule{
+			{ID: RuleID{Data1: 1}, Layer: LayerALEAuthConnectV4, Action: ActionBlock},
+			// Not a layer the engine knows, so adding fails.
+			{ID: RuleID{Data1: 2}, Layer: LayerID{Data1: 42}, Action: ActionBlock},
+		},
+	}
+	if _, err := s.Reconcile(desired, false); err == nil {
+		t.Fatalf("reconcile with bad rule succeeded")
+	}
+	if got, _ := s.Providers(); len(got) != 0 {
+		t.Fatalf("failed reconcile left providers behind: %v", got)
+	}
+	if got, _ := s.Rules(); len(got) != 0 {
+		t.Fatalf("failed reconcile left rules behind: %v", got)
+	}
+
+	desired.Rules[1].Provider = ProviderID{Data1: 2}
+	if _, err := s.Plan(desired); err == nil {
+		t.Fatalf("plan with rule owned by another provider succeeded")
+	}
+}
+
+func TestReconcileConcurrent(t *testing.T) {
+	m := NewMemoryEngine(testLayers)
+	s := newMemorySession(t, m, nil)
+	defer s.Close()
+	other := newMemorySession(t, m, nil)
+	defer other.Close()
+
+	provider := &Provider{ID: ProviderID{Data1: 1}}
+	rule := &Rule{ID: RuleID{Data1: 1}, Layer: LayerALEAuthConnectV4, Action: ActionBlock, Provider: provider.ID}
+	desired := &DesiredState{
+		Provider: provider,
+		Rules:    []*Rule{rule},
+	}
+
+	// Another session creates the desired state while Reconcile
+	// waits for the transaction lock. The plan must be computed
+	// from the state Reconcile gets the lock on, not from the state
+	// before, or it would try to create the objects again.
+	other.BeginTransaction(TransactionReadWrite)
+	if err := other.AddProvider(provider); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.AddRule(rule); err != nil {
+		t.Fatal(err)
+	}
+	type result struct {
+		plan *Plan
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		plan, err := s.Reconcile(desired, false)
+		done <- result{plan, err}
+	}()
+	time.Sleep(50 * time.Millisecond)
+	other.CommitTransaction()
+
+	res := <-done
+	if res.err != nil {
+		t.Fatalf("reconcile failed: %v", res.err)
+	}
+	if !res.plan.Empty() {
+		t.Errorf("reconcile applied a stale plan: %q", planSummary(res.plan))
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// staticLayer is the compact form of a Layer in zschema.go.
+type staticLayer struct {
+	ID       LayerID
+	KernelID uint16
+	Name     string
+	Fields   []FieldID
+}
+
+// A Schema describes a set of layers, and the fields that rules in
+// each layer can match on. It is immutable.
+type Schema struct {
+	layers []*Layer
+	types  layerTypes
+}
+
+// NewSchema returns a Schema that describes layers, for example as
+// returned by Session.Layers.
+func NewSchema(layers []*Layer) *Schema {
+	ret := &Schema{
+		types: layerTypes{},
+	}
+	for _, l := range layers {
+		l = cloneLayer(l)
+		fields := fieldTypes{}
+		for _, f := range l.Fields {
+			fields[f.ID] = f.Type
+		}
+		ret.layers = append(ret.layers, l)
+		ret.types[l.ID] = fields
+	}
+	return ret
+}
+
+var defaultSchema = func() *Schema {
+	var layers []*Layer
+	for _, sl := range staticLayers {
+		l := &Layer{
+			ID:              sl.ID,
+			KernelID:        sl.KernelID,
+			Name:            sl.Name,
+			DefaultSublayer: guidSublayerUniversal,
+		}
+		for _, f := range sl.Fields {
+			l.Fields = append(l.Fields, &Field{f, staticFieldTypes[f]})
+		}
+		layers = append(layers, l)
+	}
+	return NewSchema(layers)
+}()
+
+// DefaultSchema returns the built-in schema of the well-known WFP
+// layers. It lets rules be encoded, decoded and validated without a
+// connection to a filtering engine.
+func DefaultSchema() *Schema {
+	return defaultSchema
+}
+
+// Layers returns all the layers in the schema, ordered by KernelID.
+func (s *Schema) Layers() []*Layer {
+	var ret []*Layer
+	for _, l := range s.layers {
+		ret = append(ret, cloneLayer(l))
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].KernelID < ret[j].KernelID
+	})
+	return ret
+}
+
+// Layer returns the layer whose ID is id, or nil if the schema
+// doesn't describe that layer.
+func (s *Schema) Layer(id LayerID) *Layer {
+	for _, l := range s.layers {
+		if l.ID == id {
+			return cloneLayer(l)
+		}
+	}
+	return nil
+}
+
+// Fields returns the fields that rules in layer can match on.
+func (s *Schema) Fields(layer LayerID) ([]*Field, error) {
+	l := s.Layer(layer)
+	if l == nil {
+		return nil, fmt.Errorf("unknown layer %s", layer)
+	}
+	return l.Fields, nil
+}
+
+// FieldType returns the Go type of the values of field in layer, and
+// whether layer has that field.
+func (s *Schema) FieldType(layer LayerID, field FieldID) (reflect.Type, bool) {
+	t, ok := s.types[layer][field]
+	return t, ok
+}
+
+// LayerFields returns the fields that rules in layer can match on,
+// according to DefaultSchema.
+func LayerFields(layer LayerID) ([]*Field, error) {
+	return defaultSchema.Fields(layer)
+}
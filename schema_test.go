@@ -0,0 +1,56 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"testing"
+)
+
+func TestDefaultSchema(t *testing.T) {
+	s := DefaultSchema()
+
+	l := s.Layer(LayerALEAuthRecvAcceptV4)
+	if l == nil {
+		t.Fatalf("default schema has no ALE_AUTH_RECV_ACCEPT_V4 layer")
+	}
+	if l.KernelID != 44 {
+		t.Fatalf("ALE_AUTH_RECV_ACCEPT_V4 has KernelID %d, want 44", l.KernelID)
+	}
+	typ, ok := s.FieldType(LayerALEAuthRecvAcceptV4, FieldALEUserID)
+	if !ok {
+		t.Fatalf("ALE_AUTH_RECV_ACCEPT_V4 has no ALE_USER_ID field")
+	}
+	if typ != typeSecurityDescriptor {
+		t.Fatalf("ALE_USER_ID has type %v, want %v", typ, typeSecurityDescriptor)
+	}
+
+	layers := s.Layers()
+	for i := 1; i < len(layers); i++ {
+		if layers[i-1].KernelID >= layers[i].KernelID {
+			t.Fatalf("layers %s and %s are out of order", layers[i-1].ID, layers[i].ID)
+		}
+	}
+
+	if _, err := LayerFields(LayerID{Data1: 42}); err == nil {
+		t.Fatalf("LayerFields of unknown layer succeeded")
+	}
+}
+
+func TestDefaultSchemaMemoryEngine(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(nil), nil)
+	defer s.Close()
+
+	r := &Rule{
+		ID:    RuleID{Data1: 1},
+		Layer: LayerALEAuthConnectV4,
+		Conditions: []*Match{
+			{FieldIPRemotePort, MatchTypeEqual, uint16(443)},
+		},
+		Action: ActionBlock,
+	}
+	if err := s.AddRule(r); err != nil {
+		t.Fatalf("adding rule with default schema: %v", err)
+	}
+}
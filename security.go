@@ -0,0 +1,167 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Security descriptors control who can read, modify and delete
+// filtering engine objects. They are given in SDDL form, such as
+// "O:SYG:SYD:(A;;GA;;;SY)", so that they work the same on all
+// platforms. The Security methods below return the owner, group and
+// DACL of an object, but not its SACL, whose reading requires
+// SeSecurityPrivilege.
+
+// ProviderSecurity returns the security descriptor of the provider
+// whose GUID is id.
+func (s *Session) ProviderSecurity(id ProviderID) (string, error) {
+	if id.IsZero() {
+		return "", errors.New("GUID cannot be zero")
+	}
+
+	return s.engine.ProviderSecurity(id)
+}
+
+// SetProviderDACL replaces the DACL of the provider whose GUID is id
+// with the DACL of the security descriptor sddl. The owner, group and
+// SACL of sddl are ignored.
+func (s *Session) SetProviderDACL(id ProviderID, sddl string) error {
+	if id.IsZero() {
+		return errors.New("GUID cannot be zero")
+	}
+	if sddl == "" {
+		return errors.New("security descriptor cannot be empty")
+	}
+
+	return s.engine.SetProviderDACL(id, sddl)
+}
+
+// SublayerSecurity returns the security descriptor of the sublayer
+// whose GUID is id.
+func (s *Session) SublayerSecurity(id SublayerID) (string, error) {
+	if id.IsZero() {
+		return "", errors.New("GUID cannot be zero")
+	}
+
+	return s.engine.SublayerSecurity(id)
+}
+
+// SetSublayerDACL replaces the DACL of the sublayer whose GUID is id
+// with the DACL of the security descriptor sddl. The owner, group and
+// SACL of sddl are ignored.
+func (s *Session) SetSublayerDACL(id SublayerID, sddl string) error {
+	if id.IsZero() {
+		return errors.New("GUID cannot be zero")
+	}
+	if sddl == "" {
+		return errors.New("security descriptor cannot be empty")
+	}
+
+	return s.engine.SetSublayerDACL(id, sddl)
+}
+
+// RuleSecurity returns the security descriptor of the rule whose GUID
+// is id.
+func (s *Session) RuleSecurity(id RuleID) (string, error) {
+	if id.IsZero() {
+		return "", errors.New("GUID cannot be zero")
+	}
+
+	return s.engine.RuleSecurity(id)
+}
+
+// SetRuleDACL replaces the DACL of the rule whose GUID is id with the
+// DACL of the security descriptor sddl. The owner, group and SACL of
+// sddl are ignored.
+func (s *Session) SetRuleDACL(id RuleID, sddl string) error {
+	if id.IsZero() {
+		return errors.New("GUID cannot be zero")
+	}
+	if sddl == "" {
+		return errors.New("security descriptor cannot be empty")
+	}
+
+	return s.engine.SetRuleDACL(id, sddl)
+}
+
+// sddlComponents are the components of an SDDL security descriptor,
+// in the order they are written.
+const sddlComponents = "OGDS"
+
+// parseSDDL splits the SDDL security descriptor s into its owner,
+// group, DACL and SACL components, keyed by 'O', 'G', 'D' and 'S'.
+// Each value is the component without its "X:" prefix. The components
+// themselves aren't checked.
+func parseSDDL(s string) (map[byte]string, error) {
+	ret := map[byte]string{}
+	var (
+		cur   byte
+		start int
+		depth int
+	)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && i+1 < len(s) && s[i+1] == ':' && strings.IndexByte(sddlComponents, c) >= 0:
+			if cur != 0 {
+				ret[cur] = s[start:i]
+			} else if i != 0 {
+				return nil, fmt.Errorf("invalid security descriptor %q", s)
+			}
+			if _, ok := ret[c]; ok {
+				return nil, fmt.Errorf("security descriptor %q has more than one %c: component", s, c)
+			}
+			cur, start = c, i+2
+			i++
+		}
+		if depth < 0 {
+			return nil, fmt.Errorf("unbalanced parentheses in security descriptor %q", s)
+		}
+	}
+	if cur == 0 || depth != 0 {
+		return nil, fmt.Errorf("invalid security descriptor %q", s)
+	}
+	ret[cur] = s[start:]
+	return ret, nil
+}
+
+// formatSDDL is the inverse of parseSDDL.
+func formatSDDL(parts map[byte]string) string {
+	var b strings.Builder
+	for i := 0; i < len(sddlComponents); i++ {
+		c := sddlComponents[i]
+		if v, ok := parts[c]; ok {
+			b.WriteByte(c)
+			b.WriteByte(':')
+			b.WriteString(v)
+		}
+	}
+	return b.String()
+}
+
+// replaceDACL returns the security descriptor cur with its DACL
+// replaced by the DACL of the security descriptor sddl.
+func replaceDACL(cur, sddl string) (string, error) {
+	parts, err := parseSDDL(cur)
+	if err != nil {
+		return "", err
+	}
+	from, err := parseSDDL(sddl)
+	if err != nil {
+		return "", err
+	}
+	dacl, ok := from['D']
+	if !ok {
+		return "", fmt.Errorf("security descriptor %q has no DACL", sddl)
+	}
+	parts['D'] = dacl
+	return formatSDDL(parts), nil
+}
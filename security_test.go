@@ -0,0 +1,91 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import "testing"
+
+func TestReplaceDACL(t *testing.T) {
+	tests := []struct {
+		name string
+		cur  string
+		sddl string
+		want string
+		err  bool
+	}{
+		{
+			name: "replace",
+			cur:  "O:BAG:SYD:(A;;GA;;;BA)",
+			sddl: "D:P(A;;GA;;;SY)",
+			want: "O:BAG:SYD:P(A;;GA;;;SY)",
+		},
+		{
+			name: "ignore_owner_and_sacl",
+			cur:  "O:BAG:SYD:(A;;GA;;;BA)",
+			sddl: "O:SYD:(A;;GA;;;SY)S:(AU;FA;GA;;;WD)",
+			want: "O:BAG:SYD:(A;;GA;;;SY)",
+		},
+		{
+			name: "keep_sacl",
+			cur:  "O:BAD:(A;;GA;;;BA)S:(AU;FA;GA;;;WD)",
+			sddl: "D:(A;;GR;;;WD)",
+			want: "O:BAD:(A;;GR;;;WD)S:(AU;FA;GA;;;WD)",
+		},
+		{
+			// Colons inside ACEs aren't component boundaries.
+			name: "conditional_ace",
+			cur:  "O:BAD:(A;;GA;;;BA)",
+			sddl: `D:(XA;;GA;;;WD;(@User.Dept=="D:1"))`,
+			want: `O:BAD:(XA;;GA;;;WD;(@User.Dept=="D:1"))`,
+		},
+		{
+			name: "add_dacl",
+			cur:  "O:BAG:SY",
+			sddl: "D:",
+			want: "O:BAG:SYD:",
+		},
+		{
+			name: "no_dacl",
+			cur:  "O:BAD:(A;;GA;;;BA)",
+			sddl: "O:SY",
+			err:  true,
+		},
+		{
+			name: "garbage",
+			cur:  "O:BAD:(A;;GA;;;BA)",
+			sddl: "(A;;GA;;;SY)D:",
+			err:  true,
+		},
+		{
+			name: "duplicate",
+			cur:  "O:BAD:(A;;GA;;;BA)",
+			sddl: "D:(A;;GA;;;SY)D:(A;;GA;;;BA)",
+			err:  true,
+		},
+		{
+			name: "unbalanced",
+			cur:  "O:BAD:(A;;GA;;;BA)",
+			sddl: "D:(A;;GA;;;SY",
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := replaceDACL(test.cur, test.sddl)
+			if test.err {
+				if err == nil {
+					t.Fatalf("replaceDACL(%q, %q) = %q, want error", test.cur, test.sddl, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("replaceDACL(%q, %q) = %q, want %q", test.cur, test.sddl, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// securityInfo is the part of a security descriptor that the Security
+// methods read. The SACL is left out, since reading it requires
+// SeSecurityPrivilege.
+const securityInfo = windows.OWNER_SECURITY_INFORMATION | windows.GROUP_SECURITY_INFORMATION | windows.DACL_SECURITY_INFORMATION
+
+func (e *winEngine) ProviderSecurity(id ProviderID) (string, error) {
+	return getSecurity(func(sd **windows.SECURITY_DESCRIPTOR) error {
+		return fwpmProviderGetSecurityInfoByKey0(e.handle, &id, securityInfo, nil, nil, nil, nil, sd)
+	})
+}
+
+func (e *winEngine) SetProviderDACL(id ProviderID, sddl string) error {
+	return setDACL(sddl, func(info windows.SECURITY_INFORMATION, dacl *windows.ACL) error {
+		return fwpmProviderSetSecurityInfoByKey0(e.handle, &id, info, nil, nil, dacl, nil)
+	})
+}
+
+func (e *winEngine) SublayerSecurity(id SublayerID) (string, error) {
+	return getSecurity(func(sd **windows.SECURITY_DESCRIPTOR) error {
+		return fwpmSubLayerGetSecurityInfoByKey0(e.handle, &id, securityInfo, nil, nil, nil, nil, sd)
+	})
+}
+
+func (e *winEngine) SetSublayerDACL(id SublayerID, sddl string) error {
+	return setDACL(sddl, func(info windows.SECURITY_INFORMATION, dacl *windows.ACL) error {
+		return fwpmSubLayerSetSecurityInfoByKey0(e.handle, &id, info, nil, nil, dacl, nil)
+	})
+}
+
+func (e *winEngine) RuleSecurity(id RuleID) (string, error) {
+	return getSecurity(func(sd **windows.SECURITY_DESCRIPTOR) error {
+		return fwpmFilterGetSecurityInfoByKey0(e.handle, &id, securityInfo, nil, nil, nil, nil, sd)
+	})
+}
+
+func (e *winEngine) SetRuleDACL(id RuleID, sddl string) error {
+	return setDACL(sddl, func(info windows.SECURITY_INFORMATION, dacl *windows.ACL) error {
+		return fwpmFilterSetSecurityInfoByKey0(e.handle, &id, info, nil, nil, dacl, nil)
+	})
+}
+
+// fromSDDL returns the security descriptor sddl, or nil if sddl is
+// empty, which makes WFP apply its default security descriptor.
+func fromSDDL(sddl string) (*windows.SECURITY_DESCRIPTOR, error) {
+	if sddl == "" {
+		return nil, nil
+	}
+	sd, err := windows.SecurityDescriptorFromString(sddl)
+	if err != nil {
+		return nil, fmt.Errorf("parsing security descriptor %q: %w", sddl, err)
+	}
+	return sd, nil
+}
+
+// getSecurity calls get to fetch a security descriptor allocated by
+// WFP, and returns it in SDDL form.
+func getSecurity(get func(sd **windows.SECURITY_DESCRIPTOR) error) (string, error) {
+	var sd *windows.SECURITY_DESCRIPTOR
+	if err := get(&sd); err != nil {
+		return "", err
+	}
+	defer fwpmFreeMemory0((*struct{})(unsafe.Pointer(&sd)))
+
+	return sd.String(), nil
+}
+
+// setDACL calls set with the DACL of the security descriptor sddl,
+// and the security information that describes it.
+func setDACL(sddl string, set func(info windows.SECURITY_INFORMATION, dacl *windows.ACL) error) error {
+	sd, err := fromSDDL(sddl)
+	if err != nil {
+		return err
+	}
+	dacl, _, err := sd.DACL()
+	if err != nil {
+		return fmt.Errorf("getting DACL of security descriptor %q: %w", sddl, err)
+	}
+	info := windows.SECURITY_INFORMATION(windows.DACL_SECURITY_INFORMATION)
+	if control, _, err := sd.Control(); err == nil && control&windows.SE_DACL_PROTECTED != 0 {
+		info |= windows.PROTECTED_DACL_SECURITY_INFORMATION
+	} else {
+		info |= windows.UNPROTECTED_DACL_SECURITY_INFORMATION
+	}
+	err = set(info, dacl)
+	// dacl points into sd.
+	runtime.KeepAlive(sd)
+	return err
+}
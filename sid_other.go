@@ -0,0 +1,55 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package wf
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SID is a Windows security identifier in string form, such as
+// "S-1-5-18". It is used for SID values on platforms other than
+// Windows, which use *windows.SID instead.
+type SID string
+
+// SecurityDescriptor is a Windows security descriptor in SDDL
+// form. It is used for security descriptor values on platforms other
+// than Windows, which use *windows.SECURITY_DESCRIPTOR instead.
+type SecurityDescriptor string
+
+var (
+	typeSID                = reflect.TypeOf(SID(""))
+	typeSecurityDescriptor = reflect.TypeOf(SecurityDescriptor(""))
+)
+
+// sidString returns the string form of the SID value v.
+func sidString(v interface{}) (string, bool) {
+	sid, ok := v.(SID)
+	return string(sid), ok
+}
+
+// sidFromString parses the string form of a SID into a SID value.
+func sidFromString(s string) (interface{}, error) {
+	if !strings.HasPrefix(s, "S-") {
+		return nil, fmt.Errorf("invalid SID %q", s)
+	}
+	return SID(s), nil
+}
+
+// sdString returns the SDDL form of the security descriptor value v.
+func sdString(v interface{}) (string, bool) {
+	sd, ok := v.(SecurityDescriptor)
+	return string(sd), ok
+}
+
+// sdFromString parses an SDDL string into a security descriptor
+// value.
+func sdFromString(s string) (interface{}, error) {
+	return SecurityDescriptor(s), nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"reflect"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	typeSID                = reflect.TypeOf(&windows.SID{})
+	typeSecurityDescriptor = reflect.TypeOf(windows.SECURITY_DESCRIPTOR{})
+)
+
+// sidString returns the string form of the SID value v.
+func sidString(v interface{}) (string, bool) {
+	sid, ok := v.(*windows.SID)
+	if !ok {
+		return "", false
+	}
+	return sid.String(), true
+}
+
+// sidFromString parses the string form of a SID into a SID value.
+func sidFromString(s string) (interface{}, error) {
+	return windows.StringToSid(s)
+}
+
+// sdString returns the SDDL form of the security descriptor value v.
+func sdString(v interface{}) (string, bool) {
+	sd, ok := v.(*windows.SECURITY_DESCRIPTOR)
+	if !ok {
+		return "", false
+	}
+	return sd.String(), true
+}
+
+// sdFromString parses an SDDL string into a security descriptor
+// value.
+func sdFromString(s string) (interface{}, error) {
+	return windows.SecurityDescriptorFromString(s)
+}
@@ -0,0 +1,474 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"bytes"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"go4.org/netipx"
+)
+
+// Connection describes a synthetic connection, to be classified by a
+// Simulator.
+type Connection struct {
+	// Layer is the layer at which the connection is classified.
+	Layer LayerID
+	// Protocol is the IP protocol of the connection. It provides
+	// the value of FieldIPProtocol.
+	Protocol IPProto
+	// LocalAddr and RemoteAddr are the endpoints of the
+	// connection. They provide the values of FieldIPLocalAddress,
+	// FieldIPLocalPort, FieldIPRemoteAddress and FieldIPRemotePort.
+	// Invalid addresses provide no values.
+	LocalAddr  netip.AddrPort
+	RemoteAddr netip.AddrPort
+	// AppID is the application ID of the process that owns the
+	// connection, as returned by AppID. It provides the value of
+	// FieldALEAppID if non-empty.
+	AppID string
+	// Flags provides the value of FieldFlags.
+	Flags ConditionFlag
+	// Fields provides values for any other fields. Values in Fields
+	// take precedence over the ones derived from the fields above.
+	Fields map[FieldID]interface{}
+}
+
+// value returns the value of the field id for c, and whether c has
+// a value for that field.
+func (c *Connection) value(id FieldID) (interface{}, bool) {
+	if v, ok := c.Fields[id]; ok {
+		return v, true
+	}
+	switch id {
+	case FieldIPProtocol:
+		return uint8(c.Protocol), true
+	case FieldIPLocalAddress:
+		return c.LocalAddr.Addr(), c.LocalAddr.IsValid()
+	case FieldIPLocalPort:
+		return c.LocalAddr.Port(), c.LocalAddr.IsValid()
+	case FieldIPRemoteAddress:
+		return c.RemoteAddr.Addr(), c.RemoteAddr.IsValid()
+	case FieldIPRemotePort:
+		return c.RemoteAddr.Port(), c.RemoteAddr.IsValid()
+	case FieldALEAppID:
+		return c.AppID, c.AppID != ""
+	case FieldFlags:
+		return uint32(c.Flags), true
+	}
+	return nil, false
+}
+
+// Simulator reproduces the filter arbitration of WFP, to classify
+// connections against a set of rules without a filtering engine.
+//
+// Sublayers are evaluated in order of decreasing Weight, and the
+// rules of each sublayer in order of decreasing Weight. Within a
+// sublayer, the first matching rule with a terminating action
+// decides the sublayer's action. Across sublayers, a Block overrides
+// a Permit and ends the evaluation, except that a HardAction Permit
+// can only be overridden by a HardAction Block (a veto). A
+// connection that no rule decides is permitted.
+type Simulator struct {
+	// Sublayers are the sublayers in which rules can be evaluated.
+	// Rules in a sublayer that isn't listed are evaluated as if the
+	// sublayer had a weight of zero.
+	Sublayers []*Sublayer
+	// Rules are the rules to evaluate. Disabled and BootTime rules
+	// are ignored.
+	Rules []*Rule
+	// Callouts maps the registered callouts to the action they
+	// return: ActionPermit, ActionBlock, or zero to let evaluation
+	// continue. Rules that invoke a callout missing from Callouts
+	// behave as ActionPermit if their PermitIfMissing is set, and as
+	// ActionBlock otherwise.
+	Callouts map[CalloutID]Action
+}
+
+// Verdict is the result of classifying a Connection.
+type Verdict struct {
+	// Action is the final action, ActionPermit or ActionBlock.
+	Action Action
+	// Rule is the rule that decided Action, or nil if no rule
+	// decided the connection.
+	Rule *Rule
+	// Veto indicates that Action is a Block that overrode a
+	// HardAction Permit from a higher-priority sublayer.
+	Veto bool
+	// Sublayers is the trace of the evaluation, in order of
+	// evaluation. Sublayers that were not reached are omitted.
+	Sublayers []*SublayerTrace
+}
+
+// SublayerTrace records the evaluation of one sublayer.
+type SublayerTrace struct {
+	// Sublayer is the sublayer that was evaluated.
+	Sublayer SublayerID
+	// Rules records the rules that were evaluated, in order of
+	// evaluation.
+	Rules []*RuleTrace
+	// Action is the action decided by the sublayer, ActionPermit or
+	// ActionBlock, or zero if no rule decided.
+	Action Action
+	// Rule is the rule that decided Action, or nil.
+	Rule *Rule
+	// Overridden indicates that Action was not applied, because a
+	// higher-priority sublayer made a HardAction Permit.
+	Overridden bool
+}
+
+// RuleTrace records the evaluation of one rule.
+type RuleTrace struct {
+	// Rule is the rule that was evaluated.
+	Rule *Rule
+	// Matched indicates whether the rule's conditions matched.
+	Matched bool
+	// Action is the action the rule produced, after invoking its
+	// callout if any, or zero if it didn't match or let evaluation
+	// continue.
+	Action Action
+}
+
+// Classify returns the verdict for c.
+func (s *Simulator) Classify(c *Connection) *Verdict {
+	ret := &Verdict{Action: ActionPermit}
+	hardPermit := false
+	for _, sl := range s.sublayerOrder(c.Layer) {
+		tr := s.evalSublayer(sl.id, sl.rules, c)
+		ret.Sublayers = append(ret.Sublayers, tr)
+
+		switch tr.Action {
+		case ActionPermit:
+			if ret.Rule == nil {
+				ret.Rule = tr.Rule
+			}
+			if tr.Rule.HardAction {
+				hardPermit = true
+			}
+		case ActionBlock:
+			if hardPermit && !tr.Rule.HardAction {
+				tr.Overridden = true
+				continue
+			}
+			ret.Action = ActionBlock
+			ret.Rule = tr.Rule
+			ret.Veto = hardPermit
+			return ret
+		}
+	}
+	return ret
+}
+
+// simSublayer is a sublayer and the rules to evaluate in it.
+type simSublayer struct {
+	id     SublayerID
+	weight uint16
+	rules  []*Rule
+}
+
+// sublayerOrder returns the sublayers that have rules in layer, and
+// those rules, in evaluation order.
+func (s *Simulator) sublayerOrder(layer LayerID) []*simSublayer {
+	weights := map[SublayerID]uint16{}
+	for _, sl := range s.Sublayers {
+		weights[sl.ID] = sl.Weight
+	}
+
+	bySublayer := map[SublayerID]*simSublayer{}
+	var ret []*simSublayer
+	for _, r := range s.Rules {
+		if r.Layer != layer || r.Disabled || r.BootTime {
+			continue
+		}
+		id := r.Sublayer
+		if id.IsZero() {
+			id = guidSublayerUniversal
+		}
+		sl := bySublayer[id]
+		if sl == nil {
+			sl = &simSublayer{id: id, weight: weights[id]}
+			bySublayer[id] = sl
+			ret = append(ret, sl)
+		}
+		sl.rules = append(sl.rules, r)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].weight != ret[j].weight {
+			return ret[i].weight > ret[j].weight
+		}
+		return ret[i].id.String() < ret[j].id.String()
+	})
+	for _, sl := range ret {
+		rules := sl.rules
+		sort.SliceStable(rules, func(i, j int) bool {
+			if rules[i].Weight != rules[j].Weight {
+				return rules[i].Weight > rules[j].Weight
+			}
+			return rules[i].KernelID < rules[j].KernelID
+		})
+	}
+	return ret
+}
+
+// evalSublayer evaluates rules, which all belong to the sublayer id,
+// against c.
+func (s *Simulator) evalSublayer(id SublayerID, rules []*Rule, c *Connection) *SublayerTrace {
+	ret := &SublayerTrace{Sublayer: id}
+	for _, r := range rules {
+		rt := &RuleTrace{Rule: r, Matched: conditionsMatch(r.Conditions, c)}
+		ret.Rules = append(ret.Rules, rt)
+		if !rt.Matched {
+			continue
+		}
+		rt.Action = s.ruleAction(r)
+		if rt.Action == ActionPermit || rt.Action == ActionBlock {
+			ret.Action = rt.Action
+			ret.Rule = r
+			return ret
+		}
+	}
+	return ret
+}
+
+// ruleAction returns the action taken by a matching rule r:
+// ActionPermit, ActionBlock, or zero to continue evaluation.
+func (s *Simulator) ruleAction(r *Rule) Action {
+	switch r.Action {
+	case ActionPermit, ActionBlock:
+		return r.Action
+	case ActionCalloutInspection:
+		return 0
+	case ActionCalloutTerminating, ActionCalloutUnknown:
+		act, ok := s.Callouts[r.Callout]
+		if !ok {
+			if r.PermitIfMissing {
+				return ActionPermit
+			}
+			return ActionBlock
+		}
+		if act == 0 && r.Action == ActionCalloutTerminating {
+			// A terminating callout must decide. Treat a
+			// callout that doesn't as a block, like WFP does
+			// for callouts that fail.
+			return ActionBlock
+		}
+		return act
+	}
+	return 0
+}
+
+// conditionsMatch reports whether c matches all of ms. Matches on
+// different fields must all succeed, and at least one of the matches
+// on each field must succeed.
+func conditionsMatch(ms []*Match, c *Connection) bool {
+	byField := map[FieldID]bool{}
+	var fields []FieldID
+	for _, m := range ms {
+		ok, seen := byField[m.Field]
+		if !seen {
+			fields = append(fields, m.Field)
+		}
+		if ok {
+			continue
+		}
+		byField[m.Field] = matchValue(m, c)
+	}
+	for _, f := range fields {
+		if !byField[f] {
+			return false
+		}
+	}
+	return true
+}
+
+// matchValue reports whether c's value for m.Field satisfies m.
+func matchValue(m *Match, c *Connection) bool {
+	v, ok := c.value(m.Field)
+	if !ok {
+		return false
+	}
+	switch m.Op {
+	case MatchTypeEqual:
+		return simEqual(v, m.Value)
+	case MatchTypeNotEqual:
+		return !simEqual(v, m.Value)
+	case MatchTypeEqualCaseInsensitive:
+		a, aok := v.(string)
+		b, bok := m.Value.(string)
+		return aok && bok && strings.EqualFold(a, b)
+	case MatchTypeGreater, MatchTypeLess, MatchTypeGreaterOrEqual, MatchTypeLessOrEqual:
+		cmp, ok := simCompare(v, m.Value)
+		if !ok {
+			return false
+		}
+		switch m.Op {
+		case MatchTypeGreater:
+			return cmp > 0
+		case MatchTypeLess:
+			return cmp < 0
+		case MatchTypeGreaterOrEqual:
+			return cmp >= 0
+		default:
+			return cmp <= 0
+		}
+	case MatchTypeRange:
+		return simInRange(v, m.Value)
+	case MatchTypeFlagsAllSet, MatchTypeFlagsAnySet, MatchTypeFlagsNoneSet:
+		a, aok := simUint(v)
+		b, bok := simUint(m.Value)
+		if !aok || !bok {
+			return false
+		}
+		switch m.Op {
+		case MatchTypeFlagsAllSet:
+			return a&b == b
+		case MatchTypeFlagsAnySet:
+			return a&b != 0
+		default:
+			return a&b == 0
+		}
+	case MatchTypePrefix:
+		return simHasPrefix(v, m.Value)
+	case MatchTypeNotPrefix:
+		return !simHasPrefix(v, m.Value)
+	}
+	return false
+}
+
+// simEqual reports whether the field value v equals the match value
+// want. As in WFP, an address equals a prefix or range that
+// contains it.
+func simEqual(v, want interface{}) bool {
+	switch want.(type) {
+	case netip.Prefix:
+		return simHasPrefix(v, want)
+	case Range, netipx.IPRange:
+		return simInRange(v, want)
+	}
+	cmp, ok := simCompare(v, want)
+	return ok && cmp == 0
+}
+
+// simInRange reports whether the field value v is within the range
+// r, which is a Range or netipx.IPRange.
+func simInRange(v, r interface{}) bool {
+	var from, to interface{}
+	switch r := r.(type) {
+	case Range:
+		from, to = r.From, r.To
+	case netipx.IPRange:
+		from, to = r.From(), r.To()
+	default:
+		return false
+	}
+	lo, ok := simCompare(v, from)
+	if !ok || lo < 0 {
+		return false
+	}
+	hi, ok := simCompare(v, to)
+	return ok && hi <= 0
+}
+
+// simHasPrefix reports whether the field value v starts with the
+// prefix p. Addresses match prefixes that contain them, strings and
+// byte slices match leading substrings.
+func simHasPrefix(v, p interface{}) bool {
+	switch p := p.(type) {
+	case netip.Prefix:
+		a, ok := v.(netip.Addr)
+		return ok && p.Contains(a)
+	case string:
+		s, ok := v.(string)
+		return ok && strings.HasPrefix(s, p)
+	case []byte:
+		bs, ok := simBytes(v)
+		return ok && bytes.HasPrefix(bs, p)
+	}
+	return false
+}
+
+// simCompare compares the field value a with the match value b, and
+// reports whether they are comparable at all.
+func simCompare(a, b interface{}) (int, bool) {
+	if x, ok := simUint(a); ok {
+		y, ok := simUint(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	}
+	switch x := a.(type) {
+	case netip.Addr:
+		y, ok := b.(netip.Addr)
+		if !ok || x.Is4() != y.Is4() {
+			return 0, false
+		}
+		return x.Compare(y), true
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(x, y), true
+	}
+	if x, ok := sidString(a); ok {
+		y, ok := sidString(b)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(x, y), true
+	}
+	x, ok := simBytes(a)
+	if !ok {
+		return 0, false
+	}
+	y, ok := simBytes(b)
+	if !ok {
+		return 0, false
+	}
+	return bytes.Compare(x, y), true
+}
+
+// simUint returns v as a uint64, if v is an integer type.
+func simUint(v interface{}) (uint64, bool) {
+	switch v := v.(type) {
+	case uint8:
+		return uint64(v), true
+	case uint16:
+		return uint64(v), true
+	case uint32:
+		return uint64(v), true
+	case uint64:
+		return v, true
+	case IPProto:
+		return uint64(v), true
+	case ConditionFlag:
+		return uint64(v), true
+	}
+	return 0, false
+}
+
+// simBytes returns v as a byte slice, if v is a byte array type.
+func simBytes(v interface{}) ([]byte, bool) {
+	switch v := v.(type) {
+	case []byte:
+		return v, true
+	case [16]byte:
+		return v[:], true
+	case net.HardwareAddr:
+		return v, true
+	}
+	return nil, false
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"net/netip"
+	"testing"
+
+	"go4.org/netipx"
+)
+
+func TestSimulatorMatch(t *testing.T) {
+	conn := &Connection{
+		Layer:      LayerALEAuthConnectV4,
+		Protocol:   IPProtoTCP,
+		LocalAddr:  netip.MustParseAddrPort("10.0.0.2:51234"),
+		RemoteAddr: netip.MustParseAddrPort("192.168.1.10:443"),
+		AppID:      `\device\harddiskvolume1\app.exe`,
+		Flags:      ConditionFlagIsLoopback | ConditionFlagIsReauthorize,
+	}
+
+	tests := []struct {
+		name string
+		m    *Match
+		want bool
+	}{
+		{"equal", &Match{FieldIPRemotePort, MatchTypeEqual, uint16(443)}, true},
+		{"not equal", &Match{FieldIPRemotePort, MatchTypeNotEqual, uint16(443)}, false},
+		{"proto", &Match{FieldIPProtocol, MatchTypeEqual, IPProtoTCP}, true},
+		{"greater", &Match{FieldIPLocalPort, MatchTypeGreater, uint16(50000)}, true},
+		{"less", &Match{FieldIPLocalPort, MatchTypeLess, uint16(50000)}, false},
+		{"greater or equal", &Match{FieldIPRemotePort, MatchTypeGreaterOrEqual, uint16(443)}, true},
+		{"less or equal", &Match{FieldIPRemotePort, MatchTypeLessOrEqual, uint16(442)}, false},
+		{"range", &Match{FieldIPRemotePort, MatchTypeRange, Range{uint16(400), uint16(500)}}, true},
+		{"ip range", &Match{FieldIPRemoteAddress, MatchTypeRange, netipx.IPRangeFrom(netip.MustParseAddr("192.168.1.1"), netip.MustParseAddr("192.168.1.9"))}, false},
+		{"equal prefix", &Match{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParsePrefix("192.168.0.0/16")}, true},
+		{"prefix", &Match{FieldIPLocalAddress, MatchTypePrefix, netip.MustParsePrefix("10.0.0.0/8")}, true},
+		{"not prefix", &Match{FieldIPLocalAddress, MatchTypeNotPrefix, netip.MustParsePrefix("10.0.0.0/8")}, false},
+		{"all set", &Match{FieldFlags, MatchTypeFlagsAllSet, ConditionFlagIsLoopback | ConditionFlagIsReauthorize}, true},
+		{"any set", &Match{FieldFlags, MatchTypeFlagsAnySet, ConditionFlagIsLoopback | ConditionFlagIsWildcardBind}, true},
+		{"none set", &Match{FieldFlags, MatchTypeFlagsNoneSet, ConditionFlagIsLoopback}, false},
+		{"case insensitive", &Match{FieldALEAppID, MatchTypeEqualCaseInsensitive, `\DEVICE\HARDDISKVOLUME1\APP.EXE`}, true},
+		{"missing field", &Match{FieldALEUserID, MatchTypeEqual, "x"}, false},
+		{"integer width", &Match{FieldIPRemotePort, MatchTypeEqual, uint32(443)}, true},
+		{"family mismatch", &Match{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParseAddr("::1")}, false},
+	}
+	for _, test := range tests {
+		if got := matchValue(test.m, conn); got != test.want {
+			t.Errorf("%s: matchValue(%v) = %v, want %v", test.name, test.m, got, test.want)
+		}
+	}
+}
+
+func TestSimulatorClassify(t *testing.T) {
+	high := &Sublayer{ID: SublayerID{Data1: 1}, Weight: 10}
+	low := &Sublayer{ID: SublayerID{Data1: 2}, Weight: 1}
+	conn := &Connection{
+		Layer:      LayerALEAuthConnectV4,
+		Protocol:   IPProtoUDP,
+		RemoteAddr: netip.MustParseAddrPort("8.8.8.8:53"),
+	}
+	dns := []*Match{
+		{FieldIPRemotePort, MatchTypeEqual, uint16(53)},
+		{FieldIPProtocol, MatchTypeEqual, IPProtoUDP},
+		{FieldIPProtocol, MatchTypeEqual, IPProtoTCP},
+	}
+	rule := func(id uint32, sl *Sublayer, weight uint64, action Action, hard bool, conds []*Match) *Rule {
+		return &Rule{
+			ID:         RuleID{Data1: id},
+			Layer:      LayerALEAuthConnectV4,
+			Sublayer:   sl.ID,
+			Weight:     weight,
+			Conditions: conds,
+			Action:     action,
+			HardAction: hard,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		rules    []*Rule
+		callouts map[CalloutID]Action
+		want     Action
+		wantRule uint32
+		wantVeto bool
+	}{
+		{
+			name: "no rules",
+			want: ActionPermit,
+		},
+		{
+			name: "rule weight",
+			rules: []*Rule{
+				rule(1, high, 1, ActionPermit, false, nil),
+				rule(2, high, 2, ActionBlock, false, dns),
+			},
+			want:     ActionBlock,
+			wantRule: 2,
+		},
+		{
+			name: "conditions",
+			rules: []*Rule{
+				rule(1, high, 2, ActionBlock, false, []*Match{{FieldIPRemotePort, MatchTypeEqual, uint16(80)}}),
+				rule(2, high, 1, ActionPermit, false, dns),
+			},
+			want:     ActionPermit,
+			wantRule: 2,
+		},
+		{
+			name: "block overrides soft permit",
+			rules: []*Rule{
+				rule(1, high, 1, ActionPermit, false, nil),
+				rule(2, low, 1, ActionBlock, false, nil),
+			},
+			want:     ActionBlock,
+			wantRule: 2,
+		},
+		{
+			name: "hard permit",
+			rules: []*Rule{
+				rule(1, high, 1, ActionPermit, true, nil),
+				rule(2, low, 1, ActionBlock, false, nil),
+			},
+			want:     ActionPermit,
+			wantRule: 1,
+		},
+		{
+			name: "veto",
+			rules: []*Rule{
+				rule(1, high, 1, ActionPermit, true, nil),
+				rule(2, low, 1, ActionBlock, true, nil),
+			},
+			want:     ActionBlock,
+			wantRule: 2,
+			wantVeto: true,
+		},
+		{
+			name: "block is final",
+			rules: []*Rule{
+				rule(1, high, 1, ActionBlock, false, nil),
+				rule(2, low, 1, ActionPermit, true, nil),
+			},
+			want:     ActionBlock,
+			wantRule: 1,
+		},
+		{
+			name: "missing callout",
+			rules: []*Rule{
+				{ID: RuleID{Data1: 1}, Layer: LayerALEAuthConnectV4, Sublayer: high.ID, Action: ActionCalloutTerminating, Callout: CalloutID{Data1: 1}},
+			},
+			want:     ActionBlock,
+			wantRule: 1,
+		},
+		{
+			name: "missing callout permit",
+			rules: []*Rule{
+				{ID: RuleID{Data1: 1}, Layer: LayerALEAuthConnectV4, Sublayer: high.ID, Action: ActionCalloutTerminating, Callout: CalloutID{Data1: 1}, PermitIfMissing: true},
+				rule(2, low, 1, ActionBlock, false, nil),
+			},
+			want:     ActionBlock,
+			wantRule: 2,
+		},
+		{
+			name: "inspection callout",
+			rules: []*Rule{
+				{ID: RuleID{Data1: 1}, Layer: LayerALEAuthConnectV4, Sublayer: high.ID, Weight: 2, Action: ActionCalloutInspection, Callout: CalloutID{Data1: 1}},
+				rule(2, high, 1, ActionPermit, false, nil),
+			},
+			callouts: map[CalloutID]Action{{Data1: 1}: 0},
+			want:     ActionPermit,
+			wantRule: 2,
+		},
+		{
+			name: "disabled rule",
+			rules: []*Rule{
+				{ID: RuleID{Data1: 1}, Layer: LayerALEAuthConnectV4, Action: ActionBlock, Disabled: true},
+				{ID: RuleID{Data1: 2}, Layer: LayerALEAuthConnectV6, Action: ActionBlock},
+			},
+			want: ActionPermit,
+		},
+	}
+	for _, test := range tests {
+		s := &Simulator{
+			Sublayers: []*Sublayer{low, high},
+			Rules:     test.rules,
+			Callouts:  test.callouts,
+		}
+		v := s.Classify(conn)
+		if v.Action != test.want {
+			t.Errorf("%s: got action %s, want %s", test.name, v.Action, test.want)
+		}
+		var gotRule uint32
+		if v.Rule != nil {
+			gotRule = v.Rule.ID.Data1
+		}
+		if gotRule != test.wantRule {
+			t.Errorf("%s: got deciding rule %d, want %d", test.name, gotRule, test.wantRule)
+		}
+		if v.Veto != test.wantVeto {
+			t.Errorf("%s: got veto %v, want %v", test.name, v.Veto, test.wantVeto)
+		}
+	}
+}
+
+func TestSimulatorTrace(t *testing.T) {
+	high := &Sublayer{ID: SublayerID{Data1: 1}, Weight: 10}
+	low := &Sublayer{ID: SublayerID{Data1: 2}, Weight: 1}
+	s := &Simulator{
+		Sublayers: []*Sublayer{low, high},
+		Rules: []*Rule{
+			{ID: RuleID{Data1: 1}, Layer: LayerALEAuthConnectV4, Sublayer: low.ID, Action: ActionBlock},
+			{ID: RuleID{Data1: 2}, Layer: LayerALEAuthConnectV4, Sublayer: high.ID, Weight: 2, Action: ActionBlock, Conditions: []*Match{{FieldIPRemotePort, MatchTypeEqual, uint16(80)}}},
+			{ID: RuleID{Data1: 3}, Layer: LayerALEAuthConnectV4, Sublayer: high.ID, Weight: 1, Action: ActionPermit, HardAction: true},
+		},
+	}
+	v := s.Classify(&Connection{
+		Layer:      LayerALEAuthConnectV4,
+		RemoteAddr: netip.MustParseAddrPort("1.2.3.4:443"),
+	})
+	if len(v.Sublayers) != 2 {
+		t.Fatalf("got %d sublayers in trace, want 2", len(v.Sublayers))
+	}
+	first, second := v.Sublayers[0], v.Sublayers[1]
+	if first.Sublayer != high.ID || len(first.Rules) != 2 || first.Rules[0].Matched || !first.Rules[1].Matched || first.Action != ActionPermit {
+		t.Fatalf("wrong trace for first sublayer: %+v", first)
+	}
+	if second.Sublayer != low.ID || second.Action != ActionBlock || !second.Overridden {
+		t.Fatalf("wrong trace for second sublayer: %+v", second)
+	}
+}
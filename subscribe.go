@@ -0,0 +1,297 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultNetEventBuffer is the number of events a NetEventSubscription
+// buffers when its NetEventFilter doesn't say.
+const DefaultNetEventBuffer = 1024
+
+// NetEventFilter selects the events delivered to a
+// NetEventSubscription.
+type NetEventFilter struct {
+	// Conditions are matched against the header fields of each
+	// event, as in NetEventEnumTemplate.Conditions. If empty, all
+	// events are delivered.
+	Conditions []*Match
+	// BufferSize is the capacity of the subscription's channel. If
+	// zero, DefaultNetEventBuffer is used.
+	BufferSize int
+}
+
+// A NetEventSubscription delivers net events as the filtering engine
+// records them.
+//
+// The engine doesn't wait for subscribers, so events are buffered in
+// the channel returned by Events. If the buffer is full when an event
+// arrives, the event is dropped and counted by Lost.
+type NetEventSubscription struct {
+	subscription // first, for the alignment of lost
+	events       chan *NetEvent
+}
+
+// SubscribeNetEvents subscribes to the net events that match filter,
+// or to all events if filter is nil.
+//
+// The subscription ends when ctx is done, when it is closed, or when
+// s is closed. The channel returned by Events is then closed, after
+// any buffered events have been received.
+//
+// The filtering engine only records events if its net event
+// collection option is enabled.
+func (s *Session) SubscribeNetEvents(ctx context.Context, filter *NetEventFilter) (*NetEventSubscription, error) {
+	if filter == nil {
+		filter = &NetEventFilter{}
+	}
+	size, err := bufferSize(filter.BufferSize, DefaultNetEventBuffer)
+	if err != nil {
+		return nil, err
+	}
+	tpl := &NetEventEnumTemplate{
+		Conditions: filter.Conditions,
+	}
+	if err := tpl.validate(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sub := &NetEventSubscription{
+		events: make(chan *NetEvent, size),
+	}
+	err = sub.start(ctx, s, func() { close(sub.events) }, func() (func() error, error) {
+		return s.engine.SubscribeNetEvents(tpl, sub.deliver)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// Events returns the channel on which events are delivered. It is
+// closed when the subscription ends.
+func (sub *NetEventSubscription) Events() <-chan *NetEvent {
+	return sub.events
+}
+
+// Lost returns the number of events dropped so far, either because the
+// buffer was full or because they couldn't be decoded.
+func (sub *NetEventSubscription) Lost() uint64 {
+	return sub.lostCount()
+}
+
+// Close ends the subscription, and closes the Events channel. It
+// returns the error, if any, from unsubscribing from the filtering
+// engine.
+func (sub *NetEventSubscription) Close() error {
+	return sub.close()
+}
+
+// deliver queues e on the subscription's channel, or counts it as lost
+// if the channel is full. A nil e is an event that couldn't be
+// decoded, which is also counted as lost. deliver never blocks.
+func (sub *NetEventSubscription) deliver(e *NetEvent) {
+	if e == nil {
+		sub.addLost()
+		return
+	}
+	sub.send(func() bool {
+		select {
+		case sub.events <- e:
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+// bufferSize returns the channel capacity for the requested size,
+// which defaults to def if zero.
+func bufferSize(size, def int) (int, error) {
+	if size < 0 {
+		return 0, errors.New("negative BufferSize")
+	}
+	if size == 0 {
+		return def, nil
+	}
+	return size, nil
+}
+
+// subscription bridges the callbacks of an engine subscription to a
+// channel. It is embedded in the public subscription types, which own
+// the channel.
+type subscription struct {
+	// lost is accessed atomically, and is first for 64-bit
+	// alignment on 32-bit platforms.
+	lost uint64
+
+	session     *Session
+	unsubscribe func() error
+	closeChan   func()
+	done        chan struct{}
+
+	// mu guards closed, and is held while sending to the channel so
+	// that close doesn't close it under a sender.
+	mu     sync.Mutex
+	closed bool
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// start calls subscribe to subscribe to the engine, and arranges for
+// the subscription to be closed when ctx is done or s is closed.
+// closeChan closes the subscription's channel.
+func (sub *subscription) start(ctx context.Context, s *Session, closeChan func(), subscribe func() (unsubscribe func() error, err error)) error {
+	sub.session = s
+	sub.closeChan = closeChan
+	sub.done = make(chan struct{})
+	unsubscribe, err := subscribe()
+	if err != nil {
+		return err
+	}
+	sub.unsubscribe = unsubscribe
+	s.addSub(sub)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.close()
+		case <-sub.done:
+		}
+	}()
+	return nil
+}
+
+// send calls trySend with mu held, unless the subscription is closed,
+// and counts a loss if trySend reports that the channel was full.
+func (sub *subscription) send(trySend func() bool) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if sub.closed {
+		return
+	}
+	if !trySend() {
+		sub.addLost()
+	}
+}
+
+func (sub *subscription) addLost() {
+	atomic.AddUint64(&sub.lost, 1)
+}
+
+func (sub *subscription) lostCount() uint64 {
+	return atomic.LoadUint64(&sub.lost)
+}
+
+// close unsubscribes from the engine and closes the channel.
+func (sub *subscription) close() error {
+	sub.closeOnce.Do(func() {
+		// Unsubscribe first, so that the engine stops calling
+		// back. Holding mu while unsubscribing could deadlock
+		// with an engine that waits for callbacks in progress.
+		sub.closeErr = sub.unsubscribe()
+		sub.session.removeSub(sub)
+
+		sub.mu.Lock()
+		sub.closed = true
+		sub.closeChan()
+		sub.mu.Unlock()
+		close(sub.done)
+	})
+	return sub.closeErr
+}
+
+// addSub registers sub to be closed when s closes.
+func (s *Session) addSub(sub *subscription) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	if s.subs == nil {
+		s.subs = map[*subscription]bool{}
+	}
+	s.subs[sub] = true
+}
+
+// removeSub unregisters sub, once it has closed.
+func (s *Session) removeSub(sub *subscription) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	delete(s.subs, sub)
+}
+
+// closeSubs closes all the subscriptions of s.
+func (s *Session) closeSubs() {
+	s.subsMu.Lock()
+	var subs []*subscription
+	for sub := range s.subs {
+		subs = append(subs, sub)
+	}
+	s.subsMu.Unlock()
+	for _, sub := range subs {
+		sub.close()
+	}
+}
+
+// callbacks routes the notifications that the filtering engine
+// delivers to subscription callbacks. The engine identifies the
+// subscription by an opaque context value, which is a key of this
+// registry, because Go pointers can't be handed to C code that keeps
+// them. Each callback decodes the notification at an address.
+type callbacks struct {
+	mu   sync.Mutex
+	next uintptr
+	fns  map[uintptr]func(mem memReader, addr uint64)
+}
+
+// add registers fn, and returns its context value.
+func (r *callbacks) add(fn func(mem memReader, addr uint64)) uintptr {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fns == nil {
+		r.fns = map[uintptr]func(memReader, uint64){}
+	}
+	r.next++
+	r.fns[r.next] = fn
+	return r.next
+}
+
+// remove unregisters the callback of the context value id.
+func (r *callbacks) remove(id uintptr) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.fns, id)
+}
+
+// dispatch calls the callback of the context value id with the
+// notification at addr in mem. Notifications for unknown context
+// values, such as those racing with an unsubscribe, are ignored.
+func (r *callbacks) dispatch(mem memReader, id uintptr, addr uint64) {
+	r.mu.Lock()
+	fn, ok := r.fns[id]
+	r.mu.Unlock()
+	if ok {
+		fn(mem, addr)
+	}
+}
+
+// netEventCallback returns a callback that decodes FWPM_NET_EVENT
+// structures of the given version, and passes them to deliver.
+// Events that can't be decoded are delivered as nil.
+func netEventCallback(version int, deliver func(*NetEvent)) func(memReader, uint64) {
+	return func(mem memReader, addr uint64) {
+		e, err := decodeNetEvent(mem, addr, version)
+		if err != nil {
+			e = nil
+		}
+		deliver(e)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// receive returns the events buffered in sub, without waiting for more.
+func receive(sub *NetEventSubscription) []*NetEvent {
+	var ret []*NetEvent
+	for {
+		select {
+		case e, ok := <-sub.Events():
+			if !ok {
+				return ret
+			}
+			ret = append(ret, e)
+		default:
+			return ret
+		}
+	}
+}
+
+// waitClosed waits for the Events channel of sub to be closed, and
+// returns the events still buffered in it.
+func waitClosed(t *testing.T, sub *NetEventSubscription) []*NetEvent {
+	t.Helper()
+	var ret []*NetEvent
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case e, ok := <-sub.Events():
+			if !ok {
+				return ret
+			}
+			ret = append(ret, e)
+		case <-timeout:
+			t.Fatal("subscription channel was not closed")
+		}
+	}
+}
+
+func TestSubscribeNetEvents(t *testing.T) {
+	m := NewMemoryEngine(nil)
+	s := newMemorySession(t, m, nil)
+	defer s.Close()
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	addTestEvents(m, start, "9.9.9.9:53")
+
+	all, err := s.SubscribeNetEvents(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer all.Close()
+	cond, err := ParseNetEventMatch("IP_REMOTE_PORT == 443")
+	if err != nil {
+		t.Fatal(err)
+	}
+	https, err := s.SubscribeNetEvents(context.Background(), &NetEventFilter{
+		Conditions: []*Match{cond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer https.Close()
+
+	// Only events recorded after subscribing are delivered.
+	added := addTestEvents(m, start, "1.1.1.1:53", "8.8.8.8:443", "[2001:db8::1]:443")
+	got := receive(all)
+	if diff := cmp.Diff(got, added, netipComparers...); diff != "" {
+		t.Errorf("unfiltered subscription got wrong events (-got+want):\n%s", diff)
+	}
+	if diff := cmp.Diff(remotes(receive(https)), []string{"8.8.8.8:443", "[2001:db8::1]:443"}); diff != "" {
+		t.Errorf("filtered subscription got wrong events (-got+want):\n%s", diff)
+	}
+	if all.Lost() != 0 || https.Lost() != 0 {
+		t.Errorf("subscriptions lost %d and %d events, want none", all.Lost(), https.Lost())
+	}
+
+	// Delivered events are copies.
+	got[0].AppID = "modified"
+	events, err := s.NetEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events[1].AppID != testAppID {
+		t.Error("modifying a delivered event modified the engine's copy")
+	}
+
+	// Closing a subscription closes its channel and stops delivery,
+	// but not to other subscriptions.
+	if err := https.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := https.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+	addTestEvents(m, start, "8.8.4.4:443")
+	if got := waitClosed(t, https); len(got) != 0 {
+		t.Errorf("closed subscription got %v", remotes(got))
+	}
+	if diff := cmp.Diff(remotes(receive(all)), []string{"8.8.4.4:443"}); diff != "" {
+		t.Errorf("open subscription got wrong events (-got+want):\n%s", diff)
+	}
+}
+
+func TestSubscribeNetEventsBackpressure(t *testing.T) {
+	m := NewMemoryEngine(nil)
+	s := newMemorySession(t, m, nil)
+	defer s.Close()
+
+	sub, err := s.SubscribeNetEvents(context.Background(), &NetEventFilter{BufferSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	addTestEvents(m, start, "1.1.1.1:53", "1.1.1.2:53", "1.1.1.3:53", "1.1.1.4:53", "1.1.1.5:53")
+	if got := sub.Lost(); got != 3 {
+		t.Errorf("Lost() = %d, want 3", got)
+	}
+	// The oldest events are kept, and room in the buffer is reused.
+	if diff := cmp.Diff(remotes(receive(sub)), []string{"1.1.1.1:53", "1.1.1.2:53"}); diff != "" {
+		t.Errorf("got wrong events (-got+want):\n%s", diff)
+	}
+	addTestEvents(m, start, "1.1.1.6:53")
+	if diff := cmp.Diff(remotes(receive(sub)), []string{"1.1.1.6:53"}); diff != "" {
+		t.Errorf("got wrong events (-got+want):\n%s", diff)
+	}
+	if got := sub.Lost(); got != 3 {
+		t.Errorf("Lost() = %d, want 3", got)
+	}
+}
+
+func TestSubscribeNetEventsEnd(t *testing.T) {
+	m := NewMemoryEngine(nil)
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// Cancelling the context ends the subscription, and buffered
+	// events can still be received.
+	s := newMemorySession(t, m, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := s.SubscribeNetEvents(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addTestEvents(m, start, "1.1.1.1:53")
+	cancel()
+	if diff := cmp.Diff(remotes(waitClosed(t, sub)), []string{"1.1.1.1:53"}); diff != "" {
+		t.Errorf("got wrong events (-got+want):\n%s", diff)
+	}
+	addTestEvents(m, start, "1.1.1.2:53")
+	if got := sub.Lost(); got != 0 {
+		t.Errorf("ended subscription lost %d events, want 0", got)
+	}
+
+	// Closing the session ends its subscriptions.
+	sub, err = s.SubscribeNetEvents(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	addTestEvents(m, start, "1.1.1.3:53")
+	if got := waitClosed(t, sub); len(got) != 0 {
+		t.Errorf("subscription of closed session got %v", remotes(got))
+	}
+	if err := sub.Close(); err != nil {
+		t.Errorf("closing subscription of closed session: %v", err)
+	}
+
+	// Subscribing with a done context fails.
+	s = newMemorySession(t, m, nil)
+	defer s.Close()
+	if _, err := s.SubscribeNetEvents(ctx, nil); err == nil {
+		t.Error("subscribing with a cancelled context succeeded")
+	}
+}
+
+func TestSubscribeNetEventsInvalid(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(nil), nil)
+	defer s.Close()
+
+	for _, filter := range []*NetEventFilter{
+		{Conditions: []*Match{{FieldIPRemotePort, MatchTypeEqual, uint32(53)}}},
+		{Conditions: []*Match{nil}},
+		{BufferSize: -1},
+	} {
+		if sub, err := s.SubscribeNetEvents(context.Background(), filter); err == nil {
+			sub.Close()
+			t.Errorf("subscribing with %+v succeeded", filter)
+		}
+	}
+}
+
+func TestNetEventCallbacks(t *testing.T) {
+	var (
+		r        callbacks
+		got1     []*NetEvent
+		got2     []*NetEvent
+		mem      = newFakeMemory(8)
+		classify = &ClassifyEvent{FilterID: 0x1234, Direction: DirectionOutbound}
+	)
+
+	// FWPM_NET_EVENT_CLASSIFY_ALLOW0, with only a filter ID. A
+	// direction of zero is FWP_DIRECTION_OUTBOUND.
+	b := make([]byte, 32)
+	put64(b, 0, classify.FilterID)
+	allow := eventFixture(mem, netEventVersion2, NetEventTypeClassifyAllow, mem.add(b), false)
+	allow1 := eventFixture(mem, netEventVersion1, NetEventTypeClassifyDrop, 0, false)
+
+	id1 := r.add(netEventCallback(netEventVersion1, func(e *NetEvent) { got1 = append(got1, e) }))
+	id2 := r.add(netEventCallback(netEventVersion2, func(e *NetEvent) { got2 = append(got2, e) }))
+	if id1 == id2 {
+		t.Fatalf("callbacks share context value %d", id1)
+	}
+
+	r.dispatch(mem, id2, allow)
+	r.dispatch(mem, id1, allow1)
+	// An undecodable event is delivered as nil.
+	r.dispatch(mem, id2, mem.add(make([]byte, 16)))
+	// Events for unknown context values are dropped.
+	r.dispatch(mem, 0, allow)
+	r.remove(id1)
+	r.dispatch(mem, id1, allow1)
+
+	want := testHeader(NetEventTypeClassifyAllow, false, true)
+	want.Classify = classify
+	if diff := cmp.Diff(got2, []*NetEvent{want, nil}, netipComparers...); diff != "" {
+		t.Errorf("version 2 callback got wrong events (-got+want):\n%s", diff)
+	}
+	if diff := cmp.Diff(got1, []*NetEvent{testHeader(NetEventTypeClassifyDrop, false, false)}, netipComparers...); diff != "" {
+		t.Errorf("version 1 callback got wrong events (-got+want):\n%s", diff)
+	}
+
+	// Bridged to a subscription, undecodable events count as lost.
+	sub := &NetEventSubscription{events: make(chan *NetEvent, 1)}
+	id := r.add(netEventCallback(netEventVersion2, sub.deliver))
+	r.dispatch(mem, id, allow)
+	r.dispatch(mem, id, mem.add(make([]byte, 16)))
+	r.dispatch(mem, id, allow)
+	if got := sub.Lost(); got != 2 {
+		t.Errorf("Lost() = %d, want 2", got)
+	}
+	if got := receive(sub); len(got) != 1 || got[0].Classify.FilterID != classify.FilterID {
+		t.Errorf("subscription got %v, want the classify allow", got)
+	}
+}
@@ -2,6 +2,9 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build windows
+// +build windows
+
 package wf
 
 //sys fwpmEngineOpen0(mustBeNil *uint16, authnService authnService, authIdentity *uintptr, session *fwpmSession0, engineHandle *windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmEngineOpen0
@@ -14,24 +17,50 @@ package wf
 //sys fwpmSubLayerCreateEnumHandle0(engineHandle windows.Handle, enumTemplate *fwpmSublayerEnumTemplate0, handle *windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmSubLayerCreateEnumHandle0
 //sys fwpmSubLayerDestroyEnumHandle0(engineHandle windows.Handle, enumHandle windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmSubLayerDestroyEnumHandle0
 //sys fwpmSubLayerEnum0(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries ***fwpmSublayer0, numEntriesReturned *uint32) (ret error) [failretval!=0] = fwpuclnt.FwpmSubLayerEnum0
-//sys fwpmSubLayerAdd0(engineHandle windows.Handle, sublayer *fwpmSublayer0, nilForNow *uintptr) (ret error) [failretval!=0] = fwpuclnt.FwpmSubLayerAdd0
+//sys fwpmSubLayerAdd0(engineHandle windows.Handle, sublayer *fwpmSublayer0, sd *windows.SECURITY_DESCRIPTOR) (ret error) [failretval!=0] = fwpuclnt.FwpmSubLayerAdd0
 //sys fwpmSubLayerDeleteByKey0(engineHandle windows.Handle, guid *SublayerID) (ret error) [failretval!=0] = fwpuclnt.FwpmSubLayerDeleteByKey0
+//sys fwpmSubLayerGetSecurityInfoByKey0(engineHandle windows.Handle, key *SublayerID, securityInfo windows.SECURITY_INFORMATION, sidOwner **windows.SID, sidGroup **windows.SID, dacl **windows.ACL, sacl **windows.ACL, sd **windows.SECURITY_DESCRIPTOR) (ret error) [failretval!=0] = fwpuclnt.FwpmSubLayerGetSecurityInfoByKey0
+//sys fwpmSubLayerSetSecurityInfoByKey0(engineHandle windows.Handle, key *SublayerID, securityInfo windows.SECURITY_INFORMATION, sidOwner *windows.SID, sidGroup *windows.SID, dacl *windows.ACL, sacl *windows.ACL) (ret error) [failretval!=0] = fwpuclnt.FwpmSubLayerSetSecurityInfoByKey0
 
 //sys fwpmProviderCreateEnumHandle0(engineHandle windows.Handle, enumTemplate *struct{}, handle *windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmProviderCreateEnumHandle0
 //sys fwpmProviderDestroyEnumHandle0(engineHandle windows.Handle, enumHandle windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmProviderDestroyEnumHandle0
 //sys fwpmProviderEnum0(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries ***fwpmProvider0, numEntriesReturned *uint32) (ret error) [failretval!=0] = fwpuclnt.FwpmProviderEnum0
-//sys fwpmProviderAdd0(engineHandle windows.Handle, provider *fwpmProvider0, nilForNow *uintptr) (ret error) [failretval!=0] = fwpuclnt.FwpmProviderAdd0
+//sys fwpmProviderAdd0(engineHandle windows.Handle, provider *fwpmProvider0, sd *windows.SECURITY_DESCRIPTOR) (ret error) [failretval!=0] = fwpuclnt.FwpmProviderAdd0
 //sys fwpmProviderDeleteByKey0(engineHandle windows.Handle, guid *ProviderID) (ret error) [failretval!=0] = fwpuclnt.FwpmProviderDeleteByKey0
+//sys fwpmProviderGetSecurityInfoByKey0(engineHandle windows.Handle, key *ProviderID, securityInfo windows.SECURITY_INFORMATION, sidOwner **windows.SID, sidGroup **windows.SID, dacl **windows.ACL, sacl **windows.ACL, sd **windows.SECURITY_DESCRIPTOR) (ret error) [failretval!=0] = fwpuclnt.FwpmProviderGetSecurityInfoByKey0
+//sys fwpmProviderSetSecurityInfoByKey0(engineHandle windows.Handle, key *ProviderID, securityInfo windows.SECURITY_INFORMATION, sidOwner *windows.SID, sidGroup *windows.SID, dacl *windows.ACL, sacl *windows.ACL) (ret error) [failretval!=0] = fwpuclnt.FwpmProviderSetSecurityInfoByKey0
+
+//sys fwpmCalloutCreateEnumHandle0(engineHandle windows.Handle, enumTemplate *struct{}, handle *windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmCalloutCreateEnumHandle0
+//sys fwpmCalloutDestroyEnumHandle0(engineHandle windows.Handle, enumHandle windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmCalloutDestroyEnumHandle0
+//sys fwpmCalloutEnum0(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries ***fwpmCallout0, numEntriesReturned *uint32) (ret error) [failretval!=0] = fwpuclnt.FwpmCalloutEnum0
+//sys fwpmCalloutAdd0(engineHandle windows.Handle, callout *fwpmCallout0, sd *windows.SECURITY_DESCRIPTOR, id *uint32) (ret error) [failretval!=0] = fwpuclnt.FwpmCalloutAdd0
+//sys fwpmCalloutDeleteByKey0(engineHandle windows.Handle, guid *CalloutID) (ret error) [failretval!=0] = fwpuclnt.FwpmCalloutDeleteByKey0
+
+//sys fwpmProviderContextCreateEnumHandle0(engineHandle windows.Handle, enumTemplate *struct{}, handle *windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmProviderContextCreateEnumHandle0
+//sys fwpmProviderContextDestroyEnumHandle0(engineHandle windows.Handle, enumHandle windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmProviderContextDestroyEnumHandle0
+//sys fwpmProviderContextEnum0(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries ***fwpmProviderContext0, numEntriesReturned *uint32) (ret error) [failretval!=0] = fwpuclnt.FwpmProviderContextEnum0
+//sys fwpmProviderContextEnum2(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries ***fwpmProviderContext0, numEntriesReturned *uint32) (ret error) [failretval!=0] = fwpuclnt.FwpmProviderContextEnum2
+//sys fwpmProviderContextAdd0(engineHandle windows.Handle, providerContext *fwpmProviderContext0, sd *windows.SECURITY_DESCRIPTOR, id *uint64) (ret error) [failretval!=0] = fwpuclnt.FwpmProviderContextAdd0
+//sys fwpmProviderContextDeleteByKey0(engineHandle windows.Handle, guid *ProviderContextID) (ret error) [failretval!=0] = fwpuclnt.FwpmProviderContextDeleteByKey0
 
 //sys fwpmFilterCreateEnumHandle0(engineHandle windows.Handle, enumTemplate *fwpmFilterEnumTemplate0, handle *windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmFilterCreateEnumHandle0
 //sys fwpmFilterDestroyEnumHandle0(engineHandle windows.Handle, enumHandle windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmFilterDestroyEnumHandle0
 //sys fwpmFilterEnum0(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries ***fwpmFilter0, numEntriesReturned *uint32) (ret error) [failretval!=0] = fwpuclnt.FwpmFilterEnum0
 //sys fwpmFilterAdd0(engineHandle windows.Handle, rule *fwpmFilter0, sd *windows.SECURITY_DESCRIPTOR, id *uint64) (ret error) [failretval!=0] = fwpuclnt.FwpmFilterAdd0
 //sys fwpmFilterDeleteByKey0(engineHandle windows.Handle, guid *RuleID) (ret error) [failretval!=0] = fwpuclnt.FwpmFilterDeleteByKey0
+//sys fwpmFilterGetSecurityInfoByKey0(engineHandle windows.Handle, key *RuleID, securityInfo windows.SECURITY_INFORMATION, sidOwner **windows.SID, sidGroup **windows.SID, dacl **windows.ACL, sacl **windows.ACL, sd **windows.SECURITY_DESCRIPTOR) (ret error) [failretval!=0] = fwpuclnt.FwpmFilterGetSecurityInfoByKey0
+//sys fwpmFilterSetSecurityInfoByKey0(engineHandle windows.Handle, key *RuleID, securityInfo windows.SECURITY_INFORMATION, sidOwner *windows.SID, sidGroup *windows.SID, dacl *windows.ACL, sacl *windows.ACL) (ret error) [failretval!=0] = fwpuclnt.FwpmFilterSetSecurityInfoByKey0
+//sys fwpmFilterGetByKey0(engineHandle windows.Handle, guid *RuleID, filter **fwpmFilter0) (ret error) [failretval!=0] = fwpuclnt.FwpmFilterGetByKey0
+//sys fwpmFilterSubscribeChanges0(engineHandle windows.Handle, subscription *fwpmFilterSubscription0, callback uintptr, context uintptr, changeHandle *windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmFilterSubscribeChanges0
+//sys fwpmFilterUnsubscribeChanges0(engineHandle windows.Handle, changeHandle windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmFilterUnsubscribeChanges0
 
-//sys fwpmNetEventCreateEnumHandle0(engineHandle windows.Handle, enumTemplate *struct{}, handle *windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmNetEventCreateEnumHandle0
+//sys fwpmNetEventCreateEnumHandle0(engineHandle windows.Handle, enumTemplate *fwpmNetEventEnumTemplate0, handle *windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmNetEventCreateEnumHandle0
 //sys fwpmNetEventDestroyEnumHandle0(engineHandle windows.Handle, enumHandle windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmNetEventDestroyEnumHandle0
-//sys fwpmNetEventEnum1(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries ***fwpmNetEvent1, numEntriesReturned *uint32) (ret error) [failretval!=0] = fwpuclnt.FwpmNetEventEnum1
+//sys fwpmNetEventEnum1(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries *uintptr, numEntriesReturned *uint32) (ret error) [failretval!=0] = fwpuclnt.FwpmNetEventEnum1
+//sys fwpmNetEventEnum2(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries *uintptr, numEntriesReturned *uint32) (ret error) [failretval!=0] = fwpuclnt.FwpmNetEventEnum2
+//sys fwpmNetEventSubscribe0(engineHandle windows.Handle, subscription *fwpmNetEventSubscription0, callback uintptr, context uintptr, eventsHandle *windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmNetEventSubscribe0
+//sys fwpmNetEventSubscribe1(engineHandle windows.Handle, subscription *fwpmNetEventSubscription0, callback uintptr, context uintptr, eventsHandle *windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmNetEventSubscribe1
+//sys fwpmNetEventUnsubscribe0(engineHandle windows.Handle, eventsHandle windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmNetEventUnsubscribe0
 
 //sys fwpmTransactionBegin0(engineHandle windows.Handle, flags uint32) (ret error) [failretval!=0] = fwpuclnt.FwpmTransactionBegin0
 //sys fwpmTransactionCommit0(engineHandle windows.Handle) (ret error) [failretval!=0] = fwpuclnt.FwpmTransactionCommit0
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"time"
+)
+
+// Transact retries at most this many times, with this initial
+// backoff, doubled after each attempt.
+var (
+	transactRetries = 4
+	transactBackoff = 50 * time.Millisecond
+)
+
+// Transact runs fn in a transaction of the given kind. If fn returns
+// nil the transaction is committed, otherwise it is aborted and fn's
+// error is returned. If fn panics, the transaction is aborted and the
+// panic propagates.
+//
+// fn is passed s, and should make all its changes through it. If the
+// filtering engine aborts the transaction (TransactionAborted) or
+// can't acquire the transaction lock in time (Timeout), the whole of
+// fn is retried with exponential backoff, so fn must be safe to run
+// more than once.
+//
+// Transact updates TransactionStatus like BeginTransaction,
+// CommitTransaction and AbortTransaction do.
+func (s *Session) Transact(flags TransactionFlag, fn func(tx *Session) error) error {
+	backoff := transactBackoff
+	for i := 0; ; i++ {
+		err := s.transact(flags, fn)
+		if err == nil || i == transactRetries || !retryableTxnError(err) {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// transact makes a single attempt at running fn in a transaction.
+func (s *Session) transact(flags TransactionFlag, fn func(tx *Session) error) error {
+	if err := s.engine.BeginTransaction(flags); err != nil {
+		s.status.Err = err
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	s.status = TransactionStatus{State: BeganTransaction}
+
+	// The engine ends the transaction when a commit fails, so only
+	// abort if fn fails or panics.
+	finished := false
+	defer func() {
+		if !finished {
+			s.AbortTransaction()
+		}
+	}()
+
+	if err := fn(s); err != nil {
+		return err
+	}
+	finished = true
+	if err := s.engine.CommitTransaction(); err != nil {
+		s.status = TransactionStatus{State: AbortedTransaction, Err: err}
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	s.status = TransactionStatus{State: CommittedTransaction}
+	return nil
+}
+
+// retryableTxnError reports whether err means that a transaction
+// failed for reasons outside the caller's control, and may succeed
+// if tried again.
+func retryableTxnError(err error) bool {
+	return errors.Is(err, syscall.Errno(TransactionAborted)) || errors.Is(err, syscall.Errno(Timeout))
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestTransact(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(testLayers), nil)
+	defer s.Close()
+
+	err := s.Transact(TransactionReadWrite, func(tx *Session) error {
+		return tx.AddProvider(&Provider{ID: ProviderID{Data1: 1}})
+	})
+	if err != nil {
+		t.Fatalf("transaction failed: %v", err)
+	}
+	if st := s.TransactionStatus(); st.State != CommittedTransaction || st.Err != nil {
+		t.Fatalf("transaction status is %v/%v, want committed", st.State, st.Err)
+	}
+
+	errBoom := errors.New("boom")
+	err = s.Transact(TransactionReadWrite, func(tx *Session) error {
+		if err := tx.AddProvider(&Provider{ID: ProviderID{Data1: 2}}); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	if err != errBoom {
+		t.Fatalf("failed transaction returned %v, want %v", err, errBoom)
+	}
+	if st := s.TransactionStatus(); st.State != AbortedTransaction {
+		t.Fatalf("transaction status is %v, want aborted", st.State)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("panic in transaction was swallowed")
+			}
+		}()
+		s.Transact(TransactionReadWrite, func(tx *Session) error {
+			tx.AddProvider(&Provider{ID: ProviderID{Data1: 3}})
+			panic("boom")
+		})
+	}()
+
+	providers, err := s.Providers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(providers) != 1 || providers[0].ID != (ProviderID{Data1: 1}) {
+		t.Fatalf("aborted transactions left providers behind: %v", providers)
+	}
+}
+
+func TestTransactRetry(t *testing.T) {
+	m := NewMemoryEngine(testLayers)
+	a := newMemorySession(t, m, &Options{TransactionStartTimeout: time.Millisecond})
+	defer a.Close()
+	b := newMemorySession(t, m, &Options{TransactionStartTimeout: time.Millisecond})
+	defer b.Close()
+
+	defer func(d time.Duration) { transactBackoff = d }(transactBackoff)
+	transactBackoff = 10 * time.Millisecond
+
+	// a holds the transaction lock long enough for b's first
+	// attempts to time out.
+	a.BeginTransaction(TransactionReadWrite)
+	if st := a.TransactionStatus(); st.Err != nil {
+		t.Fatal(st.Err)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		time.Sleep(20 * time.Millisecond)
+		a.CommitTransaction()
+	}()
+
+	runs := 0
+	err := b.Transact(TransactionReadWrite, func(tx *Session) error {
+		runs++
+		return tx.AddProvider(&Provider{ID: ProviderID{Data1: 1}})
+	})
+	if err != nil {
+		t.Fatalf("transaction failed: %v", err)
+	}
+	if runs != 1 {
+		t.Fatalf("transaction function ran %d times, want 1", runs)
+	}
+	<-done
+
+	// Errors other than lock timeouts are not retried.
+	runs = 0
+	err = b.Transact(TransactionReadWrite, func(tx *Session) error {
+		runs++
+		return tx.AddProvider(&Provider{ID: ProviderID{Data1: 1}})
+	})
+	if !errors.Is(err, syscall.Errno(AlreadyExists)) || runs != 1 {
+		t.Fatalf("got err %v after %d runs, want AlreadyExists after 1", err, runs)
+	}
+}
@@ -2,9 +2,14 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build windows
+// +build windows
+
 package wf
 
 import (
+	"unsafe"
+
 	"golang.org/x/sys/windows"
 )
 
@@ -61,37 +66,6 @@ const (
 	fwpmFieldTypeFlags                          // data is a flag bitfield
 )
 
-type dataType uint32
-
-const (
-	dataTypeEmpty                  dataType = 0
-	dataTypeUint8                  dataType = 1
-	dataTypeUint16                 dataType = 2
-	dataTypeUint32                 dataType = 3
-	dataTypeUint64                 dataType = 4
-	dataTypeByteArray16            dataType = 11
-	dataTypeByteBlob               dataType = 12
-	dataTypeSID                    dataType = 13
-	dataTypeSecurityDescriptor     dataType = 14
-	dataTypeTokenInformation       dataType = 15
-	dataTypeTokenAccessInformation dataType = 16
-	dataTypeArray6                 dataType = 18
-	dataTypeBitmapIndex            dataType = 19
-	dataTypeV4AddrMask             dataType = 256
-	dataTypeV6AddrMask             dataType = 257
-	dataTypeRange                  dataType = 258
-)
-
-// Types not implemented, because WFP doesn't seem to use them.
-// dataTypeInt8 dataType = 5
-// dataTypeInt16 dataType = 6
-// dataTypeInt32 dataType = 7
-// dataTypeInt64 dataType = 8
-// dataTypeFloat dataType = 9
-// dataTypeDouble dataType = 10
-// dataTypeUnicodeString dataType = 17
-// dataTypeBitmapArray64 dataType = 20
-
 //go:notinheap
 type fwpmField0 struct {
 	FieldKey *FieldID
@@ -124,6 +98,48 @@ type fwpmSublayer0 struct {
 	Weight       uint16
 }
 
+type fwpmCalloutFlags uint32
+
+const (
+	fwpmCalloutFlagsPersistent          fwpmCalloutFlags = 0x00010000
+	fwpmCalloutFlagsUsesProviderContext fwpmCalloutFlags = 0x00020000
+	fwpmCalloutFlagsRegistered          fwpmCalloutFlags = 0x00040000
+)
+
+//go:notinheap
+type fwpmCallout0 struct {
+	CalloutKey      CalloutID
+	DisplayData     fwpmDisplayData0
+	Flags           fwpmCalloutFlags
+	ProviderKey     *windows.GUID
+	ProviderData    fwpByteBlob
+	ApplicableLayer LayerID
+	CalloutID       uint32
+}
+
+type fwpmProviderContextFlags uint32
+
+const fwpmProviderContextFlagsPersistent fwpmProviderContextFlags = 1
+
+// fwpmProviderContext0 is the Go representation of
+// FWPM_PROVIDER_CONTEXT0, FWPM_PROVIDER_CONTEXT1 and
+// FWPM_PROVIDER_CONTEXT2, which only differ in the types of policy
+// that their union can point to.
+//
+//go:notinheap
+type fwpmProviderContext0 struct {
+	ProviderContextKey ProviderContextID
+	DisplayData        fwpmDisplayData0
+	Flags              fwpmProviderContextFlags
+	ProviderKey        *windows.GUID
+	ProviderData       fwpByteBlob
+	Type               ProviderContextType
+	// DataBuffer is the union of pointers to the context's policy,
+	// which is an FWP_BYTE_BLOB for ProviderContextGeneral.
+	DataBuffer        *fwpByteBlob
+	ProviderContextID uint64
+}
+
 type fwpmProviderFlags uint32
 
 const (
@@ -181,16 +197,35 @@ type fwpmFilter0 struct {
 	NumFilterConditions uint32
 	FilterConditions    *fwpmFilterCondition0
 	Action              fwpmAction0
-
-	// Only one of RawContext/ProviderContextKey must be set.
-	RawContext         uint64
-	ProviderContextKey windows.GUID
-
+	_                   [pad64]byte
+	// Context is the union of rawContext and providerContextKey,
+	// which is the latter if Flags has
+	// fwpmFilterFlagsHasProviderContext. See rawContext and
+	// providerContextKey.
+	Context         [2]uint64
 	Reserved        *windows.GUID
+	_               [pad64]byte
 	FilterID        uint64
 	EffectiveWeight fwpValue0
 }
 
+// pad64 is the padding that aligns a 64-bit field that follows a
+// pointer-aligned one, as C does. Go aligns 64-bit fields to 8 bytes
+// only on 64-bit platforms, where no padding is needed.
+const pad64 = 8 - unsafe.Sizeof(uintptr(0))
+
+// rawContext returns a pointer to the rawContext member of f's
+// context union.
+func (f *fwpmFilter0) rawContext() *uint64 {
+	return &f.Context[0]
+}
+
+// providerContextKey returns a pointer to the providerContextKey
+// member of f's context union.
+func (f *fwpmFilter0) providerContextKey() *ProviderContextID {
+	return (*ProviderContextID)(unsafe.Pointer(&f.Context))
+}
+
 //go:notinheap
 type fwpConditionValue0 struct {
 	Type  dataType
@@ -234,88 +269,36 @@ type fwpmFilterEnumTemplate0 struct {
 	CalloutKey              *CalloutID
 }
 
-//go:notinheap
-type fwpRange0 struct {
-	From, To fwpValue0
-}
-
-type FilterEnumType uint32
+type fwpmSubscriptionFlags uint32
 
 const (
-	FilterEnumTypeFullyContained FilterEnumType = iota
-	FilterEnumTypeOverlapping
-)
-
-type FilterEnumFlags uint32
-
-const (
-	FilterEnumFlagsBestTerminatingMatch FilterEnumFlags = 0x01
-	FilterEnumFlagsSorted                               = 0x02
-	FilterEnumFlagsBootTimeOnly                         = 0x04
-	FilterEnumFlagsIncludeBootTime                      = 0x08
-	FilterEnumFlagsIncludeDisabled                      = 0x10
-	FilterEnumFlagsReserved1                            = 0x20
-)
-
-type fwpIPVersion uint32
-
-const (
-	fwpIPVersion4 fwpIPVersion = 0
-	fwpIPVersion6 fwpIPVersion = 1
+	fwpmSubscriptionFlagNotifyOnAdd    fwpmSubscriptionFlags = 1
+	fwpmSubscriptionFlagNotifyOnDelete fwpmSubscriptionFlags = 2
 )
 
 //go:notinheap
-type fwpmNetEventHeader1 struct {
-	Timestamp  windows.Filetime
-	Flags      uint32       // enum
-	IPVersion  fwpIPVersion // enum
-	IPProtocol uint8
-	_          [3]byte
-	LocalAddr  [16]byte
-	RemoteAddr [16]byte
-	LocalPort  uint16
-	RemotePort uint16
-	ScopeID    uint32
-	AppID      fwpByteBlob
-	UserID     *windows.SID
-
-	// Random reserved fields for an aborted attempt at including
-	// Ethernet frame information. Not used, but we have to pad out
-	// the struct appropriately.
-	_ struct {
-		reserved1 uint32
-		unused2   struct {
-			reserved2  [6]byte
-			reserved3  [6]byte
-			reserved4  uint32
-			reserved5  uint32
-			reserved6  uint16
-			reserved7  uint32
-			reserved8  uint32
-			reserved9  uint16
-			reserved10 uint64
-		}
-	}
+type fwpmFilterSubscription0 struct {
+	EnumTemplate *fwpmFilterEnumTemplate0
+	Flags        fwpmSubscriptionFlags
+	SessionKey   windows.GUID
 }
 
 //go:notinheap
-type fwpmNetEventClassifyDrop1 struct {
-	FilterID        uint64
-	LayerID         uint16
-	ReauthReason    uint32
-	OriginalProfile uint32
-	CurrentProfile  uint32
-	Direction       uint32
-	Loopback        uint32
+type fwpmNetEventEnumTemplate0 struct {
+	StartTime     windows.Filetime
+	EndTime       windows.Filetime
+	NumConditions uint32
+	Conditions    *fwpmFilterCondition0
 }
 
-type fwpmNetEventType uint32
-
-const fwpmNetEventClassifyDrop = 3
+//go:notinheap
+type fwpmNetEventSubscription0 struct {
+	EnumTemplate *fwpmNetEventEnumTemplate0
+	Flags        uint32
+	SessionKey   windows.GUID
+}
 
 //go:notinheap
-type fwpmNetEvent1 struct {
-	Header fwpmNetEventHeader1
-	Type   fwpmNetEventType
-	Drop   *fwpmNetEventClassifyDrop1
+type fwpRange0 struct {
+	From, To fwpValue0
 }
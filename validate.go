@@ -0,0 +1,305 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"strings"
+
+	"go4.org/netipx"
+)
+
+// A ValidationError is one problem that Validate found in a Rule.
+type ValidationError struct {
+	// Condition is the index in Rule.Conditions of the offending
+	// Match, or -1 if the problem is with the rule itself.
+	Condition int
+	// Msg describes the problem.
+	Msg string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Condition < 0 {
+		return e.Msg
+	}
+	return fmt.Sprintf("condition %d: %s", e.Condition, e.Msg)
+}
+
+// ValidationErrors is the list of all problems found by Validate.
+type ValidationErrors []*ValidationError
+
+func (es ValidationErrors) Error() string {
+	var msgs []string
+	for _, e := range es {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate checks r against the layers and fields described by
+// schema, and returns a ValidationErrors listing every problem found,
+// or nil if r is well-formed. If schema is nil, DefaultSchema is used.
+//
+// A rule that passes validation can still be rejected by the
+// filtering engine, for example if its sublayer or provider doesn't
+// exist.
+func Validate(r *Rule, schema *Schema) error {
+	if schema == nil {
+		schema = defaultSchema
+	}
+
+	var errs ValidationErrors
+	addErr := func(cond int, msg string, args ...interface{}) {
+		errs = append(errs, &ValidationError{cond, fmt.Sprintf(msg, args...)})
+	}
+
+	if r.ID.IsZero() {
+		addErr(-1, "rule has a zero ID")
+	}
+	if r.Sublayer.IsZero() {
+		addErr(-1, "rule has a zero Sublayer")
+	}
+	if r.Action&Action(ActionFlagCallout) != 0 && r.Callout.IsZero() {
+		addErr(-1, "action %s requires a Callout", r.Action)
+	}
+	if !r.ProviderContext.IsZero() && r.RawContext != 0 {
+		addErr(-1, "rule has both a ProviderContext and a RawContext")
+	}
+
+	layer := schema.Layer(r.Layer)
+	if layer == nil {
+		addErr(-1, "unknown layer %s", r.Layer)
+		return errs
+	}
+	family := layerFamily(r.Layer)
+
+	for i, m := range r.Conditions {
+		if m == nil {
+			addErr(i, "nil condition")
+			continue
+		}
+		ftype, ok := schema.FieldType(r.Layer, m.Field)
+		if !ok {
+			addErr(i, "field %s is not available in layer %s", m.Field, r.Layer)
+			continue
+		}
+		if _, ok := mtStr[m.Op]; !ok {
+			addErr(i, "unknown match type %s", m.Op)
+			continue
+		}
+		if err := validateValue(m.Value, ftype); err != "" {
+			addErr(i, "field %s: %s", m.Field, err)
+			continue
+		}
+		if err := validateOp(m.Op, m.Value, ftype); err != "" {
+			addErr(i, "field %s: %s", m.Field, err)
+		}
+		if err := validateFamily(m.Value, family); err != "" {
+			addErr(i, "field %s: %s in layer %s", m.Field, err, r.Layer)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// layerFamily returns 4 or 6 if layer is specific to IPv4 or IPv6,
+// or 0 if it handles both or neither.
+func layerFamily(layer LayerID) int {
+	name := layer.String()
+	switch {
+	case strings.Contains(name, "_V4"):
+		return 4
+	case strings.Contains(name, "_V6"):
+		return 6
+	}
+	return 0
+}
+
+// validateValue checks that v is a value that can be matched against
+// a field of type ftype, and returns a description of the problem if
+// not.
+func validateValue(v interface{}, ftype reflect.Type) string {
+	if r, ok := v.(Range); ok {
+		if !rangeTypes[ftype] {
+			return fmt.Sprintf("Range is not supported for field type %s", ftype)
+		}
+		if _, ok := r.From.(Range); ok {
+			return "can't have a Range of Ranges"
+		}
+		if _, ok := r.To.(Range); ok {
+			return "can't have a Range of Ranges"
+		}
+		if err := validateValue(r.From, ftype); err != "" {
+			return "Range.From: " + err
+		}
+		if err := validateValue(r.To, ftype); err != "" {
+			return "Range.To: " + err
+		}
+		if reflect.TypeOf(r.From) != reflect.TypeOf(r.To) {
+			return fmt.Sprintf("range type mismatch: %T vs. %T", r.From, r.To)
+		}
+		if c, ok := simCompare(r.From, r.To); ok && c > 0 {
+			return fmt.Sprintf("Range.From %v is greater than Range.To %v", r.From, r.To)
+		}
+		return ""
+	}
+
+	mapErr := fmt.Sprintf("cannot map Go type %T to field type %s", v, ftype)
+	switch ftype {
+	case typeUint8:
+		switch v.(type) {
+		case uint8, IPProto:
+			return ""
+		}
+	case typeUint16:
+		if _, ok := v.(uint16); ok {
+			return ""
+		}
+	case typeUint32:
+		switch v.(type) {
+		case uint32, ConditionFlag:
+			return ""
+		}
+	case typeUint64:
+		if _, ok := v.(uint64); ok {
+			return ""
+		}
+	case typeBytes:
+		if _, ok := v.([]byte); ok {
+			return ""
+		}
+	case typeString:
+		if _, ok := v.(string); ok {
+			return ""
+		}
+	case typeArray16:
+		if _, ok := v.([16]byte); ok {
+			return ""
+		}
+	case typeSID:
+		if _, ok := sidString(v); ok {
+			return ""
+		}
+	case typeSecurityDescriptor:
+		if _, ok := sdString(v); ok {
+			return ""
+		}
+	case typeMAC:
+		if mac, ok := v.(net.HardwareAddr); ok {
+			if len(mac) != 6 {
+				return fmt.Sprintf("MAC address %v is not 6 bytes long", mac)
+			}
+			return ""
+		}
+	case typeIP:
+		switch ip := v.(type) {
+		case netip.Addr:
+			if !ip.IsValid() {
+				return "invalid address"
+			}
+			return ""
+		case netip.Prefix:
+			if !ip.IsValid() {
+				return "invalid prefix"
+			}
+			return ""
+		case netipx.IPRange:
+			if !ip.IsValid() {
+				return fmt.Sprintf("invalid IPRange %v", ip)
+			}
+			return ""
+		}
+	}
+	return mapErr
+}
+
+// rangeTypes are the field types that accept Range values.
+var rangeTypes = map[reflect.Type]bool{
+	typeUint8:   true,
+	typeUint16:  true,
+	typeUint32:  true,
+	typeUint64:  true,
+	typeBytes:   true,
+	typeString:  true,
+	typeArray16: true,
+}
+
+// validateOp checks that op can be used to match value v against a
+// field of type ftype, and returns a description of the problem if
+// not.
+func validateOp(op MatchType, v interface{}, ftype reflect.Type) string {
+	isRange := false
+	switch v.(type) {
+	case Range, netipx.IPRange:
+		isRange = true
+	}
+	if isRange != (op == MatchTypeRange) {
+		if isRange {
+			return fmt.Sprintf("match type %s can't be used with range value %v", op, v)
+		}
+		return fmt.Sprintf("match type %s requires a Range value, not %T", op, v)
+	}
+
+	ok := false
+	switch op {
+	case MatchTypeEqual, MatchTypeNotEqual, MatchTypeRange:
+		ok = true
+	case MatchTypeGreater, MatchTypeLess, MatchTypeGreaterOrEqual, MatchTypeLessOrEqual:
+		switch ftype {
+		case typeUint8, typeUint16, typeUint32, typeUint64, typeArray16:
+			ok = true
+		case typeIP:
+			_, ok = v.(netip.Addr)
+		}
+	case MatchTypeFlagsAllSet, MatchTypeFlagsAnySet, MatchTypeFlagsNoneSet:
+		switch ftype {
+		case typeUint8, typeUint16, typeUint32, typeUint64:
+			ok = true
+		}
+	case MatchTypeEqualCaseInsensitive:
+		ok = ftype == typeString
+	case MatchTypePrefix, MatchTypeNotPrefix:
+		switch ftype {
+		case typeString, typeBytes:
+			ok = true
+		case typeIP:
+			_, ok = v.(netip.Prefix)
+		}
+	}
+	if !ok {
+		return fmt.Sprintf("match type %s is not supported for %T values of field type %s", op, v, ftype)
+	}
+	return ""
+}
+
+// validateFamily checks that any IP addresses in v belong to family,
+// as returned by layerFamily, and returns a description of the
+// problem if not.
+func validateFamily(v interface{}, family int) string {
+	var addr netip.Addr
+	switch ip := v.(type) {
+	case netip.Addr:
+		addr = ip
+	case netip.Prefix:
+		addr = ip.Addr()
+	case netipx.IPRange:
+		addr = ip.From()
+	default:
+		return ""
+	}
+	switch {
+	case family == 4 && addr.Is6():
+		return fmt.Sprintf("IPv6 value %v", v)
+	case family == 6 && addr.Is4():
+		return fmt.Sprintf("IPv4 value %v", v)
+	}
+	return ""
+}
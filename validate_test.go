@@ -0,0 +1,75 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidate(t *testing.T) {
+	good := &Rule{
+		ID:       RuleID{Data1: 1},
+		Layer:    LayerALEAuthConnectV4,
+		Sublayer: SublayerID{Data1: 1},
+		Conditions: []*Match{
+			{FieldIPRemotePort, MatchTypeEqual, uint16(443)},
+			{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParsePrefix("10.0.0.0/8")},
+			{FieldIPProtocol, MatchTypeEqual, IPProtoTCP},
+			{FieldIPLocalPort, MatchTypeRange, Range{uint16(1000), uint16(2000)}},
+		},
+		Action: ActionBlock,
+	}
+	if err := Validate(good, nil); err != nil {
+		t.Fatalf("valid rule failed validation: %v", err)
+	}
+
+	bad := &Rule{
+		Layer: LayerALEAuthConnectV6,
+		Conditions: []*Match{
+			{FieldIPRemotePort, MatchTypeEqual, uint16(443)},
+			{FieldIPRemotePort, MatchTypeEqual, uint32(443)},
+			{FieldIPRemoteAddress, MatchTypeEqual, netip.MustParseAddr("10.0.0.1")},
+			{FieldIPLocalPort, MatchTypeRange, Range{uint16(2000), uint16(1000)}},
+			{FieldIPRemotePort, MatchTypeFlagsAllSet, "foo"},
+			{FieldALEAppID, MatchTypeGreater, "foo"},
+			{FieldMACDestinationAddress, MatchTypeEqual, uint16(0)},
+		},
+		Action:          ActionCalloutTerminating,
+		ProviderContext: ProviderContextID{Data1: 1},
+		RawContext:      1,
+	}
+	err := Validate(bad, nil)
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("Validate returned %T, want ValidationErrors", err)
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Error())
+	}
+	want := []string{
+		"rule has a zero ID",
+		"rule has a zero Sublayer",
+		"action CalloutTerminating requires a Callout",
+		"rule has both a ProviderContext and a RawContext",
+		"condition 1: field IP_REMOTE_PORT: cannot map Go type uint32 to field type uint16",
+		"condition 2: field IP_REMOTE_ADDRESS: IPv4 value 10.0.0.1 in layer ALE_AUTH_CONNECT_V6",
+		"condition 3: field IP_LOCAL_PORT: Range.From 2000 is greater than Range.To 1000",
+		"condition 4: field IP_REMOTE_PORT: cannot map Go type string to field type uint16",
+		"condition 5: field ALE_APP_ID: match type > is not supported for string values of field type string",
+		"condition 6: field MAC_DESTINATION_ADDRESS is not available in layer ALE_AUTH_CONNECT_V6",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Fatalf("validation errors are wrong (-got+want):\n%s", diff)
+	}
+
+	unknown := &Rule{ID: RuleID{Data1: 1}, Sublayer: SublayerID{Data1: 1}, Layer: LayerID{Data1: 42}, Action: ActionBlock}
+	if err := Validate(unknown, nil); err == nil {
+		t.Fatalf("rule in unknown layer passed validation")
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import "context"
+
+// The Walk methods below are streaming forms of Layers, Providers,
+// Sublayers, Callouts, Rules, DropEvents and NetEvents. Rather than
+// loading every object into one slice, they call fn with one page of
+// objects at a time, so memory use is bounded by the page size.
+//
+// If fn returns an error, the walk stops and returns that error. If
+// ctx is canceled or its deadline passes, the walk stops before
+// fetching the next page and returns ctx.Err(). A page that the
+// filtering engine is already producing can't be interrupted. In all
+// cases, the engine's enumeration handle is released before the walk
+// returns.
+
+// WalkLayers calls fn with successive pages of the available WFP
+// layers.
+func (s *Session) WalkLayers(ctx context.Context, fn func([]*Layer) error) error {
+	return s.engine.WalkLayers(ctx, fn)
+}
+
+// WalkProviders calls fn with successive pages of the providers
+// known to the filtering engine.
+func (s *Session) WalkProviders(ctx context.Context, fn func([]*Provider) error) error {
+	return s.engine.WalkProviders(ctx, fn)
+}
+
+// WalkSublayers calls fn with successive pages of the sublayers
+// registered to provider, or of all sublayers if provider is zero.
+func (s *Session) WalkSublayers(ctx context.Context, provider ProviderID, fn func([]*Sublayer) error) error {
+	return s.engine.WalkSublayers(ctx, provider, fn)
+}
+
+// WalkCallouts calls fn with successive pages of the callouts known
+// to the filtering engine.
+func (s *Session) WalkCallouts(ctx context.Context, fn func([]*Callout) error) error {
+	return s.engine.WalkCallouts(ctx, fn)
+}
+
+// WalkRules calls fn with successive pages of the rules known to the
+// filtering engine. Use RuleEnumerator.Walk to restrict the results.
+func (s *Session) WalkRules(ctx context.Context, fn func([]*Rule) error) error {
+	return s.engine.WalkRules(ctx, nil, fn)
+}
+
+// WalkDropEvents calls fn with successive pages of the packet drop
+// events recorded by the filtering engine.
+func (s *Session) WalkDropEvents(ctx context.Context, fn func([]*DropEvent) error) error {
+	return s.engine.WalkNetEvents(ctx, nil, func(events []*NetEvent) error {
+		drops := dropEvents(events)
+		if len(drops) == 0 {
+			return nil
+		}
+		return fn(drops)
+	})
+}
+
+// WalkNetEvents calls fn with successive pages of the events recorded
+// by the filtering engine.
+func (s *Session) WalkNetEvents(ctx context.Context, fn func([]*NetEvent) error) error {
+	return s.engine.WalkNetEvents(ctx, nil, fn)
+}
+
+// Walk calls fn with successive pages of the rules that match e. It
+// is the streaming form of Execute.
+func (e RuleEnumerator) Walk(ctx context.Context, fn func([]*Rule) error) error {
+	tpl := e.enumTemplate
+	return e.session.engine.WalkRules(ctx, &tpl, fn)
+}
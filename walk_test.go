@@ -0,0 +1,177 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWalkRules(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(testLayers), nil)
+	defer s.Close()
+
+	const numRules = 2*enumPageSize + 50
+	provider := &Provider{ID: ProviderID{Data1: 1}, Name: "provider"}
+	if err := s.AddProvider(provider); err != nil {
+		t.Fatalf("adding provider: %v", err)
+	}
+	for i := 0; i < numRules; i++ {
+		r := &Rule{
+			ID:     RuleID{Data1: uint32(i + 1)},
+			Layer:  LayerALEAuthConnectV4,
+			Action: ActionBlock,
+		}
+		if i%2 == 0 {
+			r.Provider = provider.ID
+		}
+		if err := s.AddRule(r); err != nil {
+			t.Fatalf("adding rule %d: %v", i, err)
+		}
+	}
+
+	var (
+		pages []int
+		got   []*Rule
+	)
+	err := s.WalkRules(context.Background(), func(rules []*Rule) error {
+		pages = append(pages, len(rules))
+		got = append(got, rules...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walking rules: %v", err)
+	}
+	if diff := cmp.Diff(pages, []int{enumPageSize, enumPageSize, 50}); diff != "" {
+		t.Fatalf("wrong page sizes (-got+want):\n%s", diff)
+	}
+	want, err := s.Rules()
+	if err != nil {
+		t.Fatalf("listing rules: %v", err)
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Fatalf("walked rules differ from Rules (-got+want):\n%s", diff)
+	}
+
+	// A RuleEnumerator walk honors its template.
+	n := 0
+	err = s.EnumerateRules(FilterEnumTypeOverlapping, LayerALEAuthConnectV4).WithProvider(provider.ID).WithActionMask(ActionFlagIgnore).Walk(context.Background(), func(rules []*Rule) error {
+		for _, r := range rules {
+			if r.Provider != provider.ID {
+				t.Errorf("rule %s has provider %s, want %s", r.ID, r.Provider, provider.ID)
+			}
+		}
+		n += len(rules)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walking enumerator: %v", err)
+	}
+	if n != numRules/2 {
+		t.Fatalf("enumerator walked %d rules, want %d", n, numRules/2)
+	}
+
+	// Canceling the context stops the walk after the current page.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	err = s.WalkRules(ctx, func([]*Rule) error {
+		calls++
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("walk with canceled context: got err %v, want context.Canceled", err)
+	}
+	if calls != 1 {
+		t.Fatalf("walk with canceled context made %d calls, want 1", calls)
+	}
+
+	// An error from fn stops the walk and is returned as is.
+	errStop := errors.New("stop")
+	calls = 0
+	err = s.WalkRules(context.Background(), func([]*Rule) error {
+		calls++
+		return errStop
+	})
+	if err != errStop {
+		t.Fatalf("walk with failing fn: got err %v, want %v", err, errStop)
+	}
+	if calls != 1 {
+		t.Fatalf("walk with failing fn made %d calls, want 1", calls)
+	}
+}
+
+func TestWalkObjects(t *testing.T) {
+	s := newMemorySession(t, NewMemoryEngine(testLayers), nil)
+	defer s.Close()
+
+	provider := &Provider{ID: ProviderID{Data1: 1}, Name: "provider"}
+	if err := s.AddProvider(provider); err != nil {
+		t.Fatalf("adding provider: %v", err)
+	}
+	sublayer := &Sublayer{ID: SublayerID{Data1: 1}, Name: "sublayer", Provider: provider.ID}
+	if err := s.AddSublayer(sublayer); err != nil {
+		t.Fatalf("adding sublayer: %v", err)
+	}
+
+	ctx := context.Background()
+	var layers []*Layer
+	if err := s.WalkLayers(ctx, func(page []*Layer) error {
+		layers = append(layers, page...)
+		return nil
+	}); err != nil {
+		t.Fatalf("walking layers: %v", err)
+	}
+	if len(layers) != len(testLayers) {
+		t.Fatalf("walked %d layers, want %d", len(layers), len(testLayers))
+	}
+
+	var providers []*Provider
+	if err := s.WalkProviders(ctx, func(page []*Provider) error {
+		providers = append(providers, page...)
+		return nil
+	}); err != nil {
+		t.Fatalf("walking providers: %v", err)
+	}
+	wantProviders, err := s.Providers()
+	if err != nil {
+		t.Fatalf("listing providers: %v", err)
+	}
+	if diff := cmp.Diff(providers, wantProviders); diff != "" {
+		t.Fatalf("walked providers differ (-got+want):\n%s", diff)
+	}
+
+	var sublayers []*Sublayer
+	if err := s.WalkSublayers(ctx, provider.ID, func(page []*Sublayer) error {
+		sublayers = append(sublayers, page...)
+		return nil
+	}); err != nil {
+		t.Fatalf("walking sublayers: %v", err)
+	}
+	if diff := cmp.Diff(sublayers, []*Sublayer{sublayer}); diff != "" {
+		t.Fatalf("walked sublayers differ (-got+want):\n%s", diff)
+	}
+
+	calls := 0
+	if err := s.WalkDropEvents(ctx, func([]*DropEvent) error {
+		calls++
+		return nil
+	}); err != nil {
+		t.Fatalf("walking drop events: %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("walking no drop events made %d calls, want 0", calls)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := s.WalkProviders(canceled, func([]*Provider) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Fatalf("walking providers with canceled context: got err %v, want context.Canceled", err)
+	}
+}
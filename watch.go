@@ -0,0 +1,219 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultRuleWatchBuffer is the number of changes a RuleWatcher
+// buffers when its RuleWatchFilter doesn't say.
+const DefaultRuleWatchBuffer = 256
+
+// RuleChangeType is the kind of change reported by a RuleWatcher.
+type RuleChangeType uint32
+
+const (
+	RuleAdded   RuleChangeType = 1 // FWPM_CHANGE_ADD
+	RuleDeleted RuleChangeType = 2 // FWPM_CHANGE_DELETE
+)
+
+func (t RuleChangeType) String() string {
+	switch t {
+	case RuleAdded:
+		return "added"
+	case RuleDeleted:
+		return "deleted"
+	default:
+		return fmt.Sprintf("RuleChangeType(%d)", uint32(t))
+	}
+}
+
+// A RuleChange is the addition or deletion of a rule.
+type RuleChange struct {
+	Type     RuleChangeType
+	ID       RuleID
+	KernelID uint64
+	// Rule is the rule that was added or deleted, or nil if it
+	// couldn't be resolved. Added rules are looked up when the
+	// change is reported, so Rule is nil if the rule has already
+	// been deleted again. Deleted rules are resolved from the rules
+	// the watcher has seen, so Rule is nil for rules whose addition
+	// was lost.
+	Rule *Rule
+}
+
+// RuleWatchFilter selects the rule changes reported by a RuleWatcher.
+type RuleWatchFilter struct {
+	// Provider, if non-nil, limits the changes to rules owned by
+	// that provider.
+	Provider *ProviderID
+	// Layer, if non-zero, limits the changes to rules in that
+	// layer.
+	Layer LayerID
+	// BufferSize is the capacity of the watcher's channel. If
+	// zero, DefaultRuleWatchBuffer is used.
+	BufferSize int
+}
+
+// A RuleWatcher reports rules being added to and deleted from the
+// filtering engine, by any session.
+//
+// The engine doesn't wait for watchers, so changes are buffered in the
+// channel returned by Changes. If the buffer is full when a change
+// happens, the change is dropped and counted by Lost. After losing
+// changes, callers that track the engine's state should re-read it.
+type RuleWatcher struct {
+	subscription // first, for the alignment of lost
+	changes      chan *RuleChange
+	// known are the rules selected by the watcher's filter, for
+	// resolving deletions. It is guarded by subscription.mu.
+	known map[RuleID]*Rule
+	// deleted are the rules deleted while WatchRules reads the
+	// current rules, which must not be merged into known. It is nil
+	// once the read is merged, and guarded by subscription.mu.
+	deleted map[RuleID]bool
+}
+
+// WatchRules reports the rules added and deleted from now on that
+// match filter, or all rule changes if filter is nil.
+//
+// The watcher stops when ctx is done, when it is closed, or when s is
+// closed. The channel returned by Changes is then closed, after any
+// buffered changes have been received.
+func (s *Session) WatchRules(ctx context.Context, filter *RuleWatchFilter) (*RuleWatcher, error) {
+	if filter == nil {
+		filter = &RuleWatchFilter{}
+	}
+	size, err := bufferSize(filter.BufferSize, DefaultRuleWatchBuffer)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var tpl *RuleEnumTemplate
+	if filter.Provider != nil || !filter.Layer.IsZero() {
+		tpl = &RuleEnumTemplate{
+			Provider:   filter.Provider,
+			Layer:      filter.Layer,
+			ActionMask: ActionFlagIgnore,
+		}
+	}
+
+	w := &RuleWatcher{
+		changes: make(chan *RuleChange, size),
+		known:   map[RuleID]*Rule{},
+		deleted: map[RuleID]bool{},
+	}
+	err = w.start(ctx, s, func() { close(w.changes) }, func() (func() error, error) {
+		return s.engine.SubscribeRuleChanges(tpl, w.deliver)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The current rules are read after subscribing, so that every
+	// rule that exists from now on is either read here or reported
+	// as added. Rules reported as deleted during the read may still
+	// be in it, and are skipped.
+	rules, err := s.engine.Rules(tpl)
+	if err != nil {
+		w.Close()
+		return nil, err
+	}
+	w.mu.Lock()
+	for _, r := range rules {
+		if _, ok := w.known[r.ID]; !ok && !w.deleted[r.ID] {
+			w.known[r.ID] = r
+		}
+	}
+	w.deleted = nil
+	w.mu.Unlock()
+
+	return w, nil
+}
+
+// Changes returns the channel on which changes are delivered. It is
+// closed when the watcher stops.
+func (w *RuleWatcher) Changes() <-chan *RuleChange {
+	return w.changes
+}
+
+// Lost returns the number of changes dropped so far, either because
+// the buffer was full or because they couldn't be read from the
+// engine.
+func (w *RuleWatcher) Lost() uint64 {
+	return w.lostCount()
+}
+
+// Close stops the watcher, and closes the Changes channel. It returns
+// the error, if any, from unsubscribing from the filtering engine.
+func (w *RuleWatcher) Close() error {
+	return w.close()
+}
+
+// deliver resolves c and queues it on the watcher's channel, or counts
+// it as lost if the channel is full or c is nil. deliver never
+// blocks.
+func (w *RuleWatcher) deliver(c *RuleChange) {
+	if c == nil {
+		w.addLost()
+		return
+	}
+	w.send(func() bool {
+		switch c.Type {
+		case RuleAdded:
+			if c.Rule != nil {
+				w.known[c.ID] = cloneRule(c.Rule)
+			}
+			if w.deleted != nil {
+				delete(w.deleted, c.ID)
+			}
+		case RuleDeleted:
+			if c.Rule == nil {
+				c.Rule = w.known[c.ID]
+			}
+			delete(w.known, c.ID)
+			if w.deleted != nil {
+				w.deleted[c.ID] = true
+			}
+		}
+		select {
+		case w.changes <- c:
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+// ruleChangeCallback returns a callback that decodes
+// FWPM_FILTER_CHANGE0 structures, and passes them to deliver.
+// Changes that can't be decoded are delivered as nil.
+func ruleChangeCallback(deliver func(*RuleChange)) func(memReader, uint64) {
+	return func(mem memReader, addr uint64) {
+		c, err := decodeRuleChange(mem, addr)
+		if err != nil {
+			c = nil
+		}
+		deliver(c)
+	}
+}
+
+// decodeRuleChange decodes the FWPM_FILTER_CHANGE0 at addr.
+func decodeRuleChange(mem memReader, addr uint64) (*RuleChange, error) {
+	c := &cStruct{mem: mem, addr: addr}
+	ret := &RuleChange{
+		Type: RuleChangeType(c.u32()),
+	}
+	ret.ID = RuleID(c.guid())
+	ret.KernelID = c.u64()
+	if c.err != nil {
+		return nil, c.err
+	}
+	return ret, nil
+}
@@ -0,0 +1,357 @@
+// Copyright (c) 2026 The Inet.Af AUTHORS. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wf
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// receiveChanges returns the changes buffered in w, formatted as
+// "type rule-data1 resolved", without waiting for more.
+func receiveChanges(w *RuleWatcher) []string {
+	var ret []string
+	for {
+		select {
+		case c, ok := <-w.Changes():
+			if !ok {
+				return ret
+			}
+			resolved := c.Rule != nil && c.Rule.ID == c.ID && c.Rule.KernelID == c.KernelID
+			ret = append(ret, fmt.Sprintf("%s %d %v", c.Type, c.ID.Data1, resolved))
+		default:
+			return ret
+		}
+	}
+}
+
+func TestWatchRules(t *testing.T) {
+	m := NewMemoryEngine(nil)
+	s := newMemorySession(t, m, nil)
+	defer s.Close()
+	other := newMemorySession(t, m, nil)
+	defer other.Close()
+
+	provider := &Provider{ID: ProviderID{Data1: 1}}
+	if err := other.AddProvider(provider); err != nil {
+		t.Fatal(err)
+	}
+	rule := func(id uint32, layer LayerID, owned bool) *Rule {
+		r := &Rule{ID: RuleID{Data1: id}, Layer: layer, Action: ActionBlock}
+		if owned {
+			r.Provider = provider.ID
+		}
+		return r
+	}
+	// A rule that exists before watching starts.
+	if err := other.AddRule(rule(1, LayerALEAuthConnectV4, true)); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	all, err := s.WatchRules(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer all.Close()
+	owned, err := s.WatchRules(ctx, &RuleWatchFilter{Provider: &provider.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer owned.Close()
+	v6, err := s.WatchRules(ctx, &RuleWatchFilter{Layer: LayerALEAuthConnectV6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v6.Close()
+
+	if err := other.AddRule(rule(2, LayerALEAuthConnectV4, false)); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.AddRule(rule(3, LayerALEAuthConnectV6, true)); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []uint32{1, 2, 3} {
+		if err := other.DeleteRule(RuleID{Data1: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		w    *RuleWatcher
+		want []string
+	}{
+		{"all", all, []string{"added 2 true", "added 3 true", "deleted 1 true", "deleted 2 true", "deleted 3 true"}},
+		{"provider", owned, []string{"added 3 true", "deleted 1 true", "deleted 3 true"}},
+		{"layer", v6, []string{"added 3 true", "deleted 3 true"}},
+	}
+	for _, test := range tests {
+		if diff := cmp.Diff(receiveChanges(test.w), test.want); diff != "" {
+			t.Errorf("%s: wrong changes (-got+want):\n%s", test.name, diff)
+		}
+		if got := test.w.Lost(); got != 0 {
+			t.Errorf("%s: lost %d changes, want 0", test.name, got)
+		}
+	}
+
+	// Changes made in a transaction are reported when it commits,
+	// and not at all if it aborts.
+	other.BeginTransaction(TransactionReadWrite)
+	if err := other.AddRule(rule(4, LayerALEAuthConnectV4, false)); err != nil {
+		t.Fatal(err)
+	}
+	if got := receiveChanges(all); len(got) != 0 {
+		t.Fatalf("uncommitted changes were reported: %q", got)
+	}
+	other.AbortTransaction()
+	other.BeginTransaction(TransactionReadWrite)
+	if err := other.AddRule(rule(5, LayerALEAuthConnectV4, false)); err != nil {
+		t.Fatal(err)
+	}
+	other.CommitTransaction()
+	if diff := cmp.Diff(receiveChanges(all), []string{"added 5 true"}); diff != "" {
+		t.Errorf("wrong changes after transactions (-got+want):\n%s", diff)
+	}
+}
+
+func TestWatchRulesDynamic(t *testing.T) {
+	m := NewMemoryEngine(nil)
+	s := newMemorySession(t, m, nil)
+	defer s.Close()
+
+	w, err := s.WatchRules(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	// The rules of a dynamic session are deleted when it closes.
+	dyn := newMemorySession(t, m, &Options{Dynamic: true})
+	for _, id := range []uint32{1, 2} {
+		if err := dyn.AddRule(&Rule{ID: RuleID{Data1: id}, Layer: LayerALEAuthConnectV4, Action: ActionBlock}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := dyn.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"added 1 true", "added 2 true", "deleted 1 true", "deleted 2 true"}
+	if diff := cmp.Diff(receiveChanges(w), want); diff != "" {
+		t.Errorf("wrong changes (-got+want):\n%s", diff)
+	}
+}
+
+func TestWatchRulesBackpressure(t *testing.T) {
+	m := NewMemoryEngine(nil)
+	s := newMemorySession(t, m, nil)
+	defer s.Close()
+
+	w, err := s.WatchRules(context.Background(), &RuleWatchFilter{BufferSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []uint32{1, 2, 3} {
+		if err := s.AddRule(&Rule{ID: RuleID{Data1: id}, Layer: LayerALEAuthConnectV4, Action: ActionBlock}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := w.Lost(); got != 2 {
+		t.Errorf("Lost() = %d, want 2", got)
+	}
+	if diff := cmp.Diff(receiveChanges(w), []string{"added 1 true"}); diff != "" {
+		t.Errorf("wrong changes (-got+want):\n%s", diff)
+	}
+	// Deletions are still resolved when their additions were lost.
+	if err := s.DeleteRule(RuleID{Data1: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(receiveChanges(w), []string{"deleted 3 true"}); diff != "" {
+		t.Errorf("wrong changes (-got+want):\n%s", diff)
+	}
+
+	// Closing the session stops the watcher.
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case _, ok := <-w.Changes():
+		if ok {
+			t.Error("got a change after closing the session")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("watcher channel was not closed")
+	}
+}
+
+// duringRulesBackend opens MemoryEngine sessions whose Rules method
+// calls during after reading the rules, to change the engine while a
+// read is in progress.
+type duringRulesBackend struct {
+	*MemoryEngine
+	during func()
+}
+
+func (b *duringRulesBackend) Open(opts *Options) (Engine, error) {
+	e, err := b.MemoryEngine.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &duringRulesEngine{e, b.during}, nil
+}
+
+type duringRulesEngine struct {
+	Engine
+	during func()
+}
+
+func (e *duringRulesEngine) Rules(tpl *RuleEnumTemplate) ([]*Rule, error) {
+	rules, err := e.Engine.Rules(tpl)
+	e.during()
+	return rules, err
+}
+
+func TestWatchRulesDeleteDuringRead(t *testing.T) {
+	m := NewMemoryEngine(nil)
+	other := newMemorySession(t, m, nil)
+	defer other.Close()
+	for _, id := range []uint32{1, 2} {
+		if err := other.AddRule(&Rule{ID: RuleID{Data1: id}, Layer: LayerALEAuthConnectV4, Action: ActionBlock}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Rule 1 is deleted after WatchRules reads it, but before the
+	// read is merged into the rules the watcher knows.
+	deleted := false
+	s, err := New(&Options{Backend: &duringRulesBackend{m, func() {
+		if deleted {
+			return
+		}
+		deleted = true
+		if err := other.DeleteRule(RuleID{Data1: 1}); err != nil {
+			t.Error(err)
+		}
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	w, err := s.WatchRules(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	w.mu.Lock()
+	_, stale := w.known[RuleID{Data1: 1}]
+	w.mu.Unlock()
+	if stale {
+		t.Error("watcher kept a rule deleted during its initial read")
+	}
+	if err := other.DeleteRule(RuleID{Data1: 2}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"deleted 1 false", "deleted 2 true"}
+	if diff := cmp.Diff(receiveChanges(w), want); diff != "" {
+		t.Errorf("wrong changes (-got+want):\n%s", diff)
+	}
+}
+
+func TestWatchRulesReconcile(t *testing.T) {
+	m := NewMemoryEngine(testLayers)
+	s := newMemorySession(t, m, nil)
+	defer s.Close()
+
+	provider := &Provider{ID: ProviderID{Data1: 1}}
+	desired := &DesiredState{
+		Provider: provider,
+		Rules: []*Rule{
+			{ID: RuleID{Data1: 1}, Layer: LayerALEAuthConnectV4, Action: ActionBlock},
+		},
+	}
+	if _, err := s.Reconcile(desired, false); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w, err := s.WatchRules(ctx, &RuleWatchFilter{Provider: &provider.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Someone else deletes our rule, and the watcher notices and
+	// restores it.
+	intruder := newMemorySession(t, m, nil)
+	defer intruder.Close()
+	if err := intruder.DeleteRule(RuleID{Data1: 1}); err != nil {
+		t.Fatal(err)
+	}
+	c := <-w.Changes()
+	if c.Type != RuleDeleted || c.Rule == nil || c.Rule.Provider != provider.ID {
+		t.Fatalf("got change %+v, want the deletion of our rule", c)
+	}
+	plan, err := s.Reconcile(desired, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(planSummary(plan), []string{`create rule {00000001-0000-0000-0000-000000000000} ""`}); diff != "" {
+		t.Errorf("reconcile plan is wrong (-got+want):\n%s", diff)
+	}
+	if c := <-w.Changes(); c.Type != RuleAdded || c.ID != (RuleID{Data1: 1}) {
+		t.Errorf("got change %+v, want the addition of our rule", c)
+	}
+
+	// Cancelling the context stops the watcher.
+	cancel()
+	select {
+	case _, ok := <-w.Changes():
+		if ok {
+			t.Error("got a change after cancelling")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("watcher channel was not closed")
+	}
+}
+
+func TestDecodeRuleChange(t *testing.T) {
+	var r callbacks
+	var got []*RuleChange
+	id := r.add(ruleChangeCallback(func(c *RuleChange) { got = append(got, c) }))
+
+	for _, ptrSize := range []int{4, 8} {
+		got = nil
+		mem := newFakeMemory(ptrSize)
+		// FWPM_FILTER_CHANGE0, which has the same layout on all
+		// platforms.
+		b := make([]byte, 32)
+		put32(b, 0, uint32(RuleDeleted))
+		put32(b, 4, 0x01020304)
+		put16(b, 8, 0x0506)
+		put16(b, 10, 0x0708)
+		copy(b[12:], []byte{9, 10, 11, 12, 13, 14, 15, 16})
+		put64(b, 24, 1234)
+		r.dispatch(mem, id, mem.add(b))
+		// A truncated change is delivered as nil.
+		r.dispatch(mem, id, mem.add(b[:20]))
+
+		want := []*RuleChange{
+			{
+				Type:     RuleDeleted,
+				ID:       RuleID{0x01020304, 0x0506, 0x0708, [8]byte{9, 10, 11, 12, 13, 14, 15, 16}},
+				KernelID: 1234,
+			},
+			nil,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("%d-bit: decoded changes are wrong (-got+want):\n%s", ptrSize*8, diff)
+		}
+	}
+}
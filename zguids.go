@@ -1,54 +1,52 @@
 package wf
 
-import "golang.org/x/sys/windows"
-
 // Well-known callout IDs.
 var (
-	guidCalloutEdgeTraversalALEListenV4              = windows.GUID{0x33486ab5, 0x6d5e, 0x4e65, [8]byte{0xa0, 0x0b, 0xa7, 0xaf, 0xed, 0x0b, 0xa9, 0xa1}}
-	guidCalloutEdgeTraversalALEResourceAssignmentV4  = windows.GUID{0x079b1010, 0xf1c5, 0x4fcd, [8]byte{0xae, 0x05, 0xda, 0x41, 0x10, 0x7a, 0xbd, 0x0b}}
-	guidCalloutHttpTemplateSslHandshake              = windows.GUID{0xb3423249, 0x8d09, 0x4858, [8]byte{0x92, 0x10, 0x95, 0xc7, 0xfd, 0xa8, 0xe3, 0x0f}}
-	guidCalloutIPSecALEConnectV4                     = windows.GUID{0x6ac141fc, 0xf75d, 0x4203, [8]byte{0xb9, 0xc8, 0x48, 0xe6, 0x14, 0x9c, 0x27, 0x12}}
-	guidCalloutIPSecALEConnectV6                     = windows.GUID{0x4c0dda05, 0xe31f, 0x4666, [8]byte{0x90, 0xb0, 0xb3, 0xdf, 0xad, 0x34, 0x12, 0x9a}}
-	guidCalloutIPSecDospForwardV4                    = windows.GUID{0x2fcb56ec, 0xcd37, 0x4b4f, [8]byte{0xb1, 0x08, 0x62, 0xc2, 0xb1, 0x85, 0x0a, 0x0c}}
-	guidCalloutIPSecDospForwardV6                    = windows.GUID{0x6d08a342, 0xdb9e, 0x4fbe, [8]byte{0x9e, 0xd2, 0x57, 0x37, 0x4c, 0xe8, 0x9f, 0x79}}
-	guidCalloutIPSecForwardInboundTunnelV4           = windows.GUID{0x28829633, 0xc4f0, 0x4e66, [8]byte{0x87, 0x3f, 0x84, 0x4d, 0xb2, 0xa8, 0x99, 0xc7}}
-	guidCalloutIPSecForwardInboundTunnelV6           = windows.GUID{0xaf50bec2, 0xc686, 0x429a, [8]byte{0x88, 0x4d, 0xb7, 0x44, 0x43, 0xe7, 0xb0, 0xb4}}
-	guidCalloutIPSecForwardOutboundTunnelV4          = windows.GUID{0xfb532136, 0x15cb, 0x440b, [8]byte{0x93, 0x7c, 0x17, 0x17, 0xca, 0x32, 0x0c, 0x40}}
-	guidCalloutIPSecForwardOutboundTunnelV6          = windows.GUID{0xdae640cc, 0xe021, 0x4bee, [8]byte{0x9e, 0xb6, 0xa4, 0x8b, 0x27, 0x5c, 0x8c, 0x1d}}
-	guidCalloutIPSecInboundInitiateSecureV4          = windows.GUID{0x7dff309b, 0xba7d, 0x4aba, [8]byte{0x91, 0xaa, 0xae, 0x5c, 0x66, 0x40, 0xc9, 0x44}}
-	guidCalloutIPSecInboundInitiateSecureV6          = windows.GUID{0xa9a0d6d9, 0xc58c, 0x474e, [8]byte{0x8a, 0xeb, 0x3c, 0xfe, 0x99, 0xd6, 0xd5, 0x3d}}
-	guidCalloutIPSecInboundTransportV4               = windows.GUID{0x5132900d, 0x5e84, 0x4b5f, [8]byte{0x80, 0xe4, 0x01, 0x74, 0x1e, 0x81, 0xff, 0x10}}
-	guidCalloutIPSecInboundTransportV6               = windows.GUID{0x49d3ac92, 0x2a6c, 0x4dcf, [8]byte{0x95, 0x5f, 0x1c, 0x3b, 0xe0, 0x09, 0xdd, 0x99}}
-	guidCalloutIPSecInboundTunnelALEAcceptV4         = windows.GUID{0x3df6e7de, 0xfd20, 0x48f2, [8]byte{0x9f, 0x26, 0xf8, 0x54, 0x44, 0x4c, 0xba, 0x79}}
-	guidCalloutIPSecInboundTunnelALEAcceptV6         = windows.GUID{0xa1e392d3, 0x72ac, 0x47bb, [8]byte{0x87, 0xa7, 0x01, 0x22, 0xc6, 0x94, 0x34, 0xab}}
-	guidCalloutIPSecInboundTunnelV4                  = windows.GUID{0x191a8a46, 0x0bf8, 0x46cf, [8]byte{0xb0, 0x45, 0x4b, 0x45, 0xdf, 0xa6, 0xa3, 0x24}}
-	guidCalloutIPSecInboundTunnelV6                  = windows.GUID{0x80c342e3, 0x1e53, 0x4d6f, [8]byte{0x9b, 0x44, 0x03, 0xdf, 0x5a, 0xee, 0xe1, 0x54}}
-	guidCalloutIPSecOutboundTransportV4              = windows.GUID{0x4b46bf0a, 0x4523, 0x4e57, [8]byte{0xaa, 0x38, 0xa8, 0x79, 0x87, 0xc9, 0x10, 0xd9}}
-	guidCalloutIPSecOutboundTransportV6              = windows.GUID{0x38d87722, 0xad83, 0x4f11, [8]byte{0xa9, 0x1f, 0xdf, 0x0f, 0xb0, 0x77, 0x22, 0x5b}}
-	guidCalloutIPSecOutboundTunnelV4                 = windows.GUID{0x70a4196c, 0x835b, 0x4fb0, [8]byte{0x98, 0xe8, 0x07, 0x5f, 0x4d, 0x97, 0x7d, 0x46}}
-	guidCalloutIPSecOutboundTunnelV6                 = windows.GUID{0xf1835363, 0xa6a5, 0x4e62, [8]byte{0xb1, 0x80, 0x23, 0xdb, 0x78, 0x9d, 0x8d, 0xa6}}
-	guidCalloutPolicySilentModeAuthConnectLayerV4    = windows.GUID{0x5fbfc31d, 0xa51c, 0x44dc, [8]byte{0xac, 0xb6, 0x6, 0x24, 0xa0, 0x30, 0xa7, 0x00}}
-	guidCalloutPolicySilentModeAuthConnectLayerV6    = windows.GUID{0x5fbfc31d, 0xa51c, 0x44dc, [8]byte{0xac, 0xb6, 0x6, 0x24, 0xa0, 0x30, 0xa7, 0x01}}
-	guidCalloutPolicySilentModeAuthRecvAcceptLayerV4 = windows.GUID{0x5fbfc31d, 0xa51c, 0x44dc, [8]byte{0xac, 0xb6, 0x6, 0x24, 0xa0, 0x30, 0xa7, 0x02}}
-	guidCalloutPolicySilentModeAuthRecvAcceptLayerV6 = windows.GUID{0x5fbfc31d, 0xa51c, 0x44dc, [8]byte{0xac, 0xb6, 0x6, 0x24, 0xa0, 0x30, 0xa7, 0x03}}
-	guidCalloutReservedAuthConnectLayerV4            = windows.GUID{0x288b524d, 0x566, 0x4e19, [8]byte{0xb6, 0x12, 0x8f, 0x44, 0x1a, 0x2e, 0x59, 0x49}}
-	guidCalloutReservedAuthConnectLayerV6            = windows.GUID{0xb84b92, 0x2b5e, 0x4b71, [8]byte{0xab, 0xe, 0xaa, 0xca, 0x43, 0xe3, 0x87, 0xe6}}
-	guidCalloutSetOptionsAuthConnectLayerV4          = windows.GUID{0xbc582280, 0x1677, 0x41e9, [8]byte{0x94, 0xab, 0xc2, 0xfc, 0xb1, 0x5c, 0x2e, 0xeb}}
-	guidCalloutSetOptionsAuthConnectLayerV6          = windows.GUID{0x98e5373c, 0xb884, 0x490f, [8]byte{0xb6, 0x5f, 0x2f, 0x6a, 0x4a, 0x57, 0x51, 0x95}}
-	guidCalloutSetOptionsAuthRecvAcceptLayerV4       = windows.GUID{0x2d55f008, 0x0c01, 0x4f92, [8]byte{0xb2, 0x6e, 0xa0, 0x8a, 0x94, 0x56, 0x9b, 0x8d}}
-	guidCalloutSetOptionsAuthRecvAcceptLayerV6       = windows.GUID{0x63018537, 0xf281, 0x4dc4, [8]byte{0x83, 0xd3, 0x8d, 0xec, 0x18, 0xb7, 0xad, 0xe2}}
-	guidCalloutTCPChimneyAcceptLayerV4               = windows.GUID{0xe183ecb2, 0x3a7f, 0x4b54, [8]byte{0x8a, 0xd9, 0x76, 0x05, 0x0e, 0xd8, 0x80, 0xca}}
-	guidCalloutTCPChimneyAcceptLayerV6               = windows.GUID{0x0378cf41, 0xbf98, 0x4603, [8]byte{0x81, 0xf2, 0x7f, 0x12, 0x58, 0x60, 0x79, 0xf6}}
-	guidCalloutTCPChimneyConnectLayerV4              = windows.GUID{0xf3e10ab3, 0x2c25, 0x4279, [8]byte{0xac, 0x36, 0xc3, 0x0f, 0xc1, 0x81, 0xbe, 0xc4}}
-	guidCalloutTCPChimneyConnectLayerV6              = windows.GUID{0x39e22085, 0xa341, 0x42fc, [8]byte{0xa2, 0x79, 0xae, 0xc9, 0x4e, 0x68, 0x9c, 0x56}}
-	guidCalloutTCPTemplatesAcceptLayerV4             = windows.GUID{0x2f23f5d0, 0x40c4, 0x4c41, [8]byte{0xa2, 0x54, 0x46, 0xd8, 0xdb, 0xa8, 0x95, 0x7c}}
-	guidCalloutTCPTemplatesAcceptLayerV6             = windows.GUID{0xb25152f0, 0x991c, 0x4f53, [8]byte{0xbb, 0xe7, 0xd2, 0x4b, 0x45, 0xfe, 0x63, 0x2c}}
-	guidCalloutTCPTemplatesConnectLayerV4            = windows.GUID{0x215a0b39, 0x4b7e, 0x4eda, [8]byte{0x8c, 0xe4, 0x17, 0x96, 0x79, 0xdf, 0x62, 0x24}}
-	guidCalloutTCPTemplatesConnectLayerV6            = windows.GUID{0x838b37a1, 0x5c12, 0x4d34, [8]byte{0x8b, 0x38, 0x07, 0x87, 0x28, 0xb2, 0xd2, 0x5c}}
-	guidCalloutTeredoALEListenV6                     = windows.GUID{0x81a434e7, 0xf60c, 0x4378, [8]byte{0xba, 0xb8, 0xc6, 0x25, 0xa3, 0x0f, 0x01, 0x97}}
-	guidCalloutTeredoALEResourceAssignmentV6         = windows.GUID{0x31b95392, 0x066e, 0x42a2, [8]byte{0xb7, 0xdb, 0x92, 0xf8, 0xac, 0xdd, 0x56, 0xf9}}
-	guidCalloutWFPTransportLayerV4SilentDrop         = windows.GUID{0xeda08606, 0x2494, 0x4d78, [8]byte{0x89, 0xbc, 0x67, 0x83, 0x7c, 0x03, 0xb9, 0x69}}
-	guidCalloutWFPTransportLayerV6SilentDrop         = windows.GUID{0x8693cc74, 0xa075, 0x4156, [8]byte{0xb4, 0x76, 0x92, 0x86, 0xee, 0xce, 0x81, 0x4e}}
+	guidCalloutEdgeTraversalALEListenV4              = GUID{0x33486ab5, 0x6d5e, 0x4e65, [8]byte{0xa0, 0x0b, 0xa7, 0xaf, 0xed, 0x0b, 0xa9, 0xa1}}
+	guidCalloutEdgeTraversalALEResourceAssignmentV4  = GUID{0x079b1010, 0xf1c5, 0x4fcd, [8]byte{0xae, 0x05, 0xda, 0x41, 0x10, 0x7a, 0xbd, 0x0b}}
+	guidCalloutHttpTemplateSslHandshake              = GUID{0xb3423249, 0x8d09, 0x4858, [8]byte{0x92, 0x10, 0x95, 0xc7, 0xfd, 0xa8, 0xe3, 0x0f}}
+	guidCalloutIPSecALEConnectV4                     = GUID{0x6ac141fc, 0xf75d, 0x4203, [8]byte{0xb9, 0xc8, 0x48, 0xe6, 0x14, 0x9c, 0x27, 0x12}}
+	guidCalloutIPSecALEConnectV6                     = GUID{0x4c0dda05, 0xe31f, 0x4666, [8]byte{0x90, 0xb0, 0xb3, 0xdf, 0xad, 0x34, 0x12, 0x9a}}
+	guidCalloutIPSecDospForwardV4                    = GUID{0x2fcb56ec, 0xcd37, 0x4b4f, [8]byte{0xb1, 0x08, 0x62, 0xc2, 0xb1, 0x85, 0x0a, 0x0c}}
+	guidCalloutIPSecDospForwardV6                    = GUID{0x6d08a342, 0xdb9e, 0x4fbe, [8]byte{0x9e, 0xd2, 0x57, 0x37, 0x4c, 0xe8, 0x9f, 0x79}}
+	guidCalloutIPSecForwardInboundTunnelV4           = GUID{0x28829633, 0xc4f0, 0x4e66, [8]byte{0x87, 0x3f, 0x84, 0x4d, 0xb2, 0xa8, 0x99, 0xc7}}
+	guidCalloutIPSecForwardInboundTunnelV6           = GUID{0xaf50bec2, 0xc686, 0x429a, [8]byte{0x88, 0x4d, 0xb7, 0x44, 0x43, 0xe7, 0xb0, 0xb4}}
+	guidCalloutIPSecForwardOutboundTunnelV4          = GUID{0xfb532136, 0x15cb, 0x440b, [8]byte{0x93, 0x7c, 0x17, 0x17, 0xca, 0x32, 0x0c, 0x40}}
+	guidCalloutIPSecForwardOutboundTunnelV6          = GUID{0xdae640cc, 0xe021, 0x4bee, [8]byte{0x9e, 0xb6, 0xa4, 0x8b, 0x27, 0x5c, 0x8c, 0x1d}}
+	guidCalloutIPSecInboundInitiateSecureV4          = GUID{0x7dff309b, 0xba7d, 0x4aba, [8]byte{0x91, 0xaa, 0xae, 0x5c, 0x66, 0x40, 0xc9, 0x44}}
+	guidCalloutIPSecInboundInitiateSecureV6          = GUID{0xa9a0d6d9, 0xc58c, 0x474e, [8]byte{0x8a, 0xeb, 0x3c, 0xfe, 0x99, 0xd6, 0xd5, 0x3d}}
+	guidCalloutIPSecInboundTransportV4               = GUID{0x5132900d, 0x5e84, 0x4b5f, [8]byte{0x80, 0xe4, 0x01, 0x74, 0x1e, 0x81, 0xff, 0x10}}
+	guidCalloutIPSecInboundTransportV6               = GUID{0x49d3ac92, 0x2a6c, 0x4dcf, [8]byte{0x95, 0x5f, 0x1c, 0x3b, 0xe0, 0x09, 0xdd, 0x99}}
+	guidCalloutIPSecInboundTunnelALEAcceptV4         = GUID{0x3df6e7de, 0xfd20, 0x48f2, [8]byte{0x9f, 0x26, 0xf8, 0x54, 0x44, 0x4c, 0xba, 0x79}}
+	guidCalloutIPSecInboundTunnelALEAcceptV6         = GUID{0xa1e392d3, 0x72ac, 0x47bb, [8]byte{0x87, 0xa7, 0x01, 0x22, 0xc6, 0x94, 0x34, 0xab}}
+	guidCalloutIPSecInboundTunnelV4                  = GUID{0x191a8a46, 0x0bf8, 0x46cf, [8]byte{0xb0, 0x45, 0x4b, 0x45, 0xdf, 0xa6, 0xa3, 0x24}}
+	guidCalloutIPSecInboundTunnelV6                  = GUID{0x80c342e3, 0x1e53, 0x4d6f, [8]byte{0x9b, 0x44, 0x03, 0xdf, 0x5a, 0xee, 0xe1, 0x54}}
+	guidCalloutIPSecOutboundTransportV4              = GUID{0x4b46bf0a, 0x4523, 0x4e57, [8]byte{0xaa, 0x38, 0xa8, 0x79, 0x87, 0xc9, 0x10, 0xd9}}
+	guidCalloutIPSecOutboundTransportV6              = GUID{0x38d87722, 0xad83, 0x4f11, [8]byte{0xa9, 0x1f, 0xdf, 0x0f, 0xb0, 0x77, 0x22, 0x5b}}
+	guidCalloutIPSecOutboundTunnelV4                 = GUID{0x70a4196c, 0x835b, 0x4fb0, [8]byte{0x98, 0xe8, 0x07, 0x5f, 0x4d, 0x97, 0x7d, 0x46}}
+	guidCalloutIPSecOutboundTunnelV6                 = GUID{0xf1835363, 0xa6a5, 0x4e62, [8]byte{0xb1, 0x80, 0x23, 0xdb, 0x78, 0x9d, 0x8d, 0xa6}}
+	guidCalloutPolicySilentModeAuthConnectLayerV4    = GUID{0x5fbfc31d, 0xa51c, 0x44dc, [8]byte{0xac, 0xb6, 0x6, 0x24, 0xa0, 0x30, 0xa7, 0x00}}
+	guidCalloutPolicySilentModeAuthConnectLayerV6    = GUID{0x5fbfc31d, 0xa51c, 0x44dc, [8]byte{0xac, 0xb6, 0x6, 0x24, 0xa0, 0x30, 0xa7, 0x01}}
+	guidCalloutPolicySilentModeAuthRecvAcceptLayerV4 = GUID{0x5fbfc31d, 0xa51c, 0x44dc, [8]byte{0xac, 0xb6, 0x6, 0x24, 0xa0, 0x30, 0xa7, 0x02}}
+	guidCalloutPolicySilentModeAuthRecvAcceptLayerV6 = GUID{0x5fbfc31d, 0xa51c, 0x44dc, [8]byte{0xac, 0xb6, 0x6, 0x24, 0xa0, 0x30, 0xa7, 0x03}}
+	guidCalloutReservedAuthConnectLayerV4            = GUID{0x288b524d, 0x566, 0x4e19, [8]byte{0xb6, 0x12, 0x8f, 0x44, 0x1a, 0x2e, 0x59, 0x49}}
+	guidCalloutReservedAuthConnectLayerV6            = GUID{0xb84b92, 0x2b5e, 0x4b71, [8]byte{0xab, 0xe, 0xaa, 0xca, 0x43, 0xe3, 0x87, 0xe6}}
+	guidCalloutSetOptionsAuthConnectLayerV4          = GUID{0xbc582280, 0x1677, 0x41e9, [8]byte{0x94, 0xab, 0xc2, 0xfc, 0xb1, 0x5c, 0x2e, 0xeb}}
+	guidCalloutSetOptionsAuthConnectLayerV6          = GUID{0x98e5373c, 0xb884, 0x490f, [8]byte{0xb6, 0x5f, 0x2f, 0x6a, 0x4a, 0x57, 0x51, 0x95}}
+	guidCalloutSetOptionsAuthRecvAcceptLayerV4       = GUID{0x2d55f008, 0x0c01, 0x4f92, [8]byte{0xb2, 0x6e, 0xa0, 0x8a, 0x94, 0x56, 0x9b, 0x8d}}
+	guidCalloutSetOptionsAuthRecvAcceptLayerV6       = GUID{0x63018537, 0xf281, 0x4dc4, [8]byte{0x83, 0xd3, 0x8d, 0xec, 0x18, 0xb7, 0xad, 0xe2}}
+	guidCalloutTCPChimneyAcceptLayerV4               = GUID{0xe183ecb2, 0x3a7f, 0x4b54, [8]byte{0x8a, 0xd9, 0x76, 0x05, 0x0e, 0xd8, 0x80, 0xca}}
+	guidCalloutTCPChimneyAcceptLayerV6               = GUID{0x0378cf41, 0xbf98, 0x4603, [8]byte{0x81, 0xf2, 0x7f, 0x12, 0x58, 0x60, 0x79, 0xf6}}
+	guidCalloutTCPChimneyConnectLayerV4              = GUID{0xf3e10ab3, 0x2c25, 0x4279, [8]byte{0xac, 0x36, 0xc3, 0x0f, 0xc1, 0x81, 0xbe, 0xc4}}
+	guidCalloutTCPChimneyConnectLayerV6              = GUID{0x39e22085, 0xa341, 0x42fc, [8]byte{0xa2, 0x79, 0xae, 0xc9, 0x4e, 0x68, 0x9c, 0x56}}
+	guidCalloutTCPTemplatesAcceptLayerV4             = GUID{0x2f23f5d0, 0x40c4, 0x4c41, [8]byte{0xa2, 0x54, 0x46, 0xd8, 0xdb, 0xa8, 0x95, 0x7c}}
+	guidCalloutTCPTemplatesAcceptLayerV6             = GUID{0xb25152f0, 0x991c, 0x4f53, [8]byte{0xbb, 0xe7, 0xd2, 0x4b, 0x45, 0xfe, 0x63, 0x2c}}
+	guidCalloutTCPTemplatesConnectLayerV4            = GUID{0x215a0b39, 0x4b7e, 0x4eda, [8]byte{0x8c, 0xe4, 0x17, 0x96, 0x79, 0xdf, 0x62, 0x24}}
+	guidCalloutTCPTemplatesConnectLayerV6            = GUID{0x838b37a1, 0x5c12, 0x4d34, [8]byte{0x8b, 0x38, 0x07, 0x87, 0x28, 0xb2, 0xd2, 0x5c}}
+	guidCalloutTeredoALEListenV6                     = GUID{0x81a434e7, 0xf60c, 0x4378, [8]byte{0xba, 0xb8, 0xc6, 0x25, 0xa3, 0x0f, 0x01, 0x97}}
+	guidCalloutTeredoALEResourceAssignmentV6         = GUID{0x31b95392, 0x066e, 0x42a2, [8]byte{0xb7, 0xdb, 0x92, 0xf8, 0xac, 0xdd, 0x56, 0xf9}}
+	guidCalloutWFPTransportLayerV4SilentDrop         = GUID{0xeda08606, 0x2494, 0x4d78, [8]byte{0x89, 0xbc, 0x67, 0x83, 0x7c, 0x03, 0xb9, 0x69}}
+	guidCalloutWFPTransportLayerV6SilentDrop         = GUID{0x8693cc74, 0xa075, 0x4156, [8]byte{0xb4, 0x76, 0x92, 0x86, 0xee, 0xce, 0x81, 0x4e}}
 )
 
 // Well-known field IDs.
@@ -198,9 +196,9 @@ var (
 
 // Well-known keying IDs.
 var (
-	guidKeyingModuleAuthIP = windows.GUID{0x11e3dae0, 0xdd26, 0x4590, [8]byte{0x85, 0x7d, 0xab, 0x4b, 0x28, 0xd1, 0xa0, 0x95}}
-	guidKeyingModuleIKE    = windows.GUID{0xa9bbf787, 0x82a8, 0x45bb, [8]byte{0xa4, 0x00, 0x5d, 0x7e, 0x59, 0x52, 0xc7, 0xa9}}
-	guidKeyingModuleIKEv2  = windows.GUID{0x041792cc, 0x8f07, 0x419d, [8]byte{0xa3, 0x94, 0x71, 0x69, 0x68, 0xcb, 0x16, 0x47}}
+	guidKeyingModuleAuthIP = GUID{0x11e3dae0, 0xdd26, 0x4590, [8]byte{0x85, 0x7d, 0xab, 0x4b, 0x28, 0xd1, 0xa0, 0x95}}
+	guidKeyingModuleIKE    = GUID{0xa9bbf787, 0x82a8, 0x45bb, [8]byte{0xa4, 0x00, 0x5d, 0x7e, 0x59, 0x52, 0xc7, 0xa9}}
+	guidKeyingModuleIKEv2  = GUID{0x041792cc, 0x8f07, 0x419d, [8]byte{0xa3, 0x94, 0x71, 0x69, 0x68, 0xcb, 0x16, 0x47}}
 )
 
 // Well-known layer IDs.
@@ -304,12 +302,12 @@ var (
 
 // Well-known provider IDs.
 var (
-	guidProviderContextSecureSocketAuthIP = windows.GUID{0xb25ea800, 0x0d02, 0x46ed, [8]byte{0x92, 0xbd, 0x7f, 0xa8, 0x4b, 0xb7, 0x3e, 0x9d}}
-	guidProviderContextSecureSocketIPSec  = windows.GUID{0x8c2d4144, 0xf8e0, 0x42c0, [8]byte{0x94, 0xce, 0x7c, 0xcf, 0xc6, 0x3b, 0x2f, 0x9b}}
-	guidProviderIKEExt                    = windows.GUID{0x10ad9216, 0xccde, 0x456c, [8]byte{0x8b, 0x16, 0xe9, 0xf0, 0x4e, 0x60, 0xa9, 0x0b}}
-	guidProviderIPSecDospConfig           = windows.GUID{0x3c6c05a9, 0xc05c, 0x4bb9, [8]byte{0x83, 0x38, 0x23, 0x27, 0x81, 0x4c, 0xe8, 0xbf}}
-	guidProviderTCPChimneyOffload         = windows.GUID{0x896aa19e, 0x9a34, 0x4bcb, [8]byte{0xae, 0x79, 0xbe, 0xb9, 0x12, 0x7c, 0x84, 0xb9}}
-	guidProviderTCPTemplates              = windows.GUID{0x76cfcd30, 0x3394, 0x432d, [8]byte{0xbe, 0xd3, 0x44, 0x1a, 0xe5, 0x0e, 0x63, 0xc3}}
+	guidProviderContextSecureSocketAuthIP = GUID{0xb25ea800, 0x0d02, 0x46ed, [8]byte{0x92, 0xbd, 0x7f, 0xa8, 0x4b, 0xb7, 0x3e, 0x9d}}
+	guidProviderContextSecureSocketIPSec  = GUID{0x8c2d4144, 0xf8e0, 0x42c0, [8]byte{0x94, 0xce, 0x7c, 0xcf, 0xc6, 0x3b, 0x2f, 0x9b}}
+	guidProviderIKEExt                    = GUID{0x10ad9216, 0xccde, 0x456c, [8]byte{0x8b, 0x16, 0xe9, 0xf0, 0x4e, 0x60, 0xa9, 0x0b}}
+	guidProviderIPSecDospConfig           = GUID{0x3c6c05a9, 0xc05c, 0x4bb9, [8]byte{0x83, 0x38, 0x23, 0x27, 0x81, 0x4c, 0xe8, 0xbf}}
+	guidProviderTCPChimneyOffload         = GUID{0x896aa19e, 0x9a34, 0x4bcb, [8]byte{0xae, 0x79, 0xbe, 0xb9, 0x12, 0x7c, 0x84, 0xb9}}
+	guidProviderTCPTemplates              = GUID{0x76cfcd30, 0x3394, 0x432d, [8]byte{0xbe, 0xd3, 0x44, 0x1a, 0xe5, 0x0e, 0x63, 0xc3}}
 )
 
 // Well-known sublayer IDs.
@@ -328,307 +326,307 @@ var (
 	guidSublayerUniversal                  = SublayerID{0xeebecc03, 0xced4, 0x4380, [8]byte{0x81, 0x9a, 0x27, 0x34, 0x39, 0x7b, 0x2b, 0x74}}
 )
 
-var guidNames = map[windows.GUID]string{
-	guidCalloutEdgeTraversalALEListenV4:                  "CALLOUT_EDGE_TRAVERSAL_ALE_LISTEN_V4",
-	guidCalloutEdgeTraversalALEResourceAssignmentV4:      "CALLOUT_EDGE_TRAVERSAL_ALE_RESOURCE_ASSIGNMENT_V4",
-	guidCalloutHttpTemplateSslHandshake:                  "CALLOUT_HTTP_TEMPLATE_SSL_HANDSHAKE",
-	guidCalloutIPSecALEConnectV4:                         "CALLOUT_IPSEC_ALE_CONNECT_V4",
-	guidCalloutIPSecALEConnectV6:                         "CALLOUT_IPSEC_ALE_CONNECT_V6",
-	guidCalloutIPSecDospForwardV4:                        "CALLOUT_IPSEC_DOSP_FORWARD_V4",
-	guidCalloutIPSecDospForwardV6:                        "CALLOUT_IPSEC_DOSP_FORWARD_V6",
-	guidCalloutIPSecForwardInboundTunnelV4:               "CALLOUT_IPSEC_FORWARD_INBOUND_TUNNEL_V4",
-	guidCalloutIPSecForwardInboundTunnelV6:               "CALLOUT_IPSEC_FORWARD_INBOUND_TUNNEL_V6",
-	guidCalloutIPSecForwardOutboundTunnelV4:              "CALLOUT_IPSEC_FORWARD_OUTBOUND_TUNNEL_V4",
-	guidCalloutIPSecForwardOutboundTunnelV6:              "CALLOUT_IPSEC_FORWARD_OUTBOUND_TUNNEL_V6",
-	guidCalloutIPSecInboundInitiateSecureV4:              "CALLOUT_IPSEC_INBOUND_INITIATE_SECURE_V4",
-	guidCalloutIPSecInboundInitiateSecureV6:              "CALLOUT_IPSEC_INBOUND_INITIATE_SECURE_V6",
-	guidCalloutIPSecInboundTransportV4:                   "CALLOUT_IPSEC_INBOUND_TRANSPORT_V4",
-	guidCalloutIPSecInboundTransportV6:                   "CALLOUT_IPSEC_INBOUND_TRANSPORT_V6",
-	guidCalloutIPSecInboundTunnelALEAcceptV4:             "CALLOUT_IPSEC_INBOUND_TUNNEL_ALE_ACCEPT_V4",
-	guidCalloutIPSecInboundTunnelALEAcceptV6:             "CALLOUT_IPSEC_INBOUND_TUNNEL_ALE_ACCEPT_V6",
-	guidCalloutIPSecInboundTunnelV4:                      "CALLOUT_IPSEC_INBOUND_TUNNEL_V4",
-	guidCalloutIPSecInboundTunnelV6:                      "CALLOUT_IPSEC_INBOUND_TUNNEL_V6",
-	guidCalloutIPSecOutboundTransportV4:                  "CALLOUT_IPSEC_OUTBOUND_TRANSPORT_V4",
-	guidCalloutIPSecOutboundTransportV6:                  "CALLOUT_IPSEC_OUTBOUND_TRANSPORT_V6",
-	guidCalloutIPSecOutboundTunnelV4:                     "CALLOUT_IPSEC_OUTBOUND_TUNNEL_V4",
-	guidCalloutIPSecOutboundTunnelV6:                     "CALLOUT_IPSEC_OUTBOUND_TUNNEL_V6",
-	guidCalloutPolicySilentModeAuthConnectLayerV4:        "CALLOUT_POLICY_SILENT_MODE_AUTH_CONNECT_LAYER_V4",
-	guidCalloutPolicySilentModeAuthConnectLayerV6:        "CALLOUT_POLICY_SILENT_MODE_AUTH_CONNECT_LAYER_V6",
-	guidCalloutPolicySilentModeAuthRecvAcceptLayerV4:     "CALLOUT_POLICY_SILENT_MODE_AUTH_RECV_ACCEPT_LAYER_V4",
-	guidCalloutPolicySilentModeAuthRecvAcceptLayerV6:     "CALLOUT_POLICY_SILENT_MODE_AUTH_RECV_ACCEPT_LAYER_V6",
-	guidCalloutReservedAuthConnectLayerV4:                "CALLOUT_RESERVED_AUTH_CONNECT_LAYER_V4",
-	guidCalloutReservedAuthConnectLayerV6:                "CALLOUT_RESERVED_AUTH_CONNECT_LAYER_V6",
-	guidCalloutSetOptionsAuthConnectLayerV4:              "CALLOUT_SET_OPTIONS_AUTH_CONNECT_LAYER_V4",
-	guidCalloutSetOptionsAuthConnectLayerV6:              "CALLOUT_SET_OPTIONS_AUTH_CONNECT_LAYER_V6",
-	guidCalloutSetOptionsAuthRecvAcceptLayerV4:           "CALLOUT_SET_OPTIONS_AUTH_RECV_ACCEPT_LAYER_V4",
-	guidCalloutSetOptionsAuthRecvAcceptLayerV6:           "CALLOUT_SET_OPTIONS_AUTH_RECV_ACCEPT_LAYER_V6",
-	guidCalloutTCPChimneyAcceptLayerV4:                   "CALLOUT_TCP_CHIMNEY_ACCEPT_LAYER_V4",
-	guidCalloutTCPChimneyAcceptLayerV6:                   "CALLOUT_TCP_CHIMNEY_ACCEPT_LAYER_V6",
-	guidCalloutTCPChimneyConnectLayerV4:                  "CALLOUT_TCP_CHIMNEY_CONNECT_LAYER_V4",
-	guidCalloutTCPChimneyConnectLayerV6:                  "CALLOUT_TCP_CHIMNEY_CONNECT_LAYER_V6",
-	guidCalloutTCPTemplatesAcceptLayerV4:                 "CALLOUT_TCP_TEMPLATES_ACCEPT_LAYER_V4",
-	guidCalloutTCPTemplatesAcceptLayerV6:                 "CALLOUT_TCP_TEMPLATES_ACCEPT_LAYER_V6",
-	guidCalloutTCPTemplatesConnectLayerV4:                "CALLOUT_TCP_TEMPLATES_CONNECT_LAYER_V4",
-	guidCalloutTCPTemplatesConnectLayerV6:                "CALLOUT_TCP_TEMPLATES_CONNECT_LAYER_V6",
-	guidCalloutTeredoALEListenV6:                         "CALLOUT_TEREDO_ALE_LISTEN_V6",
-	guidCalloutTeredoALEResourceAssignmentV6:             "CALLOUT_TEREDO_ALE_RESOURCE_ASSIGNMENT_V6",
-	guidCalloutWFPTransportLayerV4SilentDrop:             "CALLOUT_WFP_TRANSPORT_LAYER_V4_SILENT_DROP",
-	guidCalloutWFPTransportLayerV6SilentDrop:             "CALLOUT_WFP_TRANSPORT_LAYER_V6_SILENT_DROP",
-	windows.GUID(FieldALEAppID):                          "ALE_APP_ID",
-	windows.GUID(FieldALEEffectiveName):                  "ALE_EFFECTIVE_NAME",
-	windows.GUID(FieldALENAPContext):                     "ALE_NAP_CONTEXT",
-	windows.GUID(FieldALEOriginalAppID):                  "ALE_ORIGINAL_APP_ID",
-	windows.GUID(FieldALEPackageID):                      "ALE_PACKAGE_ID",
-	windows.GUID(FieldALEPromiscuousMode):                "ALE_PROMISCUOUS_MODE",
-	windows.GUID(FieldALEReauthReason):                   "ALE_REAUTH_REASON",
-	windows.GUID(FieldALERemoteMachineID):                "ALE_REMOTE_MACHINE_ID",
-	windows.GUID(FieldALERemoteUserID):                   "ALE_REMOTE_USER_ID",
-	windows.GUID(FieldALESecurityAttributeFqbnValue):     "ALE_SECURITY_ATTRIBUTE_FQBN_VALUE",
-	windows.GUID(FieldALESioFirewallSystemPort):          "ALE_SIO_FIREWALL_SYSTEM_PORT",
-	windows.GUID(FieldALEUserID):                         "ALE_USER_ID",
-	windows.GUID(FieldArrivalInterfaceIndex):             "ARRIVAL_INTERFACE_INDEX",
-	windows.GUID(FieldArrivalInterfaceProfileID):         "ARRIVAL_INTERFACE_PROFILE_ID",
-	windows.GUID(FieldArrivalInterfaceType):              "ARRIVAL_INTERFACE_TYPE",
-	windows.GUID(FieldArrivalTunnelType):                 "ARRIVAL_TUNNEL_TYPE",
-	windows.GUID(FieldAuthenticationType):                "AUTHENTICATION_TYPE",
-	windows.GUID(FieldBitmapIndexKey):                    "BITMAP_INDEX_KEY",
-	windows.GUID(FieldBitmapIPLocalAddress):              "BITMAP_IP_LOCAL_ADDRESS",
-	windows.GUID(FieldBitmapIPLocalPort):                 "BITMAP_IP_LOCAL_PORT",
-	windows.GUID(FieldBitmapIPRemoteAddress):             "BITMAP_IP_REMOTE_ADDRESS",
-	windows.GUID(FieldBitmapIPRemotePort):                "BITMAP_IP_REMOTE_PORT",
-	windows.GUID(FieldClientCertKeyLength):               "CLIENT_CERT_KEY_LENGTH",
-	windows.GUID(FieldClientCertOid):                     "CLIENT_CERT_OID",
-	windows.GUID(FieldClientToken):                       "CLIENT_TOKEN",
-	windows.GUID(FieldCompartmentID):                     "COMPARTMENT_ID",
-	windows.GUID(FieldCurrentProfileID):                  "CURRENT_PROFILE_ID",
-	windows.GUID(FieldDCOMAppID):                         "DCOM_APP_ID",
-	windows.GUID(FieldDestinationInterfaceIndex):         "DESTINATION_INTERFACE_INDEX",
-	windows.GUID(FieldDestinationSubInterfaceIndex):      "DESTINATION_SUB_INTERFACE_INDEX",
-	windows.GUID(FieldDirection):                         "DIRECTION",
-	windows.GUID(FieldEmbeddedLocalAddressType):          "EMBEDDED_LOCAL_ADDRESS_TYPE",
-	windows.GUID(FieldEmbeddedLocalPort):                 "EMBEDDED_LOCAL_PORT",
-	windows.GUID(FieldEmbeddedProtocol):                  "EMBEDDED_PROTOCOL",
-	windows.GUID(FieldEmbeddedRemoteAddress):             "EMBEDDED_REMOTE_ADDRESS",
-	windows.GUID(FieldEmbeddedRemotePort):                "EMBEDDED_REMOTE_PORT",
-	windows.GUID(FieldEtherType):                         "ETHER_TYPE",
-	windows.GUID(FieldFlags):                             "FLAGS",
-	windows.GUID(FieldImageName):                         "IMAGE_NAME",
-	windows.GUID(FieldInterfaceIndex):                    "INTERFACE_INDEX",
-	windows.GUID(FieldInterfaceMACAddress):               "INTERFACE_MAC_ADDRESS",
-	windows.GUID(FieldInterfaceQuarantineEpoch):          "INTERFACE_QUARANTINE_EPOCH",
-	windows.GUID(FieldInterfaceType):                     "INTERFACE_TYPE",
-	windows.GUID(FieldIPSecPolicyKey):                    "IPSEC_POLICY_KEY",
-	windows.GUID(FieldIPSecSecurityRealmID):              "IPSEC_SECURITY_REALM_ID",
-	windows.GUID(FieldIPArrivalInterface):                "IP_ARRIVAL_INTERFACE",
-	windows.GUID(FieldIPDestinationAddress):              "IP_DESTINATION_ADDRESS",
-	windows.GUID(FieldIPDestinationAddressType):          "IP_DESTINATION_ADDRESS_TYPE",
-	windows.GUID(FieldIPDestinationPort):                 "IP_DESTINATION_PORT",
-	windows.GUID(FieldIPForwardInterface):                "IP_FORWARD_INTERFACE",
-	windows.GUID(FieldIPLocalAddress):                    "IP_LOCAL_ADDRESS",
-	windows.GUID(FieldIPLocalAddressType):                "IP_LOCAL_ADDRESS_TYPE",
-	windows.GUID(FieldIPLocalAddressV4):                  "IP_LOCAL_ADDRESS_V4",
-	windows.GUID(FieldIPLocalAddressV6):                  "IP_LOCAL_ADDRESS_V6",
-	windows.GUID(FieldIPLocalInterface):                  "IP_LOCAL_INTERFACE",
-	windows.GUID(FieldIPLocalPort):                       "IP_LOCAL_PORT",
-	windows.GUID(FieldIPNexthopAddress):                  "IP_NEXTHOP_ADDRESS",
-	windows.GUID(FieldIPNexthopInterface):                "IP_NEXTHOP_INTERFACE",
-	windows.GUID(FieldIPPhysicalArrivalInterface):        "IP_PHYSICAL_ARRIVAL_INTERFACE",
-	windows.GUID(FieldIPPhysicalNexthopInterface):        "IP_PHYSICAL_NEXTHOP_INTERFACE",
-	windows.GUID(FieldIPProtocol):                        "IP_PROTOCOL",
-	windows.GUID(FieldIPRemoteAddress):                   "IP_REMOTE_ADDRESS",
-	windows.GUID(FieldIPRemoteAddressV4):                 "IP_REMOTE_ADDRESS_V4",
-	windows.GUID(FieldIPRemoteAddressV6):                 "IP_REMOTE_ADDRESS_V6",
-	windows.GUID(FieldIPRemotePort):                      "IP_REMOTE_PORT",
-	windows.GUID(FieldIPSourceAddress):                   "IP_SOURCE_ADDRESS",
-	windows.GUID(FieldIPSourcePort):                      "IP_SOURCE_PORT",
-	windows.GUID(FieldKMAuthNAPContext):                  "KM_AUTH_NAP_CONTEXT",
-	windows.GUID(FieldKMMode):                            "KM_MODE",
-	windows.GUID(FieldKMType):                            "KM_TYPE",
-	windows.GUID(FieldL2Flags):                           "L2_FLAGS",
-	windows.GUID(FieldLocalInterfaceProfileID):           "LOCAL_INTERFACE_PROFILE_ID",
-	windows.GUID(FieldMACDestinationAddress):             "MAC_DESTINATION_ADDRESS",
-	windows.GUID(FieldMACDestinationAddressType):         "MAC_DESTINATION_ADDRESS_TYPE",
-	windows.GUID(FieldMACLocalAddress):                   "MAC_LOCAL_ADDRESS",
-	windows.GUID(FieldMACLocalAddressType):               "MAC_LOCAL_ADDRESS_TYPE",
-	windows.GUID(FieldMACRemoteAddress):                  "MAC_REMOTE_ADDRESS",
-	windows.GUID(FieldMACRemoteAddressType):              "MAC_REMOTE_ADDRESS_TYPE",
-	windows.GUID(FieldMACSourceAddress):                  "MAC_SOURCE_ADDRESS",
-	windows.GUID(FieldMACSourceAddressType):              "MAC_SOURCE_ADDRESS_TYPE",
-	windows.GUID(FieldNdisMediaType):                     "NDIS_MEDIA_TYPE",
-	windows.GUID(FieldNdisPhysicalMediaType):             "NDIS_PHYSICAL_MEDIA_TYPE",
-	windows.GUID(FieldNdisPort):                          "NDIS_PORT",
-	windows.GUID(FieldNetEventType):                      "NET_EVENT_TYPE",
-	windows.GUID(FieldNexthopInterfaceIndex):             "NEXTHOP_INTERFACE_INDEX",
-	windows.GUID(FieldNexthopInterfaceProfileID):         "NEXTHOP_INTERFACE_PROFILE_ID",
-	windows.GUID(FieldNexthopInterfaceType):              "NEXTHOP_INTERFACE_TYPE",
-	windows.GUID(FieldNexthopSubInterfaceIndex):          "NEXTHOP_SUB_INTERFACE_INDEX",
-	windows.GUID(FieldNexthopTunnelType):                 "NEXTHOP_TUNNEL_TYPE",
-	windows.GUID(FieldOriginalICMPType):                  "ORIGINAL_ICMP_TYPE",
-	windows.GUID(FieldOriginalProfileID):                 "ORIGINAL_PROFILE_ID",
-	windows.GUID(FieldPeerName):                          "PEER_NAME",
-	windows.GUID(FieldPipe):                              "PIPE",
-	windows.GUID(FieldProcessWithRPCIfUUID):              "PROCESS_WITH_RPC_IF_UUID",
-	windows.GUID(FieldQMMode):                            "QM_MODE",
-	windows.GUID(FieldReauthorizeReason):                 "REAUTHORIZE_REASON",
-	windows.GUID(FieldRemoteID):                          "REMOTE_ID",
-	windows.GUID(FieldRemoteUserToken):                   "REMOTE_USER_TOKEN",
-	windows.GUID(FieldReserved0):                         "RESERVED0",
-	windows.GUID(FieldReserved1):                         "RESERVED1",
-	windows.GUID(FieldReserved10):                        "RESERVED10",
-	windows.GUID(FieldReserved11):                        "RESERVED11",
-	windows.GUID(FieldReserved12):                        "RESERVED12",
-	windows.GUID(FieldReserved13):                        "RESERVED13",
-	windows.GUID(FieldReserved14):                        "RESERVED14",
-	windows.GUID(FieldReserved15):                        "RESERVED15",
-	windows.GUID(FieldReserved2):                         "RESERVED2",
-	windows.GUID(FieldReserved3):                         "RESERVED3",
-	windows.GUID(FieldReserved4):                         "RESERVED4",
-	windows.GUID(FieldReserved5):                         "RESERVED5",
-	windows.GUID(FieldReserved6):                         "RESERVED6",
-	windows.GUID(FieldReserved7):                         "RESERVED7",
-	windows.GUID(FieldReserved8):                         "RESERVED8",
-	windows.GUID(FieldReserved9):                         "RESERVED9",
-	windows.GUID(FieldRPCAuthLevel):                      "RPC_AUTH_LEVEL",
-	windows.GUID(FieldRPCAuthType):                       "RPC_AUTH_TYPE",
-	windows.GUID(FieldRPCEPFlags):                        "RPC_EP_FLAGS",
-	windows.GUID(FieldRPCEPValue):                        "RPC_EP_VALUE",
-	windows.GUID(FieldRPCIfFlag):                         "RPC_IF_FLAG",
-	windows.GUID(FieldRPCIfUUID):                         "RPC_IF_UUID",
-	windows.GUID(FieldRPCIfVersion):                      "RPC_IF_VERSION",
-	windows.GUID(FieldRPCProtocol):                       "RPC_PROTOCOL",
-	windows.GUID(FieldRPCProxyAuthType):                  "RPC_PROXY_AUTH_TYPE",
-	windows.GUID(FieldRPCServerName):                     "RPC_SERVER_NAME",
-	windows.GUID(FieldRPCServerPort):                     "RPC_SERVER_PORT",
-	windows.GUID(FieldSecEncryptAlgorithm):               "SEC_ENCRYPT_ALGORITHM",
-	windows.GUID(FieldSecKeySize):                        "SEC_KEY_SIZE",
-	windows.GUID(FieldSourceInterfaceIndex):              "SOURCE_INTERFACE_INDEX",
-	windows.GUID(FieldSourceSubInterfaceIndex):           "SOURCE_SUB_INTERFACE_INDEX",
-	windows.GUID(FieldSubInterfaceIndex):                 "SUB_INTERFACE_INDEX",
-	windows.GUID(FieldTunnelType):                        "TUNNEL_TYPE",
-	windows.GUID(FieldVLANID):                            "VLAN_ID",
-	windows.GUID(FieldVSwitchDestinationInterfaceID):     "VSWITCH_DESTINATION_INTERFACE_ID",
-	windows.GUID(FieldVSwitchDestinationInterfaceType):   "VSWITCH_DESTINATION_INTERFACE_TYPE",
-	windows.GUID(FieldVSwitchDestinationVmID):            "VSWITCH_DESTINATION_VM_ID",
-	windows.GUID(FieldVSwitchID):                         "VSWITCH_ID",
-	windows.GUID(FieldVSwitchNetworkType):                "VSWITCH_NETWORK_TYPE",
-	windows.GUID(FieldVSwitchSourceInterfaceID):          "VSWITCH_SOURCE_INTERFACE_ID",
-	windows.GUID(FieldVSwitchSourceInterfaceType):        "VSWITCH_SOURCE_INTERFACE_TYPE",
-	windows.GUID(FieldVSwitchSourceVmID):                 "VSWITCH_SOURCE_VM_ID",
-	windows.GUID(FieldVSwitchTenantNetworkID):            "VSWITCH_TENANT_NETWORK_ID",
-	guidKeyingModuleAuthIP:                               "KEYING_MODULE_AUTHIP",
-	guidKeyingModuleIKE:                                  "KEYING_MODULE_IKE",
-	guidKeyingModuleIKEv2:                                "KEYING_MODULE_IKEV2",
-	windows.GUID(LayerALEAuthConnectV4):                  "ALE_AUTH_CONNECT_V4",
-	windows.GUID(LayerALEAuthConnectV4Discard):           "ALE_AUTH_CONNECT_V4_DISCARD",
-	windows.GUID(LayerALEAuthConnectV6):                  "ALE_AUTH_CONNECT_V6",
-	windows.GUID(LayerALEAuthConnectV6Discard):           "ALE_AUTH_CONNECT_V6_DISCARD",
-	windows.GUID(LayerALEAuthListenV4):                   "ALE_AUTH_LISTEN_V4",
-	windows.GUID(LayerALEAuthListenV4Discard):            "ALE_AUTH_LISTEN_V4_DISCARD",
-	windows.GUID(LayerALEAuthListenV6):                   "ALE_AUTH_LISTEN_V6",
-	windows.GUID(LayerALEAuthListenV6Discard):            "ALE_AUTH_LISTEN_V6_DISCARD",
-	windows.GUID(LayerALEAuthRecvAcceptV4):               "ALE_AUTH_RECV_ACCEPT_V4",
-	windows.GUID(LayerALEAuthRecvAcceptV4Discard):        "ALE_AUTH_RECV_ACCEPT_V4_DISCARD",
-	windows.GUID(LayerALEAuthRecvAcceptV6):               "ALE_AUTH_RECV_ACCEPT_V6",
-	windows.GUID(LayerALEAuthRecvAcceptV6Discard):        "ALE_AUTH_RECV_ACCEPT_V6_DISCARD",
-	windows.GUID(LayerALEBindRedirectV4):                 "ALE_BIND_REDIRECT_V4",
-	windows.GUID(LayerALEBindRedirectV6):                 "ALE_BIND_REDIRECT_V6",
-	windows.GUID(LayerALEConnectRedirectV4):              "ALE_CONNECT_REDIRECT_V4",
-	windows.GUID(LayerALEConnectRedirectV6):              "ALE_CONNECT_REDIRECT_V6",
-	windows.GUID(LayerALEEndpointClosureV4):              "ALE_ENDPOINT_CLOSURE_V4",
-	windows.GUID(LayerALEEndpointClosureV6):              "ALE_ENDPOINT_CLOSURE_V6",
-	windows.GUID(LayerALEFlowEstablishedV4):              "ALE_FLOW_ESTABLISHED_V4",
-	windows.GUID(LayerALEFlowEstablishedV4Discard):       "ALE_FLOW_ESTABLISHED_V4_DISCARD",
-	windows.GUID(LayerALEFlowEstablishedV6):              "ALE_FLOW_ESTABLISHED_V6",
-	windows.GUID(LayerALEFlowEstablishedV6Discard):       "ALE_FLOW_ESTABLISHED_V6_DISCARD",
-	windows.GUID(LayerALEResourceAssignmentV4):           "ALE_RESOURCE_ASSIGNMENT_V4",
-	windows.GUID(LayerALEResourceAssignmentV4Discard):    "ALE_RESOURCE_ASSIGNMENT_V4_DISCARD",
-	windows.GUID(LayerALEResourceAssignmentV6):           "ALE_RESOURCE_ASSIGNMENT_V6",
-	windows.GUID(LayerALEResourceAssignmentV6Discard):    "ALE_RESOURCE_ASSIGNMENT_V6_DISCARD",
-	windows.GUID(LayerALEResourceReleaseV4):              "ALE_RESOURCE_RELEASE_V4",
-	windows.GUID(LayerALEResourceReleaseV6):              "ALE_RESOURCE_RELEASE_V6",
-	windows.GUID(LayerDatagramDataV4):                    "DATAGRAM_DATA_V4",
-	windows.GUID(LayerDatagramDataV4Discard):             "DATAGRAM_DATA_V4_DISCARD",
-	windows.GUID(LayerDatagramDataV6):                    "DATAGRAM_DATA_V6",
-	windows.GUID(LayerDatagramDataV6Discard):             "DATAGRAM_DATA_V6_DISCARD",
-	windows.GUID(LayerEgressVSwitchEthernet):             "EGRESS_VSWITCH_ETHERNET",
-	windows.GUID(LayerEgressVSwitchTransportV4):          "EGRESS_VSWITCH_TRANSPORT_V4",
-	windows.GUID(LayerEgressVSwitchTransportV6):          "EGRESS_VSWITCH_TRANSPORT_V6",
-	windows.GUID(LayerIKEExtV4):                          "IKEEXT_V4",
-	windows.GUID(LayerIKEExtV6):                          "IKEEXT_V6",
-	windows.GUID(LayerInboundICMPErrorV4):                "INBOUND_ICMP_ERROR_V4",
-	windows.GUID(LayerInboundICMPErrorV4Discard):         "INBOUND_ICMP_ERROR_V4_DISCARD",
-	windows.GUID(LayerInboundICMPErrorV6):                "INBOUND_ICMP_ERROR_V6",
-	windows.GUID(LayerInboundICMPErrorV6Discard):         "INBOUND_ICMP_ERROR_V6_DISCARD",
-	windows.GUID(LayerInboundIPPacketV4):                 "INBOUND_IPPACKET_V4",
-	windows.GUID(LayerInboundIPPacketV4Discard):          "INBOUND_IPPACKET_V4_DISCARD",
-	windows.GUID(LayerInboundIPPacketV6):                 "INBOUND_IPPACKET_V6",
-	windows.GUID(LayerInboundIPPacketV6Discard):          "INBOUND_IPPACKET_V6_DISCARD",
-	windows.GUID(LayerInboundMACFrameEthernet):           "INBOUND_MAC_FRAME_ETHERNET",
-	windows.GUID(LayerInboundMACFrameNative):             "INBOUND_MAC_FRAME_NATIVE",
-	windows.GUID(LayerInboundMACFrameNativeFast):         "INBOUND_MAC_FRAME_NATIVE_FAST",
-	windows.GUID(LayerInboundReserved2):                  "INBOUND_RESERVED2",
-	windows.GUID(LayerInboundTransportFast):              "INBOUND_TRANSPORT_FAST",
-	windows.GUID(LayerInboundTransportV4):                "INBOUND_TRANSPORT_V4",
-	windows.GUID(LayerInboundTransportV4Discard):         "INBOUND_TRANSPORT_V4_DISCARD",
-	windows.GUID(LayerInboundTransportV6):                "INBOUND_TRANSPORT_V6",
-	windows.GUID(LayerInboundTransportV6Discard):         "INBOUND_TRANSPORT_V6_DISCARD",
-	windows.GUID(LayerIngressVSwitchEthernet):            "INGRESS_VSWITCH_ETHERNET",
-	windows.GUID(LayerIngressVSwitchTransportV4):         "INGRESS_VSWITCH_TRANSPORT_V4",
-	windows.GUID(LayerIngressVSwitchTransportV6):         "INGRESS_VSWITCH_TRANSPORT_V6",
-	windows.GUID(LayerIPForwardV4):                       "IPFORWARD_V4",
-	windows.GUID(LayerIPForwardV4Discard):                "IPFORWARD_V4_DISCARD",
-	windows.GUID(LayerIPForwardV6):                       "IPFORWARD_V6",
-	windows.GUID(LayerIPForwardV6Discard):                "IPFORWARD_V6_DISCARD",
-	windows.GUID(LayerIPSecKMDemuxV4):                    "IPSEC_KM_DEMUX_V4",
-	windows.GUID(LayerIPSecKMDemuxV6):                    "IPSEC_KM_DEMUX_V6",
-	windows.GUID(LayerIPSecV4):                           "IPSEC_V4",
-	windows.GUID(LayerIPSecV6):                           "IPSEC_V6",
-	windows.GUID(LayerKMAuthorization):                   "KM_AUTHORIZATION",
-	windows.GUID(LayerNameResolutionCacheV4):             "NAME_RESOLUTION_CACHE_V4",
-	windows.GUID(LayerNameResolutionCacheV6):             "NAME_RESOLUTION_CACHE_V6",
-	windows.GUID(LayerOutboundICMPErrorV4):               "OUTBOUND_ICMP_ERROR_V4",
-	windows.GUID(LayerOutboundICMPErrorV4Discard):        "OUTBOUND_ICMP_ERROR_V4_DISCARD",
-	windows.GUID(LayerOutboundICMPErrorV6):               "OUTBOUND_ICMP_ERROR_V6",
-	windows.GUID(LayerOutboundICMPErrorV6Discard):        "OUTBOUND_ICMP_ERROR_V6_DISCARD",
-	windows.GUID(LayerOutboundIPPacketV4):                "OUTBOUND_IPPACKET_V4",
-	windows.GUID(LayerOutboundIPPacketV4Discard):         "OUTBOUND_IPPACKET_V4_DISCARD",
-	windows.GUID(LayerOutboundIPPacketV6):                "OUTBOUND_IPPACKET_V6",
-	windows.GUID(LayerOutboundIPPacketV6Discard):         "OUTBOUND_IPPACKET_V6_DISCARD",
-	windows.GUID(LayerOutboundMACFrameEthernet):          "OUTBOUND_MAC_FRAME_ETHERNET",
-	windows.GUID(LayerOutboundMACFrameNative):            "OUTBOUND_MAC_FRAME_NATIVE",
-	windows.GUID(LayerOutboundMACFrameNativeFast):        "OUTBOUND_MAC_FRAME_NATIVE_FAST",
-	windows.GUID(LayerOutboundTransportFast):             "OUTBOUND_TRANSPORT_FAST",
-	windows.GUID(LayerOutboundTransportV4):               "OUTBOUND_TRANSPORT_V4",
-	windows.GUID(LayerOutboundTransportV4Discard):        "OUTBOUND_TRANSPORT_V4_DISCARD",
-	windows.GUID(LayerOutboundTransportV6):               "OUTBOUND_TRANSPORT_V6",
-	windows.GUID(LayerOutboundTransportV6Discard):        "OUTBOUND_TRANSPORT_V6_DISCARD",
-	windows.GUID(LayerRPCEPMap):                          "RPC_EPMAP",
-	windows.GUID(LayerRPCEPAdd):                          "RPC_EP_ADD",
-	windows.GUID(LayerRPCProxyConn):                      "RPC_PROXY_CONN",
-	windows.GUID(LayerRPCProxyIf):                        "RPC_PROXY_IF",
-	windows.GUID(LayerRPCUM):                             "RPC_UM",
-	windows.GUID(LayerStreamPacketV4):                    "STREAM_PACKET_V4",
-	windows.GUID(LayerStreamPacketV6):                    "STREAM_PACKET_V6",
-	windows.GUID(LayerStreamV4):                          "STREAM_V4",
-	windows.GUID(LayerStreamV4Discard):                   "STREAM_V4_DISCARD",
-	windows.GUID(LayerStreamV6):                          "STREAM_V6",
-	windows.GUID(LayerStreamV6Discard):                   "STREAM_V6_DISCARD",
-	guidProviderContextSecureSocketAuthIP:                "PROVIDER_CONTEXT_SECURE_SOCKET_AUTHIP",
-	guidProviderContextSecureSocketIPSec:                 "PROVIDER_CONTEXT_SECURE_SOCKET_IPSEC",
-	guidProviderIKEExt:                                   "PROVIDER_IKEEXT",
-	guidProviderIPSecDospConfig:                          "PROVIDER_IPSEC_DOSP_CONFIG",
-	guidProviderTCPChimneyOffload:                        "PROVIDER_TCP_CHIMNEY_OFFLOAD",
-	guidProviderTCPTemplates:                             "PROVIDER_TCP_TEMPLATES",
-	windows.GUID(guidSublayerInspection):                 "SUBLAYER_INSPECTION",
-	windows.GUID(guidSublayerIPSecDosp):                  "SUBLAYER_IPSEC_DOSP",
-	windows.GUID(guidSublayerIPSecForwardOutboundTunnel): "SUBLAYER_IPSEC_FORWARD_OUTBOUND_TUNNEL",
-	windows.GUID(guidSublayerIPSecSecurityRealm):         "SUBLAYER_IPSEC_SECURITY_REALM",
-	windows.GUID(guidSublayerIPSecTunnel):                "SUBLAYER_IPSEC_TUNNEL",
-	windows.GUID(guidSublayerLIPS):                       "SUBLAYER_LIPS",
-	windows.GUID(guidSublayerRPCAudit):                   "SUBLAYER_RPC_AUDIT",
-	windows.GUID(guidSublayerSecureSocket):               "SUBLAYER_SECURE_SOCKET",
-	windows.GUID(guidSublayerTCPChimneyOffload):          "SUBLAYER_TCP_CHIMNEY_OFFLOAD",
-	windows.GUID(guidSublayerTCPTemplates):               "SUBLAYER_TCP_TEMPLATES",
-	windows.GUID(guidSublayerTeredo):                     "SUBLAYER_TEREDO",
-	windows.GUID(guidSublayerUniversal):                  "SUBLAYER_UNIVERSAL",
+var guidNames = map[GUID]string{
+	guidCalloutEdgeTraversalALEListenV4:              "CALLOUT_EDGE_TRAVERSAL_ALE_LISTEN_V4",
+	guidCalloutEdgeTraversalALEResourceAssignmentV4:  "CALLOUT_EDGE_TRAVERSAL_ALE_RESOURCE_ASSIGNMENT_V4",
+	guidCalloutHttpTemplateSslHandshake:              "CALLOUT_HTTP_TEMPLATE_SSL_HANDSHAKE",
+	guidCalloutIPSecALEConnectV4:                     "CALLOUT_IPSEC_ALE_CONNECT_V4",
+	guidCalloutIPSecALEConnectV6:                     "CALLOUT_IPSEC_ALE_CONNECT_V6",
+	guidCalloutIPSecDospForwardV4:                    "CALLOUT_IPSEC_DOSP_FORWARD_V4",
+	guidCalloutIPSecDospForwardV6:                    "CALLOUT_IPSEC_DOSP_FORWARD_V6",
+	guidCalloutIPSecForwardInboundTunnelV4:           "CALLOUT_IPSEC_FORWARD_INBOUND_TUNNEL_V4",
+	guidCalloutIPSecForwardInboundTunnelV6:           "CALLOUT_IPSEC_FORWARD_INBOUND_TUNNEL_V6",
+	guidCalloutIPSecForwardOutboundTunnelV4:          "CALLOUT_IPSEC_FORWARD_OUTBOUND_TUNNEL_V4",
+	guidCalloutIPSecForwardOutboundTunnelV6:          "CALLOUT_IPSEC_FORWARD_OUTBOUND_TUNNEL_V6",
+	guidCalloutIPSecInboundInitiateSecureV4:          "CALLOUT_IPSEC_INBOUND_INITIATE_SECURE_V4",
+	guidCalloutIPSecInboundInitiateSecureV6:          "CALLOUT_IPSEC_INBOUND_INITIATE_SECURE_V6",
+	guidCalloutIPSecInboundTransportV4:               "CALLOUT_IPSEC_INBOUND_TRANSPORT_V4",
+	guidCalloutIPSecInboundTransportV6:               "CALLOUT_IPSEC_INBOUND_TRANSPORT_V6",
+	guidCalloutIPSecInboundTunnelALEAcceptV4:         "CALLOUT_IPSEC_INBOUND_TUNNEL_ALE_ACCEPT_V4",
+	guidCalloutIPSecInboundTunnelALEAcceptV6:         "CALLOUT_IPSEC_INBOUND_TUNNEL_ALE_ACCEPT_V6",
+	guidCalloutIPSecInboundTunnelV4:                  "CALLOUT_IPSEC_INBOUND_TUNNEL_V4",
+	guidCalloutIPSecInboundTunnelV6:                  "CALLOUT_IPSEC_INBOUND_TUNNEL_V6",
+	guidCalloutIPSecOutboundTransportV4:              "CALLOUT_IPSEC_OUTBOUND_TRANSPORT_V4",
+	guidCalloutIPSecOutboundTransportV6:              "CALLOUT_IPSEC_OUTBOUND_TRANSPORT_V6",
+	guidCalloutIPSecOutboundTunnelV4:                 "CALLOUT_IPSEC_OUTBOUND_TUNNEL_V4",
+	guidCalloutIPSecOutboundTunnelV6:                 "CALLOUT_IPSEC_OUTBOUND_TUNNEL_V6",
+	guidCalloutPolicySilentModeAuthConnectLayerV4:    "CALLOUT_POLICY_SILENT_MODE_AUTH_CONNECT_LAYER_V4",
+	guidCalloutPolicySilentModeAuthConnectLayerV6:    "CALLOUT_POLICY_SILENT_MODE_AUTH_CONNECT_LAYER_V6",
+	guidCalloutPolicySilentModeAuthRecvAcceptLayerV4: "CALLOUT_POLICY_SILENT_MODE_AUTH_RECV_ACCEPT_LAYER_V4",
+	guidCalloutPolicySilentModeAuthRecvAcceptLayerV6: "CALLOUT_POLICY_SILENT_MODE_AUTH_RECV_ACCEPT_LAYER_V6",
+	guidCalloutReservedAuthConnectLayerV4:            "CALLOUT_RESERVED_AUTH_CONNECT_LAYER_V4",
+	guidCalloutReservedAuthConnectLayerV6:            "CALLOUT_RESERVED_AUTH_CONNECT_LAYER_V6",
+	guidCalloutSetOptionsAuthConnectLayerV4:          "CALLOUT_SET_OPTIONS_AUTH_CONNECT_LAYER_V4",
+	guidCalloutSetOptionsAuthConnectLayerV6:          "CALLOUT_SET_OPTIONS_AUTH_CONNECT_LAYER_V6",
+	guidCalloutSetOptionsAuthRecvAcceptLayerV4:       "CALLOUT_SET_OPTIONS_AUTH_RECV_ACCEPT_LAYER_V4",
+	guidCalloutSetOptionsAuthRecvAcceptLayerV6:       "CALLOUT_SET_OPTIONS_AUTH_RECV_ACCEPT_LAYER_V6",
+	guidCalloutTCPChimneyAcceptLayerV4:               "CALLOUT_TCP_CHIMNEY_ACCEPT_LAYER_V4",
+	guidCalloutTCPChimneyAcceptLayerV6:               "CALLOUT_TCP_CHIMNEY_ACCEPT_LAYER_V6",
+	guidCalloutTCPChimneyConnectLayerV4:              "CALLOUT_TCP_CHIMNEY_CONNECT_LAYER_V4",
+	guidCalloutTCPChimneyConnectLayerV6:              "CALLOUT_TCP_CHIMNEY_CONNECT_LAYER_V6",
+	guidCalloutTCPTemplatesAcceptLayerV4:             "CALLOUT_TCP_TEMPLATES_ACCEPT_LAYER_V4",
+	guidCalloutTCPTemplatesAcceptLayerV6:             "CALLOUT_TCP_TEMPLATES_ACCEPT_LAYER_V6",
+	guidCalloutTCPTemplatesConnectLayerV4:            "CALLOUT_TCP_TEMPLATES_CONNECT_LAYER_V4",
+	guidCalloutTCPTemplatesConnectLayerV6:            "CALLOUT_TCP_TEMPLATES_CONNECT_LAYER_V6",
+	guidCalloutTeredoALEListenV6:                     "CALLOUT_TEREDO_ALE_LISTEN_V6",
+	guidCalloutTeredoALEResourceAssignmentV6:         "CALLOUT_TEREDO_ALE_RESOURCE_ASSIGNMENT_V6",
+	guidCalloutWFPTransportLayerV4SilentDrop:         "CALLOUT_WFP_TRANSPORT_LAYER_V4_SILENT_DROP",
+	guidCalloutWFPTransportLayerV6SilentDrop:         "CALLOUT_WFP_TRANSPORT_LAYER_V6_SILENT_DROP",
+	GUID(FieldALEAppID):                              "ALE_APP_ID",
+	GUID(FieldALEEffectiveName):                      "ALE_EFFECTIVE_NAME",
+	GUID(FieldALENAPContext):                         "ALE_NAP_CONTEXT",
+	GUID(FieldALEOriginalAppID):                      "ALE_ORIGINAL_APP_ID",
+	GUID(FieldALEPackageID):                          "ALE_PACKAGE_ID",
+	GUID(FieldALEPromiscuousMode):                    "ALE_PROMISCUOUS_MODE",
+	GUID(FieldALEReauthReason):                       "ALE_REAUTH_REASON",
+	GUID(FieldALERemoteMachineID):                    "ALE_REMOTE_MACHINE_ID",
+	GUID(FieldALERemoteUserID):                       "ALE_REMOTE_USER_ID",
+	GUID(FieldALESecurityAttributeFqbnValue):         "ALE_SECURITY_ATTRIBUTE_FQBN_VALUE",
+	GUID(FieldALESioFirewallSystemPort):              "ALE_SIO_FIREWALL_SYSTEM_PORT",
+	GUID(FieldALEUserID):                             "ALE_USER_ID",
+	GUID(FieldArrivalInterfaceIndex):                 "ARRIVAL_INTERFACE_INDEX",
+	GUID(FieldArrivalInterfaceProfileID):             "ARRIVAL_INTERFACE_PROFILE_ID",
+	GUID(FieldArrivalInterfaceType):                  "ARRIVAL_INTERFACE_TYPE",
+	GUID(FieldArrivalTunnelType):                     "ARRIVAL_TUNNEL_TYPE",
+	GUID(FieldAuthenticationType):                    "AUTHENTICATION_TYPE",
+	GUID(FieldBitmapIndexKey):                        "BITMAP_INDEX_KEY",
+	GUID(FieldBitmapIPLocalAddress):                  "BITMAP_IP_LOCAL_ADDRESS",
+	GUID(FieldBitmapIPLocalPort):                     "BITMAP_IP_LOCAL_PORT",
+	GUID(FieldBitmapIPRemoteAddress):                 "BITMAP_IP_REMOTE_ADDRESS",
+	GUID(FieldBitmapIPRemotePort):                    "BITMAP_IP_REMOTE_PORT",
+	GUID(FieldClientCertKeyLength):                   "CLIENT_CERT_KEY_LENGTH",
+	GUID(FieldClientCertOid):                         "CLIENT_CERT_OID",
+	GUID(FieldClientToken):                           "CLIENT_TOKEN",
+	GUID(FieldCompartmentID):                         "COMPARTMENT_ID",
+	GUID(FieldCurrentProfileID):                      "CURRENT_PROFILE_ID",
+	GUID(FieldDCOMAppID):                             "DCOM_APP_ID",
+	GUID(FieldDestinationInterfaceIndex):             "DESTINATION_INTERFACE_INDEX",
+	GUID(FieldDestinationSubInterfaceIndex):          "DESTINATION_SUB_INTERFACE_INDEX",
+	GUID(FieldDirection):                             "DIRECTION",
+	GUID(FieldEmbeddedLocalAddressType):              "EMBEDDED_LOCAL_ADDRESS_TYPE",
+	GUID(FieldEmbeddedLocalPort):                     "EMBEDDED_LOCAL_PORT",
+	GUID(FieldEmbeddedProtocol):                      "EMBEDDED_PROTOCOL",
+	GUID(FieldEmbeddedRemoteAddress):                 "EMBEDDED_REMOTE_ADDRESS",
+	GUID(FieldEmbeddedRemotePort):                    "EMBEDDED_REMOTE_PORT",
+	GUID(FieldEtherType):                             "ETHER_TYPE",
+	GUID(FieldFlags):                                 "FLAGS",
+	GUID(FieldImageName):                             "IMAGE_NAME",
+	GUID(FieldInterfaceIndex):                        "INTERFACE_INDEX",
+	GUID(FieldInterfaceMACAddress):                   "INTERFACE_MAC_ADDRESS",
+	GUID(FieldInterfaceQuarantineEpoch):              "INTERFACE_QUARANTINE_EPOCH",
+	GUID(FieldInterfaceType):                         "INTERFACE_TYPE",
+	GUID(FieldIPSecPolicyKey):                        "IPSEC_POLICY_KEY",
+	GUID(FieldIPSecSecurityRealmID):                  "IPSEC_SECURITY_REALM_ID",
+	GUID(FieldIPArrivalInterface):                    "IP_ARRIVAL_INTERFACE",
+	GUID(FieldIPDestinationAddress):                  "IP_DESTINATION_ADDRESS",
+	GUID(FieldIPDestinationAddressType):              "IP_DESTINATION_ADDRESS_TYPE",
+	GUID(FieldIPDestinationPort):                     "IP_DESTINATION_PORT",
+	GUID(FieldIPForwardInterface):                    "IP_FORWARD_INTERFACE",
+	GUID(FieldIPLocalAddress):                        "IP_LOCAL_ADDRESS",
+	GUID(FieldIPLocalAddressType):                    "IP_LOCAL_ADDRESS_TYPE",
+	GUID(FieldIPLocalAddressV4):                      "IP_LOCAL_ADDRESS_V4",
+	GUID(FieldIPLocalAddressV6):                      "IP_LOCAL_ADDRESS_V6",
+	GUID(FieldIPLocalInterface):                      "IP_LOCAL_INTERFACE",
+	GUID(FieldIPLocalPort):                           "IP_LOCAL_PORT",
+	GUID(FieldIPNexthopAddress):                      "IP_NEXTHOP_ADDRESS",
+	GUID(FieldIPNexthopInterface):                    "IP_NEXTHOP_INTERFACE",
+	GUID(FieldIPPhysicalArrivalInterface):            "IP_PHYSICAL_ARRIVAL_INTERFACE",
+	GUID(FieldIPPhysicalNexthopInterface):            "IP_PHYSICAL_NEXTHOP_INTERFACE",
+	GUID(FieldIPProtocol):                            "IP_PROTOCOL",
+	GUID(FieldIPRemoteAddress):                       "IP_REMOTE_ADDRESS",
+	GUID(FieldIPRemoteAddressV4):                     "IP_REMOTE_ADDRESS_V4",
+	GUID(FieldIPRemoteAddressV6):                     "IP_REMOTE_ADDRESS_V6",
+	GUID(FieldIPRemotePort):                          "IP_REMOTE_PORT",
+	GUID(FieldIPSourceAddress):                       "IP_SOURCE_ADDRESS",
+	GUID(FieldIPSourcePort):                          "IP_SOURCE_PORT",
+	GUID(FieldKMAuthNAPContext):                      "KM_AUTH_NAP_CONTEXT",
+	GUID(FieldKMMode):                                "KM_MODE",
+	GUID(FieldKMType):                                "KM_TYPE",
+	GUID(FieldL2Flags):                               "L2_FLAGS",
+	GUID(FieldLocalInterfaceProfileID):               "LOCAL_INTERFACE_PROFILE_ID",
+	GUID(FieldMACDestinationAddress):                 "MAC_DESTINATION_ADDRESS",
+	GUID(FieldMACDestinationAddressType):             "MAC_DESTINATION_ADDRESS_TYPE",
+	GUID(FieldMACLocalAddress):                       "MAC_LOCAL_ADDRESS",
+	GUID(FieldMACLocalAddressType):                   "MAC_LOCAL_ADDRESS_TYPE",
+	GUID(FieldMACRemoteAddress):                      "MAC_REMOTE_ADDRESS",
+	GUID(FieldMACRemoteAddressType):                  "MAC_REMOTE_ADDRESS_TYPE",
+	GUID(FieldMACSourceAddress):                      "MAC_SOURCE_ADDRESS",
+	GUID(FieldMACSourceAddressType):                  "MAC_SOURCE_ADDRESS_TYPE",
+	GUID(FieldNdisMediaType):                         "NDIS_MEDIA_TYPE",
+	GUID(FieldNdisPhysicalMediaType):                 "NDIS_PHYSICAL_MEDIA_TYPE",
+	GUID(FieldNdisPort):                              "NDIS_PORT",
+	GUID(FieldNetEventType):                          "NET_EVENT_TYPE",
+	GUID(FieldNexthopInterfaceIndex):                 "NEXTHOP_INTERFACE_INDEX",
+	GUID(FieldNexthopInterfaceProfileID):             "NEXTHOP_INTERFACE_PROFILE_ID",
+	GUID(FieldNexthopInterfaceType):                  "NEXTHOP_INTERFACE_TYPE",
+	GUID(FieldNexthopSubInterfaceIndex):              "NEXTHOP_SUB_INTERFACE_INDEX",
+	GUID(FieldNexthopTunnelType):                     "NEXTHOP_TUNNEL_TYPE",
+	GUID(FieldOriginalICMPType):                      "ORIGINAL_ICMP_TYPE",
+	GUID(FieldOriginalProfileID):                     "ORIGINAL_PROFILE_ID",
+	GUID(FieldPeerName):                              "PEER_NAME",
+	GUID(FieldPipe):                                  "PIPE",
+	GUID(FieldProcessWithRPCIfUUID):                  "PROCESS_WITH_RPC_IF_UUID",
+	GUID(FieldQMMode):                                "QM_MODE",
+	GUID(FieldReauthorizeReason):                     "REAUTHORIZE_REASON",
+	GUID(FieldRemoteID):                              "REMOTE_ID",
+	GUID(FieldRemoteUserToken):                       "REMOTE_USER_TOKEN",
+	GUID(FieldReserved0):                             "RESERVED0",
+	GUID(FieldReserved1):                             "RESERVED1",
+	GUID(FieldReserved10):                            "RESERVED10",
+	GUID(FieldReserved11):                            "RESERVED11",
+	GUID(FieldReserved12):                            "RESERVED12",
+	GUID(FieldReserved13):                            "RESERVED13",
+	GUID(FieldReserved14):                            "RESERVED14",
+	GUID(FieldReserved15):                            "RESERVED15",
+	GUID(FieldReserved2):                             "RESERVED2",
+	GUID(FieldReserved3):                             "RESERVED3",
+	GUID(FieldReserved4):                             "RESERVED4",
+	GUID(FieldReserved5):                             "RESERVED5",
+	GUID(FieldReserved6):                             "RESERVED6",
+	GUID(FieldReserved7):                             "RESERVED7",
+	GUID(FieldReserved8):                             "RESERVED8",
+	GUID(FieldReserved9):                             "RESERVED9",
+	GUID(FieldRPCAuthLevel):                          "RPC_AUTH_LEVEL",
+	GUID(FieldRPCAuthType):                           "RPC_AUTH_TYPE",
+	GUID(FieldRPCEPFlags):                            "RPC_EP_FLAGS",
+	GUID(FieldRPCEPValue):                            "RPC_EP_VALUE",
+	GUID(FieldRPCIfFlag):                             "RPC_IF_FLAG",
+	GUID(FieldRPCIfUUID):                             "RPC_IF_UUID",
+	GUID(FieldRPCIfVersion):                          "RPC_IF_VERSION",
+	GUID(FieldRPCProtocol):                           "RPC_PROTOCOL",
+	GUID(FieldRPCProxyAuthType):                      "RPC_PROXY_AUTH_TYPE",
+	GUID(FieldRPCServerName):                         "RPC_SERVER_NAME",
+	GUID(FieldRPCServerPort):                         "RPC_SERVER_PORT",
+	GUID(FieldSecEncryptAlgorithm):                   "SEC_ENCRYPT_ALGORITHM",
+	GUID(FieldSecKeySize):                            "SEC_KEY_SIZE",
+	GUID(FieldSourceInterfaceIndex):                  "SOURCE_INTERFACE_INDEX",
+	GUID(FieldSourceSubInterfaceIndex):               "SOURCE_SUB_INTERFACE_INDEX",
+	GUID(FieldSubInterfaceIndex):                     "SUB_INTERFACE_INDEX",
+	GUID(FieldTunnelType):                            "TUNNEL_TYPE",
+	GUID(FieldVLANID):                                "VLAN_ID",
+	GUID(FieldVSwitchDestinationInterfaceID):         "VSWITCH_DESTINATION_INTERFACE_ID",
+	GUID(FieldVSwitchDestinationInterfaceType):       "VSWITCH_DESTINATION_INTERFACE_TYPE",
+	GUID(FieldVSwitchDestinationVmID):                "VSWITCH_DESTINATION_VM_ID",
+	GUID(FieldVSwitchID):                             "VSWITCH_ID",
+	GUID(FieldVSwitchNetworkType):                    "VSWITCH_NETWORK_TYPE",
+	GUID(FieldVSwitchSourceInterfaceID):              "VSWITCH_SOURCE_INTERFACE_ID",
+	GUID(FieldVSwitchSourceInterfaceType):            "VSWITCH_SOURCE_INTERFACE_TYPE",
+	GUID(FieldVSwitchSourceVmID):                     "VSWITCH_SOURCE_VM_ID",
+	GUID(FieldVSwitchTenantNetworkID):                "VSWITCH_TENANT_NETWORK_ID",
+	guidKeyingModuleAuthIP:                           "KEYING_MODULE_AUTHIP",
+	guidKeyingModuleIKE:                              "KEYING_MODULE_IKE",
+	guidKeyingModuleIKEv2:                            "KEYING_MODULE_IKEV2",
+	GUID(LayerALEAuthConnectV4):                      "ALE_AUTH_CONNECT_V4",
+	GUID(LayerALEAuthConnectV4Discard):               "ALE_AUTH_CONNECT_V4_DISCARD",
+	GUID(LayerALEAuthConnectV6):                      "ALE_AUTH_CONNECT_V6",
+	GUID(LayerALEAuthConnectV6Discard):               "ALE_AUTH_CONNECT_V6_DISCARD",
+	GUID(LayerALEAuthListenV4):                       "ALE_AUTH_LISTEN_V4",
+	GUID(LayerALEAuthListenV4Discard):                "ALE_AUTH_LISTEN_V4_DISCARD",
+	GUID(LayerALEAuthListenV6):                       "ALE_AUTH_LISTEN_V6",
+	GUID(LayerALEAuthListenV6Discard):                "ALE_AUTH_LISTEN_V6_DISCARD",
+	GUID(LayerALEAuthRecvAcceptV4):                   "ALE_AUTH_RECV_ACCEPT_V4",
+	GUID(LayerALEAuthRecvAcceptV4Discard):            "ALE_AUTH_RECV_ACCEPT_V4_DISCARD",
+	GUID(LayerALEAuthRecvAcceptV6):                   "ALE_AUTH_RECV_ACCEPT_V6",
+	GUID(LayerALEAuthRecvAcceptV6Discard):            "ALE_AUTH_RECV_ACCEPT_V6_DISCARD",
+	GUID(LayerALEBindRedirectV4):                     "ALE_BIND_REDIRECT_V4",
+	GUID(LayerALEBindRedirectV6):                     "ALE_BIND_REDIRECT_V6",
+	GUID(LayerALEConnectRedirectV4):                  "ALE_CONNECT_REDIRECT_V4",
+	GUID(LayerALEConnectRedirectV6):                  "ALE_CONNECT_REDIRECT_V6",
+	GUID(LayerALEEndpointClosureV4):                  "ALE_ENDPOINT_CLOSURE_V4",
+	GUID(LayerALEEndpointClosureV6):                  "ALE_ENDPOINT_CLOSURE_V6",
+	GUID(LayerALEFlowEstablishedV4):                  "ALE_FLOW_ESTABLISHED_V4",
+	GUID(LayerALEFlowEstablishedV4Discard):           "ALE_FLOW_ESTABLISHED_V4_DISCARD",
+	GUID(LayerALEFlowEstablishedV6):                  "ALE_FLOW_ESTABLISHED_V6",
+	GUID(LayerALEFlowEstablishedV6Discard):           "ALE_FLOW_ESTABLISHED_V6_DISCARD",
+	GUID(LayerALEResourceAssignmentV4):               "ALE_RESOURCE_ASSIGNMENT_V4",
+	GUID(LayerALEResourceAssignmentV4Discard):        "ALE_RESOURCE_ASSIGNMENT_V4_DISCARD",
+	GUID(LayerALEResourceAssignmentV6):               "ALE_RESOURCE_ASSIGNMENT_V6",
+	GUID(LayerALEResourceAssignmentV6Discard):        "ALE_RESOURCE_ASSIGNMENT_V6_DISCARD",
+	GUID(LayerALEResourceReleaseV4):                  "ALE_RESOURCE_RELEASE_V4",
+	GUID(LayerALEResourceReleaseV6):                  "ALE_RESOURCE_RELEASE_V6",
+	GUID(LayerDatagramDataV4):                        "DATAGRAM_DATA_V4",
+	GUID(LayerDatagramDataV4Discard):                 "DATAGRAM_DATA_V4_DISCARD",
+	GUID(LayerDatagramDataV6):                        "DATAGRAM_DATA_V6",
+	GUID(LayerDatagramDataV6Discard):                 "DATAGRAM_DATA_V6_DISCARD",
+	GUID(LayerEgressVSwitchEthernet):                 "EGRESS_VSWITCH_ETHERNET",
+	GUID(LayerEgressVSwitchTransportV4):              "EGRESS_VSWITCH_TRANSPORT_V4",
+	GUID(LayerEgressVSwitchTransportV6):              "EGRESS_VSWITCH_TRANSPORT_V6",
+	GUID(LayerIKEExtV4):                              "IKEEXT_V4",
+	GUID(LayerIKEExtV6):                              "IKEEXT_V6",
+	GUID(LayerInboundICMPErrorV4):                    "INBOUND_ICMP_ERROR_V4",
+	GUID(LayerInboundICMPErrorV4Discard):             "INBOUND_ICMP_ERROR_V4_DISCARD",
+	GUID(LayerInboundICMPErrorV6):                    "INBOUND_ICMP_ERROR_V6",
+	GUID(LayerInboundICMPErrorV6Discard):             "INBOUND_ICMP_ERROR_V6_DISCARD",
+	GUID(LayerInboundIPPacketV4):                     "INBOUND_IPPACKET_V4",
+	GUID(LayerInboundIPPacketV4Discard):              "INBOUND_IPPACKET_V4_DISCARD",
+	GUID(LayerInboundIPPacketV6):                     "INBOUND_IPPACKET_V6",
+	GUID(LayerInboundIPPacketV6Discard):              "INBOUND_IPPACKET_V6_DISCARD",
+	GUID(LayerInboundMACFrameEthernet):               "INBOUND_MAC_FRAME_ETHERNET",
+	GUID(LayerInboundMACFrameNative):                 "INBOUND_MAC_FRAME_NATIVE",
+	GUID(LayerInboundMACFrameNativeFast):             "INBOUND_MAC_FRAME_NATIVE_FAST",
+	GUID(LayerInboundReserved2):                      "INBOUND_RESERVED2",
+	GUID(LayerInboundTransportFast):                  "INBOUND_TRANSPORT_FAST",
+	GUID(LayerInboundTransportV4):                    "INBOUND_TRANSPORT_V4",
+	GUID(LayerInboundTransportV4Discard):             "INBOUND_TRANSPORT_V4_DISCARD",
+	GUID(LayerInboundTransportV6):                    "INBOUND_TRANSPORT_V6",
+	GUID(LayerInboundTransportV6Discard):             "INBOUND_TRANSPORT_V6_DISCARD",
+	GUID(LayerIngressVSwitchEthernet):                "INGRESS_VSWITCH_ETHERNET",
+	GUID(LayerIngressVSwitchTransportV4):             "INGRESS_VSWITCH_TRANSPORT_V4",
+	GUID(LayerIngressVSwitchTransportV6):             "INGRESS_VSWITCH_TRANSPORT_V6",
+	GUID(LayerIPForwardV4):                           "IPFORWARD_V4",
+	GUID(LayerIPForwardV4Discard):                    "IPFORWARD_V4_DISCARD",
+	GUID(LayerIPForwardV6):                           "IPFORWARD_V6",
+	GUID(LayerIPForwardV6Discard):                    "IPFORWARD_V6_DISCARD",
+	GUID(LayerIPSecKMDemuxV4):                        "IPSEC_KM_DEMUX_V4",
+	GUID(LayerIPSecKMDemuxV6):                        "IPSEC_KM_DEMUX_V6",
+	GUID(LayerIPSecV4):                               "IPSEC_V4",
+	GUID(LayerIPSecV6):                               "IPSEC_V6",
+	GUID(LayerKMAuthorization):                       "KM_AUTHORIZATION",
+	GUID(LayerNameResolutionCacheV4):                 "NAME_RESOLUTION_CACHE_V4",
+	GUID(LayerNameResolutionCacheV6):                 "NAME_RESOLUTION_CACHE_V6",
+	GUID(LayerOutboundICMPErrorV4):                   "OUTBOUND_ICMP_ERROR_V4",
+	GUID(LayerOutboundICMPErrorV4Discard):            "OUTBOUND_ICMP_ERROR_V4_DISCARD",
+	GUID(LayerOutboundICMPErrorV6):                   "OUTBOUND_ICMP_ERROR_V6",
+	GUID(LayerOutboundICMPErrorV6Discard):            "OUTBOUND_ICMP_ERROR_V6_DISCARD",
+	GUID(LayerOutboundIPPacketV4):                    "OUTBOUND_IPPACKET_V4",
+	GUID(LayerOutboundIPPacketV4Discard):             "OUTBOUND_IPPACKET_V4_DISCARD",
+	GUID(LayerOutboundIPPacketV6):                    "OUTBOUND_IPPACKET_V6",
+	GUID(LayerOutboundIPPacketV6Discard):             "OUTBOUND_IPPACKET_V6_DISCARD",
+	GUID(LayerOutboundMACFrameEthernet):              "OUTBOUND_MAC_FRAME_ETHERNET",
+	GUID(LayerOutboundMACFrameNative):                "OUTBOUND_MAC_FRAME_NATIVE",
+	GUID(LayerOutboundMACFrameNativeFast):            "OUTBOUND_MAC_FRAME_NATIVE_FAST",
+	GUID(LayerOutboundTransportFast):                 "OUTBOUND_TRANSPORT_FAST",
+	GUID(LayerOutboundTransportV4):                   "OUTBOUND_TRANSPORT_V4",
+	GUID(LayerOutboundTransportV4Discard):            "OUTBOUND_TRANSPORT_V4_DISCARD",
+	GUID(LayerOutboundTransportV6):                   "OUTBOUND_TRANSPORT_V6",
+	GUID(LayerOutboundTransportV6Discard):            "OUTBOUND_TRANSPORT_V6_DISCARD",
+	GUID(LayerRPCEPMap):                              "RPC_EPMAP",
+	GUID(LayerRPCEPAdd):                              "RPC_EP_ADD",
+	GUID(LayerRPCProxyConn):                          "RPC_PROXY_CONN",
+	GUID(LayerRPCProxyIf):                            "RPC_PROXY_IF",
+	GUID(LayerRPCUM):                                 "RPC_UM",
+	GUID(LayerStreamPacketV4):                        "STREAM_PACKET_V4",
+	GUID(LayerStreamPacketV6):                        "STREAM_PACKET_V6",
+	GUID(LayerStreamV4):                              "STREAM_V4",
+	GUID(LayerStreamV4Discard):                       "STREAM_V4_DISCARD",
+	GUID(LayerStreamV6):                              "STREAM_V6",
+	GUID(LayerStreamV6Discard):                       "STREAM_V6_DISCARD",
+	guidProviderContextSecureSocketAuthIP:            "PROVIDER_CONTEXT_SECURE_SOCKET_AUTHIP",
+	guidProviderContextSecureSocketIPSec:             "PROVIDER_CONTEXT_SECURE_SOCKET_IPSEC",
+	guidProviderIKEExt:                               "PROVIDER_IKEEXT",
+	guidProviderIPSecDospConfig:                      "PROVIDER_IPSEC_DOSP_CONFIG",
+	guidProviderTCPChimneyOffload:                    "PROVIDER_TCP_CHIMNEY_OFFLOAD",
+	guidProviderTCPTemplates:                         "PROVIDER_TCP_TEMPLATES",
+	GUID(guidSublayerInspection):                     "SUBLAYER_INSPECTION",
+	GUID(guidSublayerIPSecDosp):                      "SUBLAYER_IPSEC_DOSP",
+	GUID(guidSublayerIPSecForwardOutboundTunnel):     "SUBLAYER_IPSEC_FORWARD_OUTBOUND_TUNNEL",
+	GUID(guidSublayerIPSecSecurityRealm):             "SUBLAYER_IPSEC_SECURITY_REALM",
+	GUID(guidSublayerIPSecTunnel):                    "SUBLAYER_IPSEC_TUNNEL",
+	GUID(guidSublayerLIPS):                           "SUBLAYER_LIPS",
+	GUID(guidSublayerRPCAudit):                       "SUBLAYER_RPC_AUDIT",
+	GUID(guidSublayerSecureSocket):                   "SUBLAYER_SECURE_SOCKET",
+	GUID(guidSublayerTCPChimneyOffload):              "SUBLAYER_TCP_CHIMNEY_OFFLOAD",
+	GUID(guidSublayerTCPTemplates):                   "SUBLAYER_TCP_TEMPLATES",
+	GUID(guidSublayerTeredo):                         "SUBLAYER_TEREDO",
+	GUID(guidSublayerUniversal):                      "SUBLAYER_UNIVERSAL",
 }
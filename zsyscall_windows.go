@@ -40,36 +40,100 @@ func errnoErr(e syscall.Errno) error {
 var (
 	modfwpuclnt = windows.NewLazySystemDLL("fwpuclnt.dll")
 
-	procFwpmEngineClose0               = modfwpuclnt.NewProc("FwpmEngineClose0")
-	procFwpmEngineOpen0                = modfwpuclnt.NewProc("FwpmEngineOpen0")
-	procFwpmFilterAdd0                 = modfwpuclnt.NewProc("FwpmFilterAdd0")
-	procFwpmFilterCreateEnumHandle0    = modfwpuclnt.NewProc("FwpmFilterCreateEnumHandle0")
-	procFwpmFilterDeleteByKey0         = modfwpuclnt.NewProc("FwpmFilterDeleteByKey0")
-	procFwpmFilterDestroyEnumHandle0   = modfwpuclnt.NewProc("FwpmFilterDestroyEnumHandle0")
-	procFwpmFilterEnum0                = modfwpuclnt.NewProc("FwpmFilterEnum0")
-	procFwpmFreeMemory0                = modfwpuclnt.NewProc("FwpmFreeMemory0")
-	procFwpmGetAppIdFromFileName0      = modfwpuclnt.NewProc("FwpmGetAppIdFromFileName0")
-	procFwpmLayerCreateEnumHandle0     = modfwpuclnt.NewProc("FwpmLayerCreateEnumHandle0")
-	procFwpmLayerDestroyEnumHandle0    = modfwpuclnt.NewProc("FwpmLayerDestroyEnumHandle0")
-	procFwpmLayerEnum0                 = modfwpuclnt.NewProc("FwpmLayerEnum0")
-	procFwpmNetEventCreateEnumHandle0  = modfwpuclnt.NewProc("FwpmNetEventCreateEnumHandle0")
-	procFwpmNetEventDestroyEnumHandle0 = modfwpuclnt.NewProc("FwpmNetEventDestroyEnumHandle0")
-	procFwpmNetEventEnum1              = modfwpuclnt.NewProc("FwpmNetEventEnum1")
-	procFwpmProviderAdd0               = modfwpuclnt.NewProc("FwpmProviderAdd0")
-	procFwpmProviderCreateEnumHandle0  = modfwpuclnt.NewProc("FwpmProviderCreateEnumHandle0")
-	procFwpmProviderDeleteByKey0       = modfwpuclnt.NewProc("FwpmProviderDeleteByKey0")
-	procFwpmProviderDestroyEnumHandle0 = modfwpuclnt.NewProc("FwpmProviderDestroyEnumHandle0")
-	procFwpmProviderEnum0              = modfwpuclnt.NewProc("FwpmProviderEnum0")
-	procFwpmSubLayerAdd0               = modfwpuclnt.NewProc("FwpmSubLayerAdd0")
-	procFwpmSubLayerCreateEnumHandle0  = modfwpuclnt.NewProc("FwpmSubLayerCreateEnumHandle0")
-	procFwpmSubLayerDeleteByKey0       = modfwpuclnt.NewProc("FwpmSubLayerDeleteByKey0")
-	procFwpmSubLayerDestroyEnumHandle0 = modfwpuclnt.NewProc("FwpmSubLayerDestroyEnumHandle0")
-	procFwpmSubLayerEnum0              = modfwpuclnt.NewProc("FwpmSubLayerEnum0")
-	procFwpmTransactionAbort0          = modfwpuclnt.NewProc("FwpmTransactionAbort0")
-	procFwpmTransactionBegin0          = modfwpuclnt.NewProc("FwpmTransactionBegin0")
-	procFwpmTransactionCommit0         = modfwpuclnt.NewProc("FwpmTransactionCommit0")
+	procFwpmCalloutAdd0                       = modfwpuclnt.NewProc("FwpmCalloutAdd0")
+	procFwpmCalloutCreateEnumHandle0          = modfwpuclnt.NewProc("FwpmCalloutCreateEnumHandle0")
+	procFwpmCalloutDeleteByKey0               = modfwpuclnt.NewProc("FwpmCalloutDeleteByKey0")
+	procFwpmCalloutDestroyEnumHandle0         = modfwpuclnt.NewProc("FwpmCalloutDestroyEnumHandle0")
+	procFwpmCalloutEnum0                      = modfwpuclnt.NewProc("FwpmCalloutEnum0")
+	procFwpmEngineClose0                      = modfwpuclnt.NewProc("FwpmEngineClose0")
+	procFwpmEngineOpen0                       = modfwpuclnt.NewProc("FwpmEngineOpen0")
+	procFwpmFilterAdd0                        = modfwpuclnt.NewProc("FwpmFilterAdd0")
+	procFwpmFilterCreateEnumHandle0           = modfwpuclnt.NewProc("FwpmFilterCreateEnumHandle0")
+	procFwpmFilterDeleteByKey0                = modfwpuclnt.NewProc("FwpmFilterDeleteByKey0")
+	procFwpmFilterDestroyEnumHandle0          = modfwpuclnt.NewProc("FwpmFilterDestroyEnumHandle0")
+	procFwpmFilterEnum0                       = modfwpuclnt.NewProc("FwpmFilterEnum0")
+	procFwpmFilterGetByKey0                   = modfwpuclnt.NewProc("FwpmFilterGetByKey0")
+	procFwpmFilterGetSecurityInfoByKey0       = modfwpuclnt.NewProc("FwpmFilterGetSecurityInfoByKey0")
+	procFwpmFilterSetSecurityInfoByKey0       = modfwpuclnt.NewProc("FwpmFilterSetSecurityInfoByKey0")
+	procFwpmFilterSubscribeChanges0           = modfwpuclnt.NewProc("FwpmFilterSubscribeChanges0")
+	procFwpmFilterUnsubscribeChanges0         = modfwpuclnt.NewProc("FwpmFilterUnsubscribeChanges0")
+	procFwpmFreeMemory0                       = modfwpuclnt.NewProc("FwpmFreeMemory0")
+	procFwpmGetAppIdFromFileName0             = modfwpuclnt.NewProc("FwpmGetAppIdFromFileName0")
+	procFwpmLayerCreateEnumHandle0            = modfwpuclnt.NewProc("FwpmLayerCreateEnumHandle0")
+	procFwpmLayerDestroyEnumHandle0           = modfwpuclnt.NewProc("FwpmLayerDestroyEnumHandle0")
+	procFwpmLayerEnum0                        = modfwpuclnt.NewProc("FwpmLayerEnum0")
+	procFwpmNetEventCreateEnumHandle0         = modfwpuclnt.NewProc("FwpmNetEventCreateEnumHandle0")
+	procFwpmNetEventDestroyEnumHandle0        = modfwpuclnt.NewProc("FwpmNetEventDestroyEnumHandle0")
+	procFwpmNetEventEnum1                     = modfwpuclnt.NewProc("FwpmNetEventEnum1")
+	procFwpmNetEventEnum2                     = modfwpuclnt.NewProc("FwpmNetEventEnum2")
+	procFwpmNetEventSubscribe0                = modfwpuclnt.NewProc("FwpmNetEventSubscribe0")
+	procFwpmNetEventSubscribe1                = modfwpuclnt.NewProc("FwpmNetEventSubscribe1")
+	procFwpmNetEventUnsubscribe0              = modfwpuclnt.NewProc("FwpmNetEventUnsubscribe0")
+	procFwpmProviderAdd0                      = modfwpuclnt.NewProc("FwpmProviderAdd0")
+	procFwpmProviderContextAdd0               = modfwpuclnt.NewProc("FwpmProviderContextAdd0")
+	procFwpmProviderContextCreateEnumHandle0  = modfwpuclnt.NewProc("FwpmProviderContextCreateEnumHandle0")
+	procFwpmProviderContextDeleteByKey0       = modfwpuclnt.NewProc("FwpmProviderContextDeleteByKey0")
+	procFwpmProviderContextDestroyEnumHandle0 = modfwpuclnt.NewProc("FwpmProviderContextDestroyEnumHandle0")
+	procFwpmProviderContextEnum0              = modfwpuclnt.NewProc("FwpmProviderContextEnum0")
+	procFwpmProviderContextEnum2              = modfwpuclnt.NewProc("FwpmProviderContextEnum2")
+	procFwpmProviderCreateEnumHandle0         = modfwpuclnt.NewProc("FwpmProviderCreateEnumHandle0")
+	procFwpmProviderDeleteByKey0              = modfwpuclnt.NewProc("FwpmProviderDeleteByKey0")
+	procFwpmProviderDestroyEnumHandle0        = modfwpuclnt.NewProc("FwpmProviderDestroyEnumHandle0")
+	procFwpmProviderEnum0                     = modfwpuclnt.NewProc("FwpmProviderEnum0")
+	procFwpmProviderGetSecurityInfoByKey0     = modfwpuclnt.NewProc("FwpmProviderGetSecurityInfoByKey0")
+	procFwpmProviderSetSecurityInfoByKey0     = modfwpuclnt.NewProc("FwpmProviderSetSecurityInfoByKey0")
+	procFwpmSubLayerAdd0                      = modfwpuclnt.NewProc("FwpmSubLayerAdd0")
+	procFwpmSubLayerCreateEnumHandle0         = modfwpuclnt.NewProc("FwpmSubLayerCreateEnumHandle0")
+	procFwpmSubLayerDeleteByKey0              = modfwpuclnt.NewProc("FwpmSubLayerDeleteByKey0")
+	procFwpmSubLayerDestroyEnumHandle0        = modfwpuclnt.NewProc("FwpmSubLayerDestroyEnumHandle0")
+	procFwpmSubLayerEnum0                     = modfwpuclnt.NewProc("FwpmSubLayerEnum0")
+	procFwpmSubLayerGetSecurityInfoByKey0     = modfwpuclnt.NewProc("FwpmSubLayerGetSecurityInfoByKey0")
+	procFwpmSubLayerSetSecurityInfoByKey0     = modfwpuclnt.NewProc("FwpmSubLayerSetSecurityInfoByKey0")
+	procFwpmTransactionAbort0                 = modfwpuclnt.NewProc("FwpmTransactionAbort0")
+	procFwpmTransactionBegin0                 = modfwpuclnt.NewProc("FwpmTransactionBegin0")
+	procFwpmTransactionCommit0                = modfwpuclnt.NewProc("FwpmTransactionCommit0")
 )
 
+func fwpmCalloutAdd0(engineHandle windows.Handle, callout *fwpmCallout0, sd *windows.SECURITY_DESCRIPTOR, id *uint32) (ret error) {
+	r0, _, _ := syscall.Syscall6(procFwpmCalloutAdd0.Addr(), 4, uintptr(engineHandle), uintptr(unsafe.Pointer(callout)), uintptr(unsafe.Pointer(sd)), uintptr(unsafe.Pointer(id)), 0, 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmCalloutCreateEnumHandle0(engineHandle windows.Handle, enumTemplate *struct{}, handle *windows.Handle) (ret error) {
+	r0, _, _ := syscall.Syscall(procFwpmCalloutCreateEnumHandle0.Addr(), 3, uintptr(engineHandle), uintptr(unsafe.Pointer(enumTemplate)), uintptr(unsafe.Pointer(handle)))
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmCalloutDeleteByKey0(engineHandle windows.Handle, guid *CalloutID) (ret error) {
+	r0, _, _ := syscall.Syscall(procFwpmCalloutDeleteByKey0.Addr(), 2, uintptr(engineHandle), uintptr(unsafe.Pointer(guid)), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmCalloutDestroyEnumHandle0(engineHandle windows.Handle, enumHandle windows.Handle) (ret error) {
+	r0, _, _ := syscall.Syscall(procFwpmCalloutDestroyEnumHandle0.Addr(), 2, uintptr(engineHandle), uintptr(enumHandle), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmCalloutEnum0(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries ***fwpmCallout0, numEntriesReturned *uint32) (ret error) {
+	r0, _, _ := syscall.Syscall6(procFwpmCalloutEnum0.Addr(), 5, uintptr(engineHandle), uintptr(enumHandle), uintptr(numEntriesRequested), uintptr(unsafe.Pointer(entries)), uintptr(unsafe.Pointer(numEntriesReturned)), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
 func fwpmEngineClose0(engineHandle windows.Handle) (ret error) {
 	r0, _, _ := syscall.Syscall(procFwpmEngineClose0.Addr(), 1, uintptr(engineHandle), 0, 0)
 	if r0 != 0 {
@@ -126,6 +190,46 @@ func fwpmFilterEnum0(engineHandle windows.Handle, enumHandle windows.Handle, num
 	return
 }
 
+func fwpmFilterGetByKey0(engineHandle windows.Handle, guid *RuleID, filter **fwpmFilter0) (ret error) {
+	r0, _, _ := syscall.Syscall(procFwpmFilterGetByKey0.Addr(), 3, uintptr(engineHandle), uintptr(unsafe.Pointer(guid)), uintptr(unsafe.Pointer(filter)))
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmFilterGetSecurityInfoByKey0(engineHandle windows.Handle, key *RuleID, securityInfo windows.SECURITY_INFORMATION, sidOwner **windows.SID, sidGroup **windows.SID, dacl **windows.ACL, sacl **windows.ACL, sd **windows.SECURITY_DESCRIPTOR) (ret error) {
+	r0, _, _ := syscall.Syscall9(procFwpmFilterGetSecurityInfoByKey0.Addr(), 8, uintptr(engineHandle), uintptr(unsafe.Pointer(key)), uintptr(securityInfo), uintptr(unsafe.Pointer(sidOwner)), uintptr(unsafe.Pointer(sidGroup)), uintptr(unsafe.Pointer(dacl)), uintptr(unsafe.Pointer(sacl)), uintptr(unsafe.Pointer(sd)), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmFilterSetSecurityInfoByKey0(engineHandle windows.Handle, key *RuleID, securityInfo windows.SECURITY_INFORMATION, sidOwner *windows.SID, sidGroup *windows.SID, dacl *windows.ACL, sacl *windows.ACL) (ret error) {
+	r0, _, _ := syscall.Syscall9(procFwpmFilterSetSecurityInfoByKey0.Addr(), 7, uintptr(engineHandle), uintptr(unsafe.Pointer(key)), uintptr(securityInfo), uintptr(unsafe.Pointer(sidOwner)), uintptr(unsafe.Pointer(sidGroup)), uintptr(unsafe.Pointer(dacl)), uintptr(unsafe.Pointer(sacl)), 0, 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmFilterSubscribeChanges0(engineHandle windows.Handle, subscription *fwpmFilterSubscription0, callback uintptr, context uintptr, changeHandle *windows.Handle) (ret error) {
+	r0, _, _ := syscall.Syscall6(procFwpmFilterSubscribeChanges0.Addr(), 5, uintptr(engineHandle), uintptr(unsafe.Pointer(subscription)), uintptr(callback), uintptr(context), uintptr(unsafe.Pointer(changeHandle)), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmFilterUnsubscribeChanges0(engineHandle windows.Handle, changeHandle windows.Handle) (ret error) {
+	r0, _, _ := syscall.Syscall(procFwpmFilterUnsubscribeChanges0.Addr(), 2, uintptr(engineHandle), uintptr(changeHandle), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
 func fwpmFreeMemory0(p *struct{}) {
 	syscall.Syscall(procFwpmFreeMemory0.Addr(), 1, uintptr(unsafe.Pointer(p)), 0, 0)
 	return
@@ -163,7 +267,7 @@ func fwpmLayerEnum0(engineHandle windows.Handle, enumHandle windows.Handle, numE
 	return
 }
 
-func fwpmNetEventCreateEnumHandle0(engineHandle windows.Handle, enumTemplate *struct{}, handle *windows.Handle) (ret error) {
+func fwpmNetEventCreateEnumHandle0(engineHandle windows.Handle, enumTemplate *fwpmNetEventEnumTemplate0, handle *windows.Handle) (ret error) {
 	r0, _, _ := syscall.Syscall(procFwpmNetEventCreateEnumHandle0.Addr(), 3, uintptr(engineHandle), uintptr(unsafe.Pointer(enumTemplate)), uintptr(unsafe.Pointer(handle)))
 	if r0 != 0 {
 		ret = syscall.Errno(r0)
@@ -179,7 +283,7 @@ func fwpmNetEventDestroyEnumHandle0(engineHandle windows.Handle, enumHandle wind
 	return
 }
 
-func fwpmNetEventEnum1(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries ***fwpmNetEvent1, numEntriesReturned *uint32) (ret error) {
+func fwpmNetEventEnum1(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries *uintptr, numEntriesReturned *uint32) (ret error) {
 	r0, _, _ := syscall.Syscall6(procFwpmNetEventEnum1.Addr(), 5, uintptr(engineHandle), uintptr(enumHandle), uintptr(numEntriesRequested), uintptr(unsafe.Pointer(entries)), uintptr(unsafe.Pointer(numEntriesReturned)), 0)
 	if r0 != 0 {
 		ret = syscall.Errno(r0)
@@ -187,8 +291,88 @@ func fwpmNetEventEnum1(engineHandle windows.Handle, enumHandle windows.Handle, n
 	return
 }
 
-func fwpmProviderAdd0(engineHandle windows.Handle, provider *fwpmProvider0, nilForNow *uintptr) (ret error) {
-	r0, _, _ := syscall.Syscall(procFwpmProviderAdd0.Addr(), 3, uintptr(engineHandle), uintptr(unsafe.Pointer(provider)), uintptr(unsafe.Pointer(nilForNow)))
+func fwpmNetEventEnum2(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries *uintptr, numEntriesReturned *uint32) (ret error) {
+	r0, _, _ := syscall.Syscall6(procFwpmNetEventEnum2.Addr(), 5, uintptr(engineHandle), uintptr(enumHandle), uintptr(numEntriesRequested), uintptr(unsafe.Pointer(entries)), uintptr(unsafe.Pointer(numEntriesReturned)), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmNetEventSubscribe0(engineHandle windows.Handle, subscription *fwpmNetEventSubscription0, callback uintptr, context uintptr, eventsHandle *windows.Handle) (ret error) {
+	r0, _, _ := syscall.Syscall6(procFwpmNetEventSubscribe0.Addr(), 5, uintptr(engineHandle), uintptr(unsafe.Pointer(subscription)), uintptr(callback), uintptr(context), uintptr(unsafe.Pointer(eventsHandle)), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmNetEventSubscribe1(engineHandle windows.Handle, subscription *fwpmNetEventSubscription0, callback uintptr, context uintptr, eventsHandle *windows.Handle) (ret error) {
+	r0, _, _ := syscall.Syscall6(procFwpmNetEventSubscribe1.Addr(), 5, uintptr(engineHandle), uintptr(unsafe.Pointer(subscription)), uintptr(callback), uintptr(context), uintptr(unsafe.Pointer(eventsHandle)), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmNetEventUnsubscribe0(engineHandle windows.Handle, eventsHandle windows.Handle) (ret error) {
+	r0, _, _ := syscall.Syscall(procFwpmNetEventUnsubscribe0.Addr(), 2, uintptr(engineHandle), uintptr(eventsHandle), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmProviderAdd0(engineHandle windows.Handle, provider *fwpmProvider0, sd *windows.SECURITY_DESCRIPTOR) (ret error) {
+	r0, _, _ := syscall.Syscall(procFwpmProviderAdd0.Addr(), 3, uintptr(engineHandle), uintptr(unsafe.Pointer(provider)), uintptr(unsafe.Pointer(sd)))
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmProviderContextAdd0(engineHandle windows.Handle, providerContext *fwpmProviderContext0, sd *windows.SECURITY_DESCRIPTOR, id *uint64) (ret error) {
+	r0, _, _ := syscall.Syscall6(procFwpmProviderContextAdd0.Addr(), 4, uintptr(engineHandle), uintptr(unsafe.Pointer(providerContext)), uintptr(unsafe.Pointer(sd)), uintptr(unsafe.Pointer(id)), 0, 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmProviderContextCreateEnumHandle0(engineHandle windows.Handle, enumTemplate *struct{}, handle *windows.Handle) (ret error) {
+	r0, _, _ := syscall.Syscall(procFwpmProviderContextCreateEnumHandle0.Addr(), 3, uintptr(engineHandle), uintptr(unsafe.Pointer(enumTemplate)), uintptr(unsafe.Pointer(handle)))
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmProviderContextDeleteByKey0(engineHandle windows.Handle, guid *ProviderContextID) (ret error) {
+	r0, _, _ := syscall.Syscall(procFwpmProviderContextDeleteByKey0.Addr(), 2, uintptr(engineHandle), uintptr(unsafe.Pointer(guid)), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmProviderContextDestroyEnumHandle0(engineHandle windows.Handle, enumHandle windows.Handle) (ret error) {
+	r0, _, _ := syscall.Syscall(procFwpmProviderContextDestroyEnumHandle0.Addr(), 2, uintptr(engineHandle), uintptr(enumHandle), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmProviderContextEnum0(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries ***fwpmProviderContext0, numEntriesReturned *uint32) (ret error) {
+	r0, _, _ := syscall.Syscall6(procFwpmProviderContextEnum0.Addr(), 5, uintptr(engineHandle), uintptr(enumHandle), uintptr(numEntriesRequested), uintptr(unsafe.Pointer(entries)), uintptr(unsafe.Pointer(numEntriesReturned)), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmProviderContextEnum2(engineHandle windows.Handle, enumHandle windows.Handle, numEntriesRequested uint32, entries ***fwpmProviderContext0, numEntriesReturned *uint32) (ret error) {
+	r0, _, _ := syscall.Syscall6(procFwpmProviderContextEnum2.Addr(), 5, uintptr(engineHandle), uintptr(enumHandle), uintptr(numEntriesRequested), uintptr(unsafe.Pointer(entries)), uintptr(unsafe.Pointer(numEntriesReturned)), 0)
 	if r0 != 0 {
 		ret = syscall.Errno(r0)
 	}
@@ -227,8 +411,24 @@ func fwpmProviderEnum0(engineHandle windows.Handle, enumHandle windows.Handle, n
 	return
 }
 
-func fwpmSubLayerAdd0(engineHandle windows.Handle, sublayer *fwpmSublayer0, nilForNow *uintptr) (ret error) {
-	r0, _, _ := syscall.Syscall(procFwpmSubLayerAdd0.Addr(), 3, uintptr(engineHandle), uintptr(unsafe.Pointer(sublayer)), uintptr(unsafe.Pointer(nilForNow)))
+func fwpmProviderGetSecurityInfoByKey0(engineHandle windows.Handle, key *ProviderID, securityInfo windows.SECURITY_INFORMATION, sidOwner **windows.SID, sidGroup **windows.SID, dacl **windows.ACL, sacl **windows.ACL, sd **windows.SECURITY_DESCRIPTOR) (ret error) {
+	r0, _, _ := syscall.Syscall9(procFwpmProviderGetSecurityInfoByKey0.Addr(), 8, uintptr(engineHandle), uintptr(unsafe.Pointer(key)), uintptr(securityInfo), uintptr(unsafe.Pointer(sidOwner)), uintptr(unsafe.Pointer(sidGroup)), uintptr(unsafe.Pointer(dacl)), uintptr(unsafe.Pointer(sacl)), uintptr(unsafe.Pointer(sd)), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmProviderSetSecurityInfoByKey0(engineHandle windows.Handle, key *ProviderID, securityInfo windows.SECURITY_INFORMATION, sidOwner *windows.SID, sidGroup *windows.SID, dacl *windows.ACL, sacl *windows.ACL) (ret error) {
+	r0, _, _ := syscall.Syscall9(procFwpmProviderSetSecurityInfoByKey0.Addr(), 7, uintptr(engineHandle), uintptr(unsafe.Pointer(key)), uintptr(securityInfo), uintptr(unsafe.Pointer(sidOwner)), uintptr(unsafe.Pointer(sidGroup)), uintptr(unsafe.Pointer(dacl)), uintptr(unsafe.Pointer(sacl)), 0, 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmSubLayerAdd0(engineHandle windows.Handle, sublayer *fwpmSublayer0, sd *windows.SECURITY_DESCRIPTOR) (ret error) {
+	r0, _, _ := syscall.Syscall(procFwpmSubLayerAdd0.Addr(), 3, uintptr(engineHandle), uintptr(unsafe.Pointer(sublayer)), uintptr(unsafe.Pointer(sd)))
 	if r0 != 0 {
 		ret = syscall.Errno(r0)
 	}
@@ -267,6 +467,22 @@ func fwpmSubLayerEnum0(engineHandle windows.Handle, enumHandle windows.Handle, n
 	return
 }
 
+func fwpmSubLayerGetSecurityInfoByKey0(engineHandle windows.Handle, key *SublayerID, securityInfo windows.SECURITY_INFORMATION, sidOwner **windows.SID, sidGroup **windows.SID, dacl **windows.ACL, sacl **windows.ACL, sd **windows.SECURITY_DESCRIPTOR) (ret error) {
+	r0, _, _ := syscall.Syscall9(procFwpmSubLayerGetSecurityInfoByKey0.Addr(), 8, uintptr(engineHandle), uintptr(unsafe.Pointer(key)), uintptr(securityInfo), uintptr(unsafe.Pointer(sidOwner)), uintptr(unsafe.Pointer(sidGroup)), uintptr(unsafe.Pointer(dacl)), uintptr(unsafe.Pointer(sacl)), uintptr(unsafe.Pointer(sd)), 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
+func fwpmSubLayerSetSecurityInfoByKey0(engineHandle windows.Handle, key *SublayerID, securityInfo windows.SECURITY_INFORMATION, sidOwner *windows.SID, sidGroup *windows.SID, dacl *windows.ACL, sacl *windows.ACL) (ret error) {
+	r0, _, _ := syscall.Syscall9(procFwpmSubLayerSetSecurityInfoByKey0.Addr(), 7, uintptr(engineHandle), uintptr(unsafe.Pointer(key)), uintptr(securityInfo), uintptr(unsafe.Pointer(sidOwner)), uintptr(unsafe.Pointer(sidGroup)), uintptr(unsafe.Pointer(dacl)), uintptr(unsafe.Pointer(sacl)), 0, 0)
+	if r0 != 0 {
+		ret = syscall.Errno(r0)
+	}
+	return
+}
+
 func fwpmTransactionAbort0(engineHandle windows.Handle) (ret error) {
 	r0, _, _ := syscall.Syscall(procFwpmTransactionAbort0.Addr(), 1, uintptr(engineHandle), 0, 0)
 	if r0 != 0 {